@@ -0,0 +1,45 @@
+// Copyright (c) InsightFinder Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+// Command insightfinder-emulator serves an in-memory InsightFinder API for
+// local demos. Point the provider at it with base_url or IF_BASE_URL.
+package main
+
+import (
+	"flag"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/insightfinder/terraform-provider-insightfinder/internal/emulator"
+)
+
+func main() {
+	var (
+		addr       string
+		username   string
+		licenseKey string
+		systems    string
+	)
+
+	flag.StringVar(&addr, "addr", "127.0.0.1:8080", "address to listen on")
+	flag.StringVar(&username, "username", "", "only accept this user name (default: any)")
+	flag.StringVar(&licenseKey, "license-key", "", "only accept this license key (default: any)")
+	flag.StringVar(&systems, "systems", "", "comma-separated system names to create on startup")
+	flag.Parse()
+
+	opts := emulator.Options{
+		Username:   username,
+		LicenseKey: licenseKey,
+	}
+	for _, name := range strings.Split(systems, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			opts.Systems = append(opts.Systems, name)
+		}
+	}
+
+	log.Printf("InsightFinder API emulator listening on http://%s", addr)
+	if err := http.ListenAndServe(addr, emulator.New(opts)); err != nil {
+		log.Fatal(err.Error())
+	}
+}
@@ -5,18 +5,11 @@
 ```hcl
 resource "insightfinder_log_labels" "error_whitelist" {
   project_name = "my-application-logs"
-  
-  log_label_settings = [
+
+  label_settings = [
     {
-      label_type       = "whitelist"
-      log_label_string = jsonencode([
-        {
-          type           = "fieldName"
-          keyword        = "severity=error|critical|fatal"
-          isCritical     = true
-          isHotEventOnly = false
-        }
-      ])
+      label_type = "whitelist"
+      values     = ["severity=error|critical|fatal"]
     }
   ]
 }
@@ -27,17 +20,17 @@ resource "insightfinder_log_labels" "error_whitelist" {
 ```hcl
 resource "insightfinder_log_labels" "pattern_names" {
   project_name = "my-application-logs"
-  
-  log_label_settings = [
+
+  label_settings = [
     {
-      label_type       = "patternName"
-      log_label_string = jsonencode([
+      label_type = "patternName"
+      rules = [
         {
-          type           = "fieldName"
-          keyword        = "message"
-          patternNameKey = "message"
+          type             = "fieldName"
+          keyword          = "message"
+          pattern_name_key = "message"
         }
-      ])
+      ]
     }
   ]
 }
@@ -48,16 +41,11 @@ resource "insightfinder_log_labels" "pattern_names" {
 ```hcl
 resource "insightfinder_log_labels" "training" {
   project_name = "my-application-logs"
-  
-  log_label_settings = [
+
+  label_settings = [
     {
-      label_type       = "trainingWhitelist"
-      log_label_string = jsonencode([
-        {
-          type    = "fieldName"
-          keyword = "component"
-        }
-      ])
+      label_type = "trainingWhitelist"
+      values     = ["component"]
     }
   ]
 }
@@ -68,63 +56,42 @@ resource "insightfinder_log_labels" "training" {
 ```hcl
 resource "insightfinder_log_labels" "complete" {
   project_name = "my-application-logs"
-  
-  log_label_settings = [
+
+  label_settings = [
     # Whitelist critical errors
     {
-      label_type       = "whitelist"
-      log_label_string = jsonencode([
-        {
-          type           = "fieldName"
-          keyword        = "severity=error|critical"
-          isCritical     = true
-          isHotEventOnly = false
-        },
-        {
-          type           = "fieldName"
-          keyword        = "level=ERROR|FATAL"
-          isCritical     = true
-          isHotEventOnly = true
-        }
-      ])
+      label_type = "whitelist"
+      values     = ["severity=error|critical", "level=ERROR|FATAL"]
     },
-    
+
     # Blacklist noise
     {
-      label_type       = "blacklist"
-      log_label_string = jsonencode([
-        {
-          type    = "fieldName"
-          keyword = "healthcheck|ping"
-        }
-      ])
+      label_type = "blacklist"
+      values     = ["healthcheck|ping"]
     },
-    
+
     # Training whitelist
     {
-      label_type       = "trainingWhitelist"
-      log_label_string = jsonencode([
-        {
-          type    = "fieldName"
-          keyword = "service_name"
-        },
-        {
-          type    = "fieldName"
-          keyword = "component"
-        }
-      ])
+      label_type = "trainingWhitelist"
+      values     = ["service_name", "component"]
     },
-    
+
+    # Regular expressions grouping entries into patterns
+    {
+      label_type = "patternMatchRegex"
+      values     = ["timeout after \\d+ms"]
+    },
+
     # Pattern naming
     {
-      label_type       = "patternName"
-      log_label_string = jsonencode([
+      label_type = "patternName"
+      rules = [
         {
-          type           = "fieldName"
-          keyword        = "message"
-          patternNameKey = "message"
+          type             = "fieldName"
+          keyword          = "message"
+          pattern_name_key = "message"
         }
-      ])
+      ]
     }
   ]
 }
@@ -151,16 +118,11 @@ resource "insightfinder_project" "app" {
 
 resource "insightfinder_log_labels" "app_labels" {
   project_name = insightfinder_project.app.project_name
-  
-  log_label_settings = [
+
+  label_settings = [
     {
-      label_type       = "whitelist"
-      log_label_string = jsonencode([{
-        type           = "fieldName"
-        keyword        = "severity=.*error.*|.*critical.*"
-        isCritical     = true
-        isHotEventOnly = false
-      }])
+      label_type = "whitelist"
+      values     = ["severity=.*error.*|.*critical.*"]
     }
   ]
 }
@@ -170,6 +132,6 @@ resource "insightfinder_log_labels" "app_labels" {
 
 - Log labels must be associated with an existing project
 - Multiple label types can be configured simultaneously
-- Use `jsonencode()` to properly format the label strings
+- Set `values` for label types taking strings and `rules` for label types taking rule objects
 - Field names are case-sensitive
 - Regular expressions are supported in keyword fields
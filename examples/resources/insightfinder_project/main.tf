@@ -57,13 +57,13 @@ resource "insightfinder_project" "advanced_logs" {
   
   # Email alerts
   enable_new_alert_email = true
-  email_setting = jsonencode({
-    enableIncidentDetectionEmailAlert  = true
-    enableIncidentPredictionEmailAlert = true
-    enableRootCauseEmailAlert          = true
-    emailDampeningPeriod               = 3600000
-    onlySendWithRCA                    = false
-  })
+  email_setting = {
+    enable_incident_detection_email_alert  = true
+    enable_incident_prediction_email_alert = true
+    enable_root_cause_email_alert          = true
+    email_dampening_period                 = 3600000
+    only_send_with_rca                     = false
+  }
   
   # Webhook configuration
   webhook_url = "https://hooks.example.com/incident"
@@ -0,0 +1,38 @@
+# Project Webhook Examples
+
+variable "webhook_token" {
+  description = "Bearer token the webhook receiver expects"
+  type        = string
+  sensitive   = true
+}
+
+resource "insightfinder_project" "app_logs" {
+  project_name = "app-logs"
+  system_name  = "Production"
+
+  project_creation_config = {
+    data_type          = "Log"
+    instance_type      = "PrivateCloud"
+    project_cloud_type = "PrivateCloud"
+  }
+}
+
+# Owned by the alerting team, separately from the project's modeling settings
+resource "insightfinder_project_webhook" "app_logs" {
+  project_name = insightfinder_project.app_logs.project_name
+  url          = "https://hooks.example.com/insightfinder"
+
+  headers = {
+    Authorization = "Bearer ${var.webhook_token}"
+  }
+
+  event_types = [
+    "log",
+    "detectedIncident",
+    "predictedIncident",
+    "detectedIncidentWithRC",
+  ]
+
+  blacklist_keywords = ["healthcheck"]
+  critical_keywords  = ["outage", "data loss"]
+}
@@ -0,0 +1,208 @@
+// Copyright (c) InsightFinder Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+// Package emulator implements an in-memory fake of the InsightFinder API
+// endpoints used by the provider. It keeps projects, systems, log labels,
+// ServiceNow integrations and JWT settings so that acceptance tests and
+// local demos can run without a live tenant.
+//
+// The emulator is an http.Handler; wrap it with httptest.NewServer in tests
+// or run cmd/insightfinder-emulator for a standalone server.
+package emulator
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// Options configures an emulator.
+type Options struct {
+	// Username and LicenseKey are the only credentials accepted. When empty,
+	// any non-empty user name or license key is accepted.
+	Username   string
+	LicenseKey string
+
+	// Systems are created up front, by display name.
+	Systems []string
+
+	// Projects are created up front. Their systems are created as needed.
+	Projects []Project
+}
+
+// Project seeds an existing project.
+type Project struct {
+	Name        string
+	DisplayName string
+	SystemName  string
+	DataType    string
+}
+
+// Emulator is a stateful fake of the InsightFinder API.
+type Emulator struct {
+	opts Options
+
+	mu           sync.Mutex
+	projects     map[string]*project
+	systems      []*system
+	integrations map[string]*integration
+}
+
+type system struct {
+	id        string
+	name      string
+	jwtSecret string
+	jwtType   int
+}
+
+// New returns an emulator seeded with the systems and projects in opts.
+func New(opts Options) *Emulator {
+	e := &Emulator{
+		opts:         opts,
+		projects:     make(map[string]*project),
+		integrations: make(map[string]*integration),
+	}
+
+	for _, name := range opts.Systems {
+		e.ensureSystem(name)
+	}
+	for _, p := range opts.Projects {
+		dataType := p.DataType
+		if dataType == "" {
+			dataType = "Log"
+		}
+		e.createProject(map[string]string{
+			"projectName":        p.Name,
+			"projectDisplayName": p.DisplayName,
+			"systemName":         p.SystemName,
+			"dataType":           dataType,
+			"instanceType":       "PrivateCloud",
+			"projectCloudType":   "PrivateCloud",
+		})
+	}
+
+	return e
+}
+
+// ServeHTTP routes a request to the matching endpoint after checking its
+// credentials.
+func (e *Emulator) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	user, ok := e.authenticate(r)
+	if !ok {
+		writeError(w, http.StatusUnauthorized, "Invalid user name or license key")
+		return
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	switch r.URL.Path {
+	case "/api/external/v1/watch-tower-setting":
+		switch r.Method {
+		case http.MethodGet:
+			e.getProjectSettings(w, r)
+		case http.MethodPost:
+			e.updateProjectSettings(w, r)
+		default:
+			writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		}
+	case "/api/v1/check-and-add-custom-project":
+		e.handleCreateProject(w, r)
+	case "/api/v1/delete-project":
+		e.handleDeleteProject(w, r)
+	case "/api/external/v1/projectkeywords":
+		e.getProjectKeywords(w, r)
+	case "/api/external/v1/systemframework":
+		switch r.Method {
+		case http.MethodGet:
+			e.getSystemFramework(w, user)
+		case http.MethodPost:
+			e.updateSystemFramework(w, r, user)
+		default:
+			writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		}
+	case "/api/external/v1/service-integration":
+		switch r.Method {
+		case http.MethodGet:
+			e.getIntegration(w, r)
+		case http.MethodPost:
+			e.updateIntegration(w, r)
+		default:
+			writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		}
+	default:
+		writeError(w, http.StatusNotFound, "unknown endpoint "+r.URL.Path)
+	}
+}
+
+// authenticate returns the user a request is made for. Credentials come
+// from the X-User-Name and X-API-Key headers, or from the userName and
+// licenseKey form fields.
+func (e *Emulator) authenticate(r *http.Request) (string, bool) {
+	user := r.Header.Get("X-User-Name")
+	key := r.Header.Get("X-API-Key")
+	if user == "" || key == "" {
+		if err := r.ParseForm(); err == nil {
+			user = r.PostForm.Get("userName")
+			key = r.PostForm.Get("licenseKey")
+		}
+	}
+
+	if user == "" || key == "" {
+		return "", false
+	}
+	if e.opts.Username != "" && user != e.opts.Username {
+		return "", false
+	}
+	if e.opts.LicenseKey != "" && key != e.opts.LicenseKey {
+		return "", false
+	}
+	return user, true
+}
+
+// systemByName finds a system by display name or ID, ignoring case.
+func (e *Emulator) systemByName(name string) *system {
+	name = strings.TrimSpace(name)
+	for _, s := range e.systems {
+		if strings.EqualFold(s.name, name) || strings.EqualFold(s.id, name) {
+			return s
+		}
+	}
+	return nil
+}
+
+// ensureSystem returns the system called name, creating it first if needed.
+// IDs are hashes of the name, like the opaque IDs the API hands out.
+func (e *Emulator) ensureSystem(name string) *system {
+	if s := e.systemByName(name); s != nil {
+		return s
+	}
+
+	sum := sha256.Sum256([]byte(name))
+	s := &system{
+		id:   hex.EncodeToString(sum[:16]),
+		name: name,
+	}
+	e.systems = append(e.systems, s)
+	return s
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, map[string]interface{}{
+		"success": false,
+		"message": message,
+	})
+}
+
+func writeSuccess(w http.ResponseWriter) {
+	writeJSON(w, http.StatusOK, map[string]interface{}{"success": true})
+}
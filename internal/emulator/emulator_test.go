@@ -0,0 +1,265 @@
+// Copyright (c) InsightFinder Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package emulator
+
+import (
+	"context"
+	"errors"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/insightfinder/terraform-provider-insightfinder/internal/provider/client"
+)
+
+// newTestClient starts an emulator with opts and returns a client for it.
+func newTestClient(t *testing.T, opts Options) *client.Client {
+	t.Helper()
+
+	server := httptest.NewServer(New(opts))
+	t.Cleanup(server.Close)
+
+	c, err := client.NewClient(server.URL, "test_user", "test_key")
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	c.Retry.MaxAttempts = 1
+	return c
+}
+
+func TestEmulatorAuthentication(t *testing.T) {
+	c := newTestClient(t, Options{Username: "test_user", LicenseKey: "other_key"})
+
+	_, err := c.GetSystemFramework(context.Background(), "test_user", true)
+	if !errors.Is(err, client.ErrUnauthorized) {
+		t.Errorf("Expected ErrUnauthorized, got: %v", err)
+	}
+}
+
+func TestEmulatorProjectLifecycle(t *testing.T) {
+	c := newTestClient(t, Options{})
+	ctx := context.Background()
+
+	project := &client.ProjectConfig{
+		ProjectName:        "emulated-project",
+		ProjectDisplayName: "Emulated Project",
+		SystemName:         "emulated-system",
+		DataType:           "Log",
+		InstanceType:       "PrivateCloud",
+		ProjectCloudType:   "PrivateCloud",
+	}
+	if err := c.CreateProject(ctx, project); err != nil {
+		t.Fatalf("Expected no error creating project, got: %v", err)
+	}
+
+	if err := c.CreateProject(ctx, project); !errors.Is(err, client.ErrAlreadyExists) {
+		t.Errorf("Expected ErrAlreadyExists creating a duplicate project, got: %v", err)
+	}
+
+	got, err := c.GetProject(ctx, "emulated-project", "test_user")
+	if err != nil {
+		t.Fatalf("Expected no error reading project, got: %v", err)
+	}
+	if got.ProjectDisplayName != "Emulated Project" {
+		t.Errorf("Expected display name 'Emulated Project', got '%s'", got.ProjectDisplayName)
+	}
+
+	err = c.UpdateProject(ctx, &client.ProjectConfig{
+		ProjectName: "emulated-project",
+		Settings:    map[string]interface{}{"cValue": 3, "projectTimeZone": "America/New_York"},
+	})
+	if err != nil {
+		t.Fatalf("Expected no error updating project, got: %v", err)
+	}
+
+	got, err = c.GetProject(ctx, "emulated-project", "test_user")
+	if err != nil {
+		t.Fatalf("Expected no error reading project, got: %v", err)
+	}
+	if got.CValue != 3 || got.Settings["projectTimeZone"] != "America/New_York" {
+		t.Errorf("Expected updated settings, got: %v", got.Settings)
+	}
+
+	// Creating a project also creates its system
+	ids, err := c.ResolveSystemNameToIDs(ctx, []string{"emulated-system"}, "test_user")
+	if err != nil || len(ids) != 1 {
+		t.Fatalf("Expected system to be created with project, got %v, %v", ids, err)
+	}
+
+	if err := c.DeleteProject(ctx, "emulated-project"); err != nil {
+		t.Fatalf("Expected no error deleting project, got: %v", err)
+	}
+	if _, err := c.GetProject(ctx, "emulated-project", "test_user"); !errors.Is(err, client.ErrNotFound) {
+		t.Errorf("Expected ErrNotFound after delete, got: %v", err)
+	}
+	if err := c.DeleteProject(ctx, "emulated-project"); !errors.Is(err, client.ErrNotFound) {
+		t.Errorf("Expected ErrNotFound deleting a missing project, got: %v", err)
+	}
+}
+
+func TestEmulatorPartialSettings(t *testing.T) {
+	c := newTestClient(t, Options{Projects: []Project{{Name: "email-project", SystemName: "email-system"}}})
+	ctx := context.Background()
+
+	err := c.UpdateProject(ctx, &client.ProjectConfig{
+		ProjectName: "email-project",
+		Settings: map[string]interface{}{
+			"emailSetting": map[string]interface{}{"onlySendWithRCA": false, "emailDampeningPeriod": 3600, "awSeverityLevel": "Major"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	// The emulator, like the API, replaces the whole object; the client
+	// merges the partial one into the current object first
+	err = c.UpdateProject(ctx, &client.ProjectConfig{
+		ProjectName:     "email-project",
+		Settings:        map[string]interface{}{"emailSetting": map[string]interface{}{"onlySendWithRCA": true}},
+		PartialSettings: []string{"emailSetting"},
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	project, err := c.GetProject(ctx, "email-project", "test_user")
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	emailSetting, _ := project.Settings["emailSetting"].(map[string]interface{})
+	if emailSetting["onlySendWithRCA"] != true {
+		t.Errorf("Expected onlySendWithRCA to be updated, got %v", emailSetting)
+	}
+	if emailSetting["emailDampeningPeriod"] != float64(3600) || emailSetting["awSeverityLevel"] != "Major" {
+		t.Errorf("Expected unset email settings to survive, got %v", emailSetting)
+	}
+}
+
+func TestEmulatorLogLabels(t *testing.T) {
+	c := newTestClient(t, Options{Projects: []Project{{Name: "labels-project", SystemName: "labels-system"}}})
+	ctx := context.Background()
+
+	err := c.CreateOrUpdateLogLabels(ctx, "labels-project", "test_user", []*client.LogLabelSetting{
+		{LabelType: "whitelist", LogLabelString: `["ERROR","FATAL"]`},
+		{LabelType: "blacklist", LogLabelString: `["DEBUG"]`},
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	labels, err := c.GetLogLabels(ctx, "labels-project", "test_user")
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if labels["whitelist"] != `["ERROR","FATAL"]` {
+		t.Errorf("Unexpected whitelist: %s", labels["whitelist"])
+	}
+	if labels["trainingBlacklistLabels"] != `["DEBUG"]` {
+		t.Errorf("Expected blacklist under trainingBlacklistLabels, got: %v", labels)
+	}
+
+	if _, err := c.GetLogLabels(ctx, "missing-project", "test_user"); !errors.Is(err, client.ErrNotFound) {
+		t.Errorf("Expected ErrNotFound for unknown project, got: %v", err)
+	}
+}
+
+func TestEmulatorJWTConfig(t *testing.T) {
+	c := newTestClient(t, Options{Systems: []string{"jwt-system"}})
+	c.SystemCacheTTL = 0
+	ctx := context.Background()
+
+	err := c.CreateOrUpdateJWTConfig(ctx, &client.JWTConfig{SystemName: "jwt-system", JWTSecret: "s3cret", JWTType: 1}, "test_user")
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	jwt, err := c.GetJWTConfig(ctx, "jwt-system", "test_user")
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if jwt.JWTSecret != "s3cret" || jwt.SystemName != "jwt-system" {
+		t.Errorf("Unexpected JWT config: %+v", jwt)
+	}
+
+	if _, err := c.GetJWTConfig(ctx, "missing-system", "test_user"); !errors.Is(err, client.ErrNotFound) {
+		t.Errorf("Expected ErrNotFound for unknown system, got: %v", err)
+	}
+}
+
+func TestEmulatorServiceNow(t *testing.T) {
+	c := newTestClient(t, Options{Systems: []string{"system1", "system2"}})
+	ctx := context.Background()
+
+	ids, err := c.ResolveSystemNameToIDs(ctx, []string{"system1", "system2"}, "test_user")
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	config := &client.ServiceNowConfig{
+		Account:         "account",
+		ServiceHost:     "example.service-now.com",
+		Password:        "pw",
+		DampeningPeriod: 30,
+		SystemIDs:       ids,
+		Options:         []string{"send_incident"},
+		ContentOption:   []string{"root_cause"},
+	}
+	if err := c.CreateOrUpdateServiceNowConfig(ctx, config, "test_user", false); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	got, err := c.GetServiceNowConfig(ctx, "account", "example.service-now.com", "test_user")
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if got.DampeningPeriod != 30 || got.AuthType != "basic" || len(got.SystemNames) != 2 || got.SystemNames[1] != "system2" {
+		t.Errorf("Unexpected ServiceNow config: %+v", got)
+	}
+
+	if err := c.DeleteServiceNowConfig(ctx, "account", "example.service-now.com", "test_user"); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if _, err := c.GetServiceNowConfig(ctx, "account", "example.service-now.com", "test_user"); !errors.Is(err, client.ErrNotFound) {
+		t.Errorf("Expected ErrNotFound after delete, got: %v", err)
+	}
+}
+
+func TestEmulatorWebhook(t *testing.T) {
+	c := newTestClient(t, Options{Projects: []Project{{Name: "webhook-project", SystemName: "webhook-system"}}})
+	ctx := context.Background()
+
+	if _, err := c.GetWebhookConfig(ctx, "webhook-project", "test_user"); !errors.Is(err, client.ErrNotFound) {
+		t.Errorf("Expected ErrNotFound before a webhook is configured, got: %v", err)
+	}
+
+	dampening := 15
+	err := c.UpdateWebhookConfig(ctx, &client.WebhookConfig{
+		ProjectName:      "webhook-project",
+		URL:              "https://hooks.example.com",
+		Headers:          map[string]string{"Authorization": "Bearer token"},
+		EventTypes:       []string{"log", "detectedIncident"},
+		CriticalKeywords: []string{"outage"},
+		AlertDampening:   &dampening,
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	webhook, err := c.GetWebhookConfig(ctx, "webhook-project", "test_user")
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if webhook.URL != "https://hooks.example.com" || webhook.Headers["Authorization"] != "Bearer token" || *webhook.AlertDampening != 15 {
+		t.Errorf("Unexpected webhook: %+v", webhook)
+	}
+	if len(webhook.EventTypes) != 2 || webhook.EventTypes[0] != "detectedIncident" || len(webhook.CriticalKeywords) != 1 {
+		t.Errorf("Unexpected webhook keywords: %+v", webhook)
+	}
+
+	if err := c.DeleteWebhookConfig(ctx, "webhook-project"); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if _, err := c.GetWebhookConfig(ctx, "webhook-project", "test_user"); !errors.Is(err, client.ErrNotFound) {
+		t.Errorf("Expected ErrNotFound after delete, got: %v", err)
+	}
+}
@@ -0,0 +1,148 @@
+// Copyright (c) InsightFinder Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package emulator
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// integration is a stored ServiceNow integration.
+type integration struct {
+	account         string
+	serviceHost     string
+	password        string
+	proxy           string
+	dampeningPeriod int
+	appID           string
+	appKey          string
+	authType        string
+	systemIDs       []string
+	options         []string
+	contentOption   []string
+}
+
+// integrationKey builds the service ID the API uses for a ServiceNow
+// integration.
+func integrationKey(account, serviceHost string) string {
+	return "ServiceNow:" + account + ":" + serviceHost
+}
+
+// getIntegration implements GET /api/external/v1/service-integration with
+// operation=display. A missing integration is an empty object.
+func (e *Emulator) getIntegration(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	if query.Get("serviceProvider") != "ServiceNow" || query.Get("operation") != "display" {
+		writeError(w, http.StatusBadRequest, "unsupported operation")
+		return
+	}
+
+	key := integrationKey(query.Get("account"), query.Get("service_host"))
+	in, ok := e.integrations[key]
+	if !ok {
+		writeJSON(w, http.StatusOK, map[string]interface{}{})
+		return
+	}
+
+	systemNames := make([]string, 0, len(in.systemIDs))
+	for _, id := range in.systemIDs {
+		if s := e.systemByName(id); s != nil {
+			systemNames = append(systemNames, s.name)
+		}
+	}
+
+	// Nested settings are JSON strings, as returned by the API
+	config, err := json.Marshal(map[string]interface{}{
+		"systemIds":     in.systemIDs,
+		"systemNames":   systemNames,
+		"contentOption": in.contentOption,
+	})
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	options, err := json.Marshal(in.options)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"key":                         key,
+		"account":                     in.account,
+		"service_host":                in.serviceHost,
+		"password":                    in.password,
+		"proxy":                       in.proxy,
+		"dampeningPeriod":             in.dampeningPeriod,
+		"appId":                       in.appID,
+		"appKey":                      in.appKey,
+		"authType":                    strings.ToUpper(in.authType),
+		"serviceNowIntegrationConfig": string(config),
+		"options":                     string(options),
+	})
+}
+
+// updateIntegration implements POST /api/external/v1/service-integration,
+// which either creates or replaces a ServiceNow integration
+// (operation=ServiceNow) or removes one (operation=delete).
+func (e *Emulator) updateIntegration(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid form: "+err.Error())
+		return
+	}
+	form := r.PostForm
+
+	switch form.Get("operation") {
+	case "delete":
+		delete(e.integrations, form.Get("service_id"))
+		writeSuccess(w)
+	case "ServiceNow":
+		in := &integration{
+			account:     form.Get("account"),
+			serviceHost: strings.TrimSpace(form.Get("service_host")),
+			password:    form.Get("password"),
+			proxy:       form.Get("proxy"),
+			appID:       form.Get("appId"),
+			appKey:      form.Get("appKey"),
+			authType:    form.Get("auth_type"),
+		}
+		if in.account == "" || in.serviceHost == "" {
+			writeError(w, http.StatusBadRequest, "account and service_host are required")
+			return
+		}
+
+		if v := form.Get("dampeningPeriod"); v != "" {
+			period, err := strconv.Atoi(v)
+			if err != nil {
+				writeError(w, http.StatusBadRequest, "invalid dampeningPeriod")
+				return
+			}
+			in.dampeningPeriod = period
+		}
+
+		for field, target := range map[string]*[]string{
+			"systemIds":     &in.systemIDs,
+			"options":       &in.options,
+			"contentOption": &in.contentOption,
+		} {
+			if err := json.Unmarshal([]byte(form.Get(field)), target); err != nil {
+				writeError(w, http.StatusBadRequest, "invalid "+field)
+				return
+			}
+		}
+		for _, id := range in.systemIDs {
+			if e.systemByName(id) == nil {
+				writeError(w, http.StatusBadRequest, "unknown system "+id)
+				return
+			}
+		}
+
+		e.integrations[integrationKey(in.account, in.serviceHost)] = in
+		writeSuccess(w)
+	default:
+		writeError(w, http.StatusBadRequest, "unsupported operation")
+	}
+}
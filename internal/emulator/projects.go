@@ -0,0 +1,249 @@
+// Copyright (c) InsightFinder Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package emulator
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// project is a stored project with its watch-tower settings and log labels.
+type project struct {
+	name     string
+	systemID string
+	settings map[string]interface{}
+
+	// keywords holds log labels by API field, e.g. "whitelist" or
+	// "trainingBlacklistLabels".
+	keywords map[string][]interface{}
+}
+
+// settingOperations are keys of a watch-tower-setting update that trigger an
+// operation instead of being stored as settings.
+var settingOperations = map[string]bool{
+	"logLabelSettingCreate": true,
+	"logLabelSettingRemove": true,
+	"logToMetricCreate":     true,
+	"logToMetricDelete":     true,
+	"logJsonTypeUpdate":     true,
+}
+
+// labelFields maps the label types of logLabelSettingCreate to the keys
+// projectkeywords returns their labels under. The table is kept separate from
+// the provider's own so that the emulator checks the provider against the
+// API's field names rather than against itself.
+var labelFields = map[string]string{
+	"trainingWhitelist":   "trainingWhitelist",
+	"featurelist":         "featurelist",
+	"incidentlist":        "incidentlist",
+	"triagelist":          "triagelist",
+	"patternName":         "patternNameLabels",
+	"whitelist":           "whitelist",
+	"blacklist":           "trainingBlacklistLabels",
+	"patternSignature":    "patternSignatureLabels",
+	"patternMatchRegex":   "patternMatchRegexLabels",
+	"patternIgnoreRegex":  "patternIgnoreRegexLabels",
+	"customAction":        "customActionLabels",
+	"logEventID":          "logEventIDLabels",
+	"logSeverity":         "logSeverityLabels",
+	"logStatusCode":       "logStatusCodeLabels",
+	"alertEventType":      "alertEventTypeLabels",
+	"anomalyFeature":      "anomalyFeatureLabels",
+	"dataFilter":          "dataFilterLabels",
+	"instanceName":        "instanceNameLabels",
+	"dataQualityCheck":    "dataQualityCheckLabels",
+	"extractionBlacklist": "extractionBlacklist",
+}
+
+// createProject stores a new project from check-and-add-custom-project form
+// fields, creating its system if needed. It returns false when a project
+// with the same name already exists.
+func (e *Emulator) createProject(fields map[string]string) bool {
+	name := fields["projectName"]
+	if _, exists := e.projects[name]; exists {
+		return false
+	}
+
+	systemName := fields["systemName"]
+	if systemName == "" {
+		systemName = name
+	}
+	sys := e.ensureSystem(systemName)
+
+	displayName := fields["projectDisplayName"]
+	if displayName == "" {
+		displayName = name
+	}
+
+	// A freshly created project comes back with the API defaults
+	e.projects[name] = &project{
+		name:     name,
+		systemID: sys.id,
+		settings: map[string]interface{}{
+			"projectName":        name,
+			"projectDisplayName": displayName,
+			"dataType":           fields["dataType"],
+			"instanceType":       fields["instanceType"],
+			"cloudType":          fields["projectCloudType"],
+			"cValue":             5,
+			"pValue":             0.95,
+			"projectTimeZone":    "UTC",
+			"samplingInterval":   600,
+		},
+		keywords: make(map[string][]interface{}),
+	}
+	return true
+}
+
+// handleCreateProject implements POST /api/v1/check-and-add-custom-project.
+func (e *Emulator) handleCreateProject(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	if err := r.ParseForm(); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid form: "+err.Error())
+		return
+	}
+
+	fields := make(map[string]string)
+	for _, key := range []string{"projectName", "projectDisplayName", "systemName", "dataType", "instanceType", "projectCloudType"} {
+		fields[key] = strings.TrimSpace(r.PostForm.Get(key))
+	}
+	if r.PostForm.Get("operation") != "create" {
+		writeError(w, http.StatusBadRequest, "unsupported operation")
+		return
+	}
+	if fields["projectName"] == "" || fields["dataType"] == "" {
+		writeError(w, http.StatusBadRequest, "projectName and dataType are required")
+		return
+	}
+
+	if !e.createProject(fields) {
+		writeError(w, http.StatusBadRequest, "Project name already existed")
+		return
+	}
+	writeSuccess(w)
+}
+
+// handleDeleteProject implements POST /api/v1/delete-project. The project's
+// system is kept, as it may hold other projects and settings.
+func (e *Emulator) handleDeleteProject(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	if err := r.ParseForm(); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid form: "+err.Error())
+		return
+	}
+
+	name := r.PostForm.Get("projectName")
+	if _, ok := e.projects[name]; !ok {
+		writeError(w, http.StatusNotFound, "Project not found")
+		return
+	}
+	delete(e.projects, name)
+	writeSuccess(w)
+}
+
+// getProjectSettings implements GET /api/external/v1/watch-tower-setting.
+// Each requested project that exists is returned as a JSON string holding
+// its settings under DATA, like the real endpoint.
+func (e *Emulator) getProjectSettings(w http.ResponseWriter, r *http.Request) {
+	var projectList []struct {
+		CustomerName string `json:"customerName"`
+		ProjectName  string `json:"projectName"`
+	}
+	if err := json.Unmarshal([]byte(r.URL.Query().Get("projectList")), &projectList); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid projectList")
+		return
+	}
+
+	settingList := make(map[string]string)
+	for _, item := range projectList {
+		p, ok := e.projects[item.ProjectName]
+		if !ok {
+			continue
+		}
+		encoded, err := json.Marshal(map[string]interface{}{"DATA": p.settings})
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		settingList[p.name] = string(encoded)
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{"settingList": settingList})
+}
+
+// updateProjectSettings implements POST /api/external/v1/watch-tower-setting.
+// Settings in the body are merged into the stored ones; a
+// logLabelSettingCreate entry replaces one label list.
+func (e *Emulator) updateProjectSettings(w http.ResponseWriter, r *http.Request) {
+	p, ok := e.projects[r.URL.Query().Get("projectName")]
+	if !ok {
+		writeError(w, http.StatusNotFound, "Project not found")
+		return
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "failed to read body")
+		return
+	}
+
+	// Like the API, a body that isn't a settings object is accepted and
+	// changes nothing
+	var update map[string]interface{}
+	if err := json.Unmarshal(body, &update); err != nil {
+		writeSuccess(w)
+		return
+	}
+
+	if create, ok := update["logLabelSettingCreate"].(map[string]interface{}); ok {
+		labelType, _ := create["labelType"].(string)
+		if labelType != "" {
+			var labels []interface{}
+			labelString, _ := create["logLabelString"].(string)
+			if err := json.Unmarshal([]byte(labelString), &labels); err != nil {
+				writeError(w, http.StatusBadRequest, "logLabelString must be a JSON array")
+				return
+			}
+
+			field, ok := labelFields[labelType]
+			if !ok {
+				field = labelType
+			}
+			if len(labels) == 0 {
+				delete(p.keywords, field)
+			} else {
+				p.keywords[field] = labels
+			}
+		}
+	}
+
+	for key, value := range update {
+		if settingOperations[key] {
+			continue
+		}
+		p.settings[key] = value
+	}
+
+	writeSuccess(w)
+}
+
+// getProjectKeywords implements GET /api/external/v1/projectkeywords. Unknown
+// projects get 204 No Content.
+func (e *Emulator) getProjectKeywords(w http.ResponseWriter, r *http.Request) {
+	p, ok := e.projects[r.URL.Query().Get("projectName")]
+	if !ok {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{"keywords": p.keywords})
+}
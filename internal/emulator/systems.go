@@ -0,0 +1,101 @@
+// Copyright (c) InsightFinder Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package emulator
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// getSystemFramework implements GET /api/external/v1/systemframework. Every
+// system is returned as a JSON string in ownSystemArr, with its settings
+// encoded again as a JSON string.
+func (e *Emulator) getSystemFramework(w http.ResponseWriter, user string) {
+	own := make([]string, 0, len(e.systems))
+	for _, s := range e.systems {
+		setting, err := json.Marshal(map[string]interface{}{
+			"systemLevelJWTSecret": s.jwtSecret,
+			"jwtType":              s.jwtType,
+		})
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+
+		encoded, err := json.Marshal(map[string]interface{}{
+			"systemKey": map[string]string{
+				"userName":        user,
+				"systemName":      s.id,
+				"environmentName": "All",
+			},
+			"systemId":          s.id,
+			"systemName":        s.name,
+			"systemDisplayName": s.name,
+			"systemSetting":     string(setting),
+			"environmentArr":    []string{"All"},
+		})
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		own = append(own, string(encoded))
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"success":        true,
+		"ownSystemArr":   own,
+		"shareSystemArr": []string{},
+	})
+}
+
+// updateSystemFramework implements POST /api/external/v1/systemframework
+// with operation=systemFrameworkSetting, which stores a system's JWT setting.
+func (e *Emulator) updateSystemFramework(w http.ResponseWriter, r *http.Request, user string) {
+	if err := r.ParseForm(); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid form: "+err.Error())
+		return
+	}
+	if r.PostForm.Get("operation") != "systemFrameworkSetting" {
+		writeError(w, http.StatusBadRequest, "unsupported operation")
+		return
+	}
+
+	var key struct {
+		UserName   string `json:"userName"`
+		SystemName string `json:"systemName"`
+	}
+	if err := json.Unmarshal([]byte(r.PostForm.Get("systemKey")), &key); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid systemKey")
+		return
+	}
+	if key.UserName != user {
+		writeError(w, http.StatusForbidden, "system belongs to another user")
+		return
+	}
+
+	var setting struct {
+		SystemLevelJWTSecret string `json:"systemLevelJWTSecret"`
+		JWTType              int    `json:"jwtType"`
+	}
+	if err := json.Unmarshal([]byte(r.PostForm.Get("systemFrameworkSetting")), &setting); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid systemFrameworkSetting")
+		return
+	}
+
+	var target *system
+	for _, s := range e.systems {
+		if s.id == key.SystemName {
+			target = s
+			break
+		}
+	}
+	if target == nil {
+		writeError(w, http.StatusNotFound, "System not found")
+		return
+	}
+
+	target.jwtSecret = setting.SystemLevelJWTSecret
+	target.jwtType = setting.JWTType
+	writeSuccess(w)
+}
@@ -0,0 +1,47 @@
+// Copyright (c) InsightFinder Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import "context"
+
+// InsightFinderAPI is the set of API operations the provider's resources and
+// data sources use. *Client implements it against the live API; tests can
+// use the in-memory fake in the clienttest package instead.
+type InsightFinderAPI interface {
+	// CustomerName returns the user whose resources are managed.
+	CustomerName() string
+
+	GetProject(ctx context.Context, projectName, username string) (*ProjectConfig, error)
+	CreateProject(ctx context.Context, project *ProjectConfig) error
+	UpdateProject(ctx context.Context, project *ProjectConfig) error
+	DeleteProject(ctx context.Context, projectName string) error
+
+	GetLogLabels(ctx context.Context, projectName, username string) (map[string]string, error)
+	CreateOrUpdateLogLabels(ctx context.Context, projectName, username string, settings []*LogLabelSetting) error
+	MergeLogLabels(ctx context.Context, projectName, username string, add, remove []*LogLabelSetting) error
+	DeleteLogLabels(ctx context.Context, projectName, username string, labelTypes []string) error
+
+	GetSystemFramework(ctx context.Context, username string, needDetail bool) (*SystemFrameworkResponse, error)
+	ResolveSystemNameToIDs(ctx context.Context, systemNames []string, username string) ([]string, error)
+	ResolveSystemIDsToNames(ctx context.Context, systemIDs []string, username string) ([]string, error)
+
+	GetJWTConfig(ctx context.Context, systemName, username string) (*JWTConfig, error)
+	CreateOrUpdateJWTConfig(ctx context.Context, config *JWTConfig, username string) error
+	DeleteJWTConfig(ctx context.Context, config *JWTConfig, username string) error
+
+	GetWebhookConfig(ctx context.Context, projectName, username string) (*WebhookConfig, error)
+	UpdateWebhookConfig(ctx context.Context, config *WebhookConfig) error
+	DeleteWebhookConfig(ctx context.Context, projectName string) error
+
+	GetServiceNowConfig(ctx context.Context, account, serviceHost, username string) (*ServiceNowConfig, error)
+	CreateOrUpdateServiceNowConfig(ctx context.Context, config *ServiceNowConfig, username string, verify bool) error
+	DeleteServiceNowConfig(ctx context.Context, account, serviceHost, username string) error
+}
+
+var _ InsightFinderAPI = &Client{}
+
+// CustomerName returns the user name the client authenticates as.
+func (c *Client) CustomerName() string {
+	return c.Username
+}
@@ -5,20 +5,53 @@ package client
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
 	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
+// DefaultRequestTimeout bounds a single API call when the caller's context
+// carries no deadline of its own.
+const DefaultRequestTimeout = 30 * time.Second
+
 // Client is the InsightFinder API client
 type Client struct {
 	BaseURL    string
 	Username   string
 	LicenseKey string
 	HTTPClient *http.Client
+
+	// RequestTimeout is applied to requests whose context has no deadline.
+	// Operations that run under a Terraform timeout use that deadline instead.
+	RequestTimeout time.Duration
+
+	// Retry controls how throttled and transient failures are retried.
+	Retry RetryPolicy
+
+	// LabelConvergenceTimeout bounds how long log label writes wait for
+	// the label lists to read back as written. Zero skips the check.
+	LabelConvergenceTimeout time.Duration
+
+	// SystemCacheTTL is how long the system framework used for system name
+	// and ID resolution is reused. Zero disables the cache.
+	SystemCacheTTL time.Duration
+
+	// limiter and inFlight are shared by every resource and data source
+	// using this client. See SetRateLimit and SetMaxConcurrentRequests.
+	limiter  *rateLimiter
+	inFlight semaphore
+
+	systems systemCache
+
+	// projectLocks serializes writes to the watch-tower settings of each
+	// project. See lockProject.
+	projectLocks keyedLocks
 }
 
 // NewClient creates a new InsightFinder API client
@@ -34,92 +67,148 @@ func NewClient(baseURL, username, licenseKey string) (*Client, error) {
 	}
 
 	return &Client{
-		BaseURL:    baseURL,
-		Username:   username,
-		LicenseKey: licenseKey,
-		HTTPClient: &http.Client{
-			Timeout: time.Second * 30,
-		},
+		BaseURL:                 baseURL,
+		Username:                username,
+		LicenseKey:              licenseKey,
+		HTTPClient:              &http.Client{},
+		RequestTimeout:          DefaultRequestTimeout,
+		Retry:                   DefaultRetryPolicy(),
+		SystemCacheTTL:          DefaultSystemCacheTTL,
+		LabelConvergenceTimeout: DefaultLabelConvergenceTimeout,
 	}, nil
 }
 
 // DoRequest performs an HTTP request with authentication headers
-func (c *Client) DoRequest(method, path string, body interface{}) ([]byte, int, error) {
-	var reqBody io.Reader
+func (c *Client) DoRequest(ctx context.Context, method, path string, body interface{}) ([]byte, int, error) {
+	var reqBody []byte
 	if body != nil {
 		jsonBody, err := json.Marshal(body)
 		if err != nil {
 			return nil, 0, fmt.Errorf("failed to marshal request body: %w", err)
 		}
-		reqBody = bytes.NewBuffer(jsonBody)
+		reqBody = jsonBody
 	}
 
-	url := fmt.Sprintf("%s%s", c.BaseURL, path)
-	req, err := http.NewRequest(method, url, reqBody)
-	if err != nil {
-		return nil, 0, fmt.Errorf("failed to create request: %w", err)
+	return c.do(ctx, method, path, reqBody, "application/json")
+}
+
+// DoFormRequest performs an HTTP request with form data
+func (c *Client) DoFormRequest(ctx context.Context, method, path string, formData url.Values) ([]byte, int, error) {
+	// Add authentication to form data
+	formData.Set("userName", c.Username)
+	formData.Set("licenseKey", c.LicenseKey)
+
+	return c.do(ctx, method, path, []byte(formData.Encode()), "application/x-www-form-urlencoded")
+}
+
+// do sends a request, retrying it according to the client's retry policy,
+// and returns the final response body and status code.
+func (c *Client) do(ctx context.Context, method, path string, body []byte, contentType string) ([]byte, int, error) {
+	idempotent := isIdempotent(ctx, method)
+
+	attempts := c.Retry.MaxAttempts
+	if attempts < 1 {
+		attempts = 1
 	}
 
-	// Add authentication headers
-	req.Header.Set("X-User-Name", c.Username)
-	req.Header.Set("X-API-Key", c.LicenseKey)
-	req.Header.Set("Content-Type", "application/json")
+	for attempt := 1; ; attempt++ {
+		respBody, statusCode, header, err := c.doOnce(ctx, method, path, body, contentType)
+		if attempt >= attempts || ctx.Err() != nil || !shouldRetry(statusCode, idempotent) {
+			return respBody, statusCode, err
+		}
 
-	resp, err := c.HTTPClient.Do(req)
-	if err != nil {
-		return nil, 0, fmt.Errorf("failed to execute request: %w", err)
+		wait := c.Retry.backoff(attempt)
+		if retryAfter, ok := parseRetryAfter(header, time.Now()); ok {
+			wait = retryAfter
+		}
+
+		// Don't start a wait the operation deadline won't let us finish;
+		// report the last response instead of a bare deadline error.
+		if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < wait {
+			return respBody, statusCode, err
+		}
+
+		tflog.Debug(ctx, "Retrying InsightFinder API request", map[string]any{
+			"method":       method,
+			"path":         path,
+			"status_code":  statusCode,
+			"attempt":      attempt + 1,
+			"max_attempts": attempts,
+			"wait":         wait.String(),
+		})
+
+		if sleepErr := sleepContext(ctx, wait); sleepErr != nil {
+			return respBody, statusCode, err
+		}
 	}
-	defer resp.Body.Close()
+}
 
-	respBody, err := io.ReadAll(resp.Body)
+// doOnce sends a single request and returns the response body, status code
+// and headers.
+func (c *Client) doOnce(ctx context.Context, method, path string, body []byte, contentType string) ([]byte, int, http.Header, error) {
+	release, err := c.acquire(ctx)
 	if err != nil {
-		return nil, resp.StatusCode, fmt.Errorf("failed to read response body: %w", err)
+		return nil, 0, nil, fmt.Errorf("waiting for request slot: %w", err)
 	}
+	defer release()
 
-	return respBody, resp.StatusCode, nil
-}
+	if _, ok := ctx.Deadline(); !ok && c.RequestTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.RequestTimeout)
+		defer cancel()
+	}
 
-// DoFormRequest performs an HTTP request with form data
-func (c *Client) DoFormRequest(method, path string, formData url.Values) ([]byte, int, error) {
-	// Add authentication to form data
-	formData.Set("userName", c.Username)
-	formData.Set("licenseKey", c.LicenseKey)
+	var reqBody io.Reader
+	if body != nil {
+		reqBody = bytes.NewReader(body)
+	}
 
 	url := fmt.Sprintf("%s%s", c.BaseURL, path)
-	req, err := http.NewRequest(method, url, bytes.NewBufferString(formData.Encode()))
+	req, err := http.NewRequestWithContext(ctx, method, url, reqBody)
 	if err != nil {
-		return nil, 0, fmt.Errorf("failed to create request: %w", err)
+		return nil, 0, nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
-	// Add authentication headers (some endpoints might use these)
+	// Add authentication headers (form endpoints also accept these)
 	req.Header.Set("X-User-Name", c.Username)
 	req.Header.Set("X-API-Key", c.LicenseKey)
-	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Content-Type", contentType)
+
+	// Field-level redaction below handles known secrets; masking the license
+	// key everywhere also covers bodies that fail to parse.
+	ctx = tflog.MaskAllFieldValuesStrings(ctx, c.LicenseKey)
+	fields := map[string]any{
+		"method": method,
+		"path":   req.URL.Path,
+		"query":  redactQuery(path),
+	}
+
+	tflog.Trace(ctx, "Sending InsightFinder API request", mergeFields(fields, map[string]any{
+		"headers": redactHeaders(req.Header),
+		"body":    redactBody(contentType, body),
+	}))
 
+	start := time.Now()
 	resp, err := c.HTTPClient.Do(req)
 	if err != nil {
-		return nil, 0, fmt.Errorf("failed to execute request: %w", err)
+		tflog.Trace(ctx, "InsightFinder API request failed", mergeFields(fields, map[string]any{
+			"latency_ms": time.Since(start).Milliseconds(),
+			"error":      err.Error(),
+		}))
+		return nil, 0, nil, fmt.Errorf("failed to execute request: %w", err)
 	}
 	defer resp.Body.Close()
 
 	respBody, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return nil, resp.StatusCode, fmt.Errorf("failed to read response body: %w", err)
+		return nil, resp.StatusCode, resp.Header, fmt.Errorf("failed to read response body: %w", err)
 	}
 
-	return respBody, resp.StatusCode, nil
-}
-
-// APIError represents an error response from the API
-type APIError struct {
-	Success   bool   `json:"success"`
-	Message   string `json:"message"`
-	ErrorCode int    `json:"errorCode,omitempty"`
-}
+	tflog.Trace(ctx, "Received InsightFinder API response", mergeFields(fields, map[string]any{
+		"status_code": resp.StatusCode,
+		"latency_ms":  time.Since(start).Milliseconds(),
+		"body":        redactBody(resp.Header.Get("Content-Type"), respBody),
+	}))
 
-func (e *APIError) Error() string {
-	if e.Message != "" {
-		return fmt.Sprintf("API error (code %d): %s", e.ErrorCode, e.Message)
-	}
-	return fmt.Sprintf("API error (code %d)", e.ErrorCode)
+	return respBody, resp.StatusCode, resp.Header, nil
 }
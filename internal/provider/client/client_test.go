@@ -4,10 +4,13 @@
 package client
 
 import (
+	"context"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
 	"testing"
+	"time"
 )
 
 func TestNewClient(t *testing.T) {
@@ -154,7 +157,7 @@ func TestDoRequest(t *testing.T) {
 			}
 
 			// Execute request
-			respBody, statusCode, err := client.DoRequest(tt.method, tt.path, tt.body)
+			respBody, statusCode, err := client.DoRequest(context.Background(), tt.method, tt.path, tt.body)
 
 			if tt.expectError {
 				if err == nil {
@@ -238,7 +241,7 @@ func TestDoFormRequest(t *testing.T) {
 			}
 
 			// Execute request
-			respBody, statusCode, err := client.DoFormRequest(tt.method, tt.path, tt.formData)
+			respBody, statusCode, err := client.DoFormRequest(context.Background(), tt.method, tt.path, tt.formData)
 
 			if tt.expectError {
 				if err == nil {
@@ -265,7 +268,39 @@ func TestClientHTTPTimeout(t *testing.T) {
 		t.Fatalf("Failed to create client: %v", err)
 	}
 
-	if client.HTTPClient.Timeout == 0 {
-		t.Error("Expected HTTP client timeout to be set")
+	if client.RequestTimeout == 0 {
+		t.Error("Expected default request timeout to be set")
+	}
+}
+
+func TestDoRequestContextCancellation(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	defer server.Close()
+	defer close(release)
+
+	client, err := NewClient(server.URL, "test_user", "test_key")
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, _, err = client.DoRequest(ctx, "GET", "/api/hang", nil)
+	if err == nil {
+		t.Fatal("Expected error when context deadline is exceeded, got nil")
+	}
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected context.DeadlineExceeded, got: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("Expected request to be cancelled promptly, took %s", elapsed)
 	}
 }
@@ -0,0 +1,554 @@
+// Copyright (c) InsightFinder Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+// Package clienttest provides an in-memory implementation of
+// client.InsightFinderAPI for unit tests of resources and data sources.
+package clienttest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/insightfinder/terraform-provider-insightfinder/internal/provider/client"
+)
+
+// System is a system known to the fake.
+type System struct {
+	ID        string
+	Name      string
+	JWTSecret string
+	JWTType   int
+}
+
+// Fake is an in-memory client.InsightFinderAPI. The exported maps hold its
+// state and may be set up or inspected directly by tests. Methods are safe
+// for concurrent use; direct field access is not.
+type Fake struct {
+	mu sync.Mutex
+
+	// User is returned by CustomerName.
+	User string
+
+	// Projects are keyed by project name. Settings hold the watch-tower
+	// settings returned by GetProject.
+	Projects map[string]*client.ProjectConfig
+
+	// LogLabels maps project names to label lists (JSON arrays) keyed by
+	// API field, as returned by GetLogLabels.
+	LogLabels map[string]map[string]string
+
+	// Systems are returned in order by the system framework.
+	Systems []*System
+
+	// ServiceNow integrations are keyed by ServiceNowKey.
+	ServiceNow map[string]*client.ServiceNowConfig
+
+	// Errors makes the named method, e.g. "GetProject", fail with the error.
+	Errors map[string]error
+
+	// Calls records the name of every method called, in order.
+	Calls []string
+}
+
+var _ client.InsightFinderAPI = &Fake{}
+
+// New returns an empty fake acting as user.
+func New(user string) *Fake {
+	return &Fake{
+		User:       user,
+		Projects:   make(map[string]*client.ProjectConfig),
+		LogLabels:  make(map[string]map[string]string),
+		ServiceNow: make(map[string]*client.ServiceNowConfig),
+		Errors:     make(map[string]error),
+	}
+}
+
+// ServiceNowKey returns the key of a ServiceNow integration in
+// Fake.ServiceNow.
+func ServiceNowKey(account, serviceHost string) string {
+	return account + "@" + serviceHost
+}
+
+// AddSystem adds a system and returns it.
+func (f *Fake) AddSystem(id, name string) *System {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	s := &System{ID: id, Name: name}
+	f.Systems = append(f.Systems, s)
+	return s
+}
+
+// Called reports whether method was called.
+func (f *Fake) Called(method string) bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for _, call := range f.Calls {
+		if call == method {
+			return true
+		}
+	}
+	return false
+}
+
+// call records a method call and returns the error configured for it. The
+// caller must hold f.mu.
+func (f *Fake) call(method string) error {
+	f.Calls = append(f.Calls, method)
+	return f.Errors[method]
+}
+
+// system finds a system by name or ID, ignoring case. The caller must hold
+// f.mu.
+func (f *Fake) system(nameOrID string) *System {
+	nameOrID = strings.TrimSpace(nameOrID)
+	for _, s := range f.Systems {
+		if strings.EqualFold(s.Name, nameOrID) || strings.EqualFold(s.ID, nameOrID) {
+			return s
+		}
+	}
+	return nil
+}
+
+// CustomerName implements client.InsightFinderAPI.
+func (f *Fake) CustomerName() string {
+	return f.User
+}
+
+// GetProject implements client.InsightFinderAPI. Display name, C and P
+// values are derived from the settings like the real client does.
+func (f *Fake) GetProject(_ context.Context, projectName, _ string) (*client.ProjectConfig, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.call("GetProject"); err != nil {
+		return nil, err
+	}
+
+	stored, ok := f.Projects[projectName]
+	if !ok {
+		return nil, fmt.Errorf("project '%s': %w", projectName, client.ErrNotFound)
+	}
+
+	project := *stored
+	project.Settings = make(map[string]interface{}, len(stored.Settings))
+	for k, v := range stored.Settings {
+		project.Settings[k] = v
+	}
+	if displayName, ok := project.Settings["projectDisplayName"].(string); ok {
+		project.ProjectDisplayName = displayName
+	}
+	if cValue, ok := project.Settings["cValue"].(float64); ok {
+		project.CValue = int(cValue)
+	}
+	if pValue, ok := project.Settings["pValue"].(float64); ok {
+		project.PValue = pValue
+	}
+	return &project, nil
+}
+
+// CreateProject implements client.InsightFinderAPI. Creating an existing
+// project fails with client.ErrAlreadyExists, like the real client.
+func (f *Fake) CreateProject(_ context.Context, project *client.ProjectConfig) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.call("CreateProject"); err != nil {
+		return err
+	}
+	if _, exists := f.Projects[project.ProjectName]; exists {
+		return fmt.Errorf("project '%s': %w", project.ProjectName, client.ErrAlreadyExists)
+	}
+
+	stored := *project
+	stored.Settings = map[string]interface{}{}
+	if project.ProjectDisplayName != "" {
+		stored.Settings["projectDisplayName"] = project.ProjectDisplayName
+	}
+	f.Projects[project.ProjectName] = &stored
+
+	if f.system(project.SystemName) == nil {
+		f.Systems = append(f.Systems, &System{ID: project.SystemName, Name: project.SystemName})
+	}
+	return nil
+}
+
+// UpdateProject implements client.InsightFinderAPI. Settings are merged
+// into the stored ones after a JSON round trip, so numbers read back as
+// float64 like they do from the API. Partial settings are merged into the
+// stored objects like the real client does.
+func (f *Fake) UpdateProject(_ context.Context, project *client.ProjectConfig) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.call("UpdateProject"); err != nil {
+		return err
+	}
+	stored, ok := f.Projects[project.ProjectName]
+	if !ok {
+		return fmt.Errorf("project '%s': %w", project.ProjectName, client.ErrNotFound)
+	}
+
+	return mergeSettings(stored, client.MergePartialSettings(project.Settings, stored.Settings, project.PartialSettings))
+}
+
+// mergeSettings merges settings into the stored project after a JSON round
+// trip.
+func mergeSettings(stored *client.ProjectConfig, settings map[string]interface{}) error {
+	encoded, err := json.Marshal(settings)
+	if err != nil {
+		return err
+	}
+	var decoded map[string]interface{}
+	if err := json.Unmarshal(encoded, &decoded); err != nil {
+		return err
+	}
+	for k, v := range decoded {
+		stored.Settings[k] = v
+	}
+	return nil
+}
+
+// GetWebhookConfig implements client.InsightFinderAPI.
+func (f *Fake) GetWebhookConfig(_ context.Context, projectName, _ string) (*client.WebhookConfig, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.call("GetWebhookConfig"); err != nil {
+		return nil, err
+	}
+	stored, ok := f.Projects[projectName]
+	if !ok {
+		return nil, fmt.Errorf("project '%s': %w", projectName, client.ErrNotFound)
+	}
+
+	config := client.WebhookConfigFromSettings(projectName, stored.Settings)
+	if config.URL == "" {
+		return nil, fmt.Errorf("webhook for project '%s': %w", projectName, client.ErrNotFound)
+	}
+	return config, nil
+}
+
+// UpdateWebhookConfig implements client.InsightFinderAPI.
+func (f *Fake) UpdateWebhookConfig(_ context.Context, config *client.WebhookConfig) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.call("UpdateWebhookConfig"); err != nil {
+		return err
+	}
+	stored, ok := f.Projects[config.ProjectName]
+	if !ok {
+		return fmt.Errorf("project '%s': %w", config.ProjectName, client.ErrNotFound)
+	}
+	return mergeSettings(stored, config.Settings())
+}
+
+// DeleteWebhookConfig implements client.InsightFinderAPI.
+func (f *Fake) DeleteWebhookConfig(_ context.Context, projectName string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.call("DeleteWebhookConfig"); err != nil {
+		return err
+	}
+	stored, ok := f.Projects[projectName]
+	if !ok {
+		return fmt.Errorf("project '%s': %w", projectName, client.ErrNotFound)
+	}
+	return mergeSettings(stored, (&client.WebhookConfig{ProjectName: projectName}).Settings())
+}
+
+// DeleteProject implements client.InsightFinderAPI.
+func (f *Fake) DeleteProject(_ context.Context, projectName string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.call("DeleteProject"); err != nil {
+		return err
+	}
+	if _, ok := f.Projects[projectName]; !ok {
+		return fmt.Errorf("project '%s': %w", projectName, client.ErrNotFound)
+	}
+	delete(f.Projects, projectName)
+	delete(f.LogLabels, projectName)
+	return nil
+}
+
+// GetLogLabels implements client.InsightFinderAPI.
+func (f *Fake) GetLogLabels(_ context.Context, projectName, _ string) (map[string]string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.call("GetLogLabels"); err != nil {
+		return nil, err
+	}
+
+	labels, ok := f.LogLabels[projectName]
+	if !ok {
+		if _, exists := f.Projects[projectName]; !exists {
+			return nil, fmt.Errorf("log labels for project '%s': %w", projectName, client.ErrNotFound)
+		}
+	}
+
+	result := make(map[string]string, len(labels))
+	for k, v := range labels {
+		result[k] = v
+	}
+	return result, nil
+}
+
+// CreateOrUpdateLogLabels implements client.InsightFinderAPI. Each setting
+// replaces the label list of its type; an empty list removes it.
+func (f *Fake) CreateOrUpdateLogLabels(_ context.Context, projectName, _ string, settings []*client.LogLabelSetting) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.call("CreateOrUpdateLogLabels"); err != nil {
+		return err
+	}
+
+	f.setLogLabels(projectName, settings)
+	return nil
+}
+
+// MergeLogLabels implements client.InsightFinderAPI with
+// client.MergeLogLabelSettings.
+func (f *Fake) MergeLogLabels(_ context.Context, projectName, _ string, add, remove []*client.LogLabelSetting) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.call("MergeLogLabels"); err != nil {
+		return err
+	}
+
+	merged, err := client.MergeLogLabelSettings(f.LogLabels[projectName], add, remove)
+	if err != nil {
+		return err
+	}
+	f.setLogLabels(projectName, merged)
+	return nil
+}
+
+// setLogLabels replaces the label list of each setting; an empty list
+// removes it.
+func (f *Fake) setLogLabels(projectName string, settings []*client.LogLabelSetting) {
+	labels, ok := f.LogLabels[projectName]
+	if !ok {
+		labels = make(map[string]string)
+		f.LogLabels[projectName] = labels
+	}
+	for _, setting := range settings {
+		field := client.MapLabelTypeToAPIField(setting.LabelType)
+		if setting.LogLabelString == "" || setting.LogLabelString == "[]" {
+			delete(labels, field)
+			continue
+		}
+		labels[field] = setting.LogLabelString
+	}
+}
+
+// DeleteLogLabels implements client.InsightFinderAPI.
+func (f *Fake) DeleteLogLabels(_ context.Context, projectName, _ string, labelTypes []string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.call("DeleteLogLabels"); err != nil {
+		return err
+	}
+	for _, labelType := range labelTypes {
+		delete(f.LogLabels[projectName], client.MapLabelTypeToAPIField(labelType))
+	}
+	return nil
+}
+
+// GetSystemFramework implements client.InsightFinderAPI, encoding each
+// system the way the API does.
+func (f *Fake) GetSystemFramework(_ context.Context, username string, _ bool) (*client.SystemFrameworkResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.call("GetSystemFramework"); err != nil {
+		return nil, err
+	}
+
+	response := &client.SystemFrameworkResponse{Success: true, OwnSystemArr: []string{}}
+	for _, s := range f.Systems {
+		setting, err := json.Marshal(map[string]interface{}{
+			"systemLevelJWTSecret": s.JWTSecret,
+			"jwtType":              s.JWTType,
+		})
+		if err != nil {
+			return nil, err
+		}
+		encoded, err := json.Marshal(map[string]interface{}{
+			"systemKey":         client.SystemKey{UserName: username, SystemName: s.ID, EnvironmentName: "All"},
+			"systemId":          s.ID,
+			"systemName":        s.Name,
+			"systemDisplayName": s.Name,
+			"systemSetting":     string(setting),
+		})
+		if err != nil {
+			return nil, err
+		}
+		response.OwnSystemArr = append(response.OwnSystemArr, string(encoded))
+	}
+	return response, nil
+}
+
+// ResolveSystemNameToIDs implements client.InsightFinderAPI.
+func (f *Fake) ResolveSystemNameToIDs(_ context.Context, systemNames []string, _ string) ([]string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.call("ResolveSystemNameToIDs"); err != nil {
+		return nil, err
+	}
+
+	ids := make([]string, 0, len(systemNames))
+	var missing []string
+	for _, name := range systemNames {
+		if s := f.system(name); s != nil {
+			ids = append(ids, s.ID)
+		} else {
+			missing = append(missing, name)
+		}
+	}
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("system(s) %s: %w", strings.Join(missing, ", "), client.ErrNotFound)
+	}
+	return ids, nil
+}
+
+// ResolveSystemIDsToNames implements client.InsightFinderAPI. Unknown IDs
+// are returned unchanged.
+func (f *Fake) ResolveSystemIDsToNames(_ context.Context, systemIDs []string, _ string) ([]string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.call("ResolveSystemIDsToNames"); err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(systemIDs))
+	for _, id := range systemIDs {
+		if s := f.system(id); s != nil {
+			names = append(names, s.Name)
+		} else {
+			names = append(names, id)
+		}
+	}
+	return names, nil
+}
+
+// GetJWTConfig implements client.InsightFinderAPI.
+func (f *Fake) GetJWTConfig(_ context.Context, systemName, _ string) (*client.JWTConfig, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.call("GetJWTConfig"); err != nil {
+		return nil, err
+	}
+
+	s := f.system(systemName)
+	if s == nil {
+		return nil, fmt.Errorf("system '%s': %w", systemName, client.ErrNotFound)
+	}
+	return &client.JWTConfig{SystemName: s.Name, SystemID: s.ID, JWTSecret: s.JWTSecret, JWTType: s.JWTType}, nil
+}
+
+// CreateOrUpdateJWTConfig implements client.InsightFinderAPI.
+func (f *Fake) CreateOrUpdateJWTConfig(_ context.Context, config *client.JWTConfig, _ string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.call("CreateOrUpdateJWTConfig"); err != nil {
+		return err
+	}
+	return f.setJWT(config)
+}
+
+// DeleteJWTConfig implements client.InsightFinderAPI by clearing the secret.
+func (f *Fake) DeleteJWTConfig(_ context.Context, config *client.JWTConfig, _ string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.call("DeleteJWTConfig"); err != nil {
+		return err
+	}
+	return f.setJWT(&client.JWTConfig{SystemName: config.SystemName, SystemID: config.SystemID})
+}
+
+// setJWT stores a JWT setting on the system matching config. The caller
+// must hold f.mu.
+func (f *Fake) setJWT(config *client.JWTConfig) error {
+	target := config.SystemID
+	if target == "" {
+		target = config.SystemName
+	}
+	s := f.system(target)
+	if s == nil {
+		return fmt.Errorf("system '%s': %w", target, client.ErrNotFound)
+	}
+	s.JWTSecret = config.JWTSecret
+	s.JWTType = config.JWTType
+	return nil
+}
+
+// GetServiceNowConfig implements client.InsightFinderAPI. System names are
+// filled in from the system IDs.
+func (f *Fake) GetServiceNowConfig(_ context.Context, account, serviceHost, _ string) (*client.ServiceNowConfig, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.call("GetServiceNowConfig"); err != nil {
+		return nil, err
+	}
+
+	stored, ok := f.ServiceNow[ServiceNowKey(account, serviceHost)]
+	if !ok {
+		return nil, fmt.Errorf("ServiceNow integration: %w", client.ErrNotFound)
+	}
+
+	config := *stored
+	config.SystemNames = nil
+	for _, id := range config.SystemIDs {
+		if s := f.system(id); s != nil {
+			config.SystemNames = append(config.SystemNames, s.Name)
+		}
+	}
+	return &config, nil
+}
+
+// CreateOrUpdateServiceNowConfig implements client.InsightFinderAPI.
+func (f *Fake) CreateOrUpdateServiceNowConfig(_ context.Context, config *client.ServiceNowConfig, _ string, _ bool) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.call("CreateOrUpdateServiceNowConfig"); err != nil {
+		return err
+	}
+
+	stored := *config
+	if stored.AuthType == "" {
+		stored.AuthType = "basic"
+	}
+	f.ServiceNow[ServiceNowKey(config.Account, config.ServiceHost)] = &stored
+	return nil
+}
+
+// DeleteServiceNowConfig implements client.InsightFinderAPI.
+func (f *Fake) DeleteServiceNowConfig(_ context.Context, account, serviceHost, _ string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.call("DeleteServiceNowConfig"); err != nil {
+		return err
+	}
+	delete(f.ServiceNow, ServiceNowKey(account, serviceHost))
+	return nil
+}
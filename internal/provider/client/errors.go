@@ -0,0 +1,164 @@
+// Copyright (c) InsightFinder Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// Sentinel errors for classifying API failures. Match them with errors.Is;
+// the concrete error is usually an *APIError carrying the details.
+var (
+	ErrNotFound      = errors.New("not found")
+	ErrAlreadyExists = errors.New("already exists")
+	ErrUnauthorized  = errors.New("unauthorized")
+	ErrBadRequest    = errors.New("bad request")
+	ErrRateLimited   = errors.New("rate limited")
+	ErrServer        = errors.New("server error")
+)
+
+// maxErrorBodyLength caps how much of a non-JSON error body is kept in the
+// error message.
+const maxErrorBodyLength = 512
+
+// APIError represents an error response from the API
+type APIError struct {
+	// StatusCode is the HTTP status of the response. Calls that return 200
+	// with "success": false keep the 200.
+	StatusCode int `json:"-"`
+	// Endpoint is the method and path of the failed call, without the
+	// query string.
+	Endpoint string `json:"-"`
+
+	Success   bool   `json:"success"`
+	Message   string `json:"message"`
+	ErrorCode int    `json:"errorCode,omitempty"`
+
+	// kind is the sentinel this error matches with errors.Is, if any.
+	kind error
+}
+
+func (e *APIError) Error() string {
+	var b strings.Builder
+	b.WriteString("API error")
+	if e.Endpoint != "" {
+		fmt.Fprintf(&b, " on %s", e.Endpoint)
+	}
+	if e.StatusCode != 0 {
+		fmt.Fprintf(&b, ": HTTP %d", e.StatusCode)
+	}
+	if e.ErrorCode != 0 {
+		fmt.Fprintf(&b, " (code %d)", e.ErrorCode)
+	}
+	if e.Message != "" {
+		fmt.Fprintf(&b, ": %s", e.Message)
+	}
+	return b.String()
+}
+
+// Is reports whether the error belongs to the given sentinel category.
+func (e *APIError) Is(target error) bool {
+	return e.kind != nil && target == e.kind
+}
+
+// Unwrap returns the sentinel category, if any.
+func (e *APIError) Unwrap() error {
+	return e.kind
+}
+
+// checkResponse turns a failed API response into an *APIError. A response
+// fails when its status is not 2xx or when its JSON body reports
+// "success": false. Successful responses return nil.
+func checkResponse(method, path string, statusCode int, body []byte) error {
+	var payload struct {
+		Success   *bool  `json:"success"`
+		Message   string `json:"message"`
+		Error     string `json:"error"`
+		ErrorCode int    `json:"errorCode"`
+	}
+	parsed := json.Unmarshal(body, &payload) == nil
+
+	failed := statusCode < 200 || statusCode > 299
+	if !failed && parsed && payload.Success != nil && !*payload.Success {
+		failed = true
+	}
+	if !failed {
+		return nil
+	}
+
+	apiErr := &APIError{
+		StatusCode: statusCode,
+		Endpoint:   endpoint(method, path),
+	}
+	if parsed {
+		apiErr.Message = payload.Message
+		if apiErr.Message == "" {
+			apiErr.Message = payload.Error
+		}
+		apiErr.ErrorCode = payload.ErrorCode
+	} else {
+		apiErr.Message = truncate(strings.TrimSpace(string(body)), maxErrorBodyLength)
+	}
+	apiErr.kind = classify(statusCode, apiErr.Message)
+
+	return apiErr
+}
+
+// classify maps a status code and message to one of the sentinel errors.
+// The API reports some conditions, such as duplicate projects, only in the
+// message text. Message text is only trusted on 2xx ("success": false), 400
+// and 404 responses: a 5xx whose message mentions a missing object is still
+// a server failure, and must not make a resource drop out of state.
+func classify(statusCode int, message string) error {
+	lower := strings.ToLower(message)
+	messageNotFound := strings.Contains(lower, "not found") ||
+		strings.Contains(lower, "does not exist") ||
+		strings.Contains(lower, "doesn't exist")
+	textTrusted := statusCode < 300 || statusCode == http.StatusBadRequest || statusCode == http.StatusNotFound
+
+	switch {
+	case statusCode >= 500:
+		return ErrServer
+	case statusCode == http.StatusUnauthorized, statusCode == http.StatusForbidden:
+		return ErrUnauthorized
+	case statusCode == http.StatusTooManyRequests:
+		return ErrRateLimited
+	case strings.Contains(lower, "already exist"):
+		return ErrAlreadyExists
+	case statusCode == http.StatusNotFound, textTrusted && messageNotFound:
+		return ErrNotFound
+	case statusCode >= 400:
+		return ErrBadRequest
+	}
+	return nil
+}
+
+// notFoundError reports a missing object that the API signalled through an
+// empty or incomplete response rather than an error status.
+func notFoundError(method, path string, statusCode int, message string) error {
+	return &APIError{
+		StatusCode: statusCode,
+		Endpoint:   endpoint(method, path),
+		Message:    message,
+		kind:       ErrNotFound,
+	}
+}
+
+func endpoint(method, path string) string {
+	if i := strings.IndexByte(path, '?'); i >= 0 {
+		path = path[:i]
+	}
+	return method + " " + path
+}
+
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	return s[:n] + "..."
+}
@@ -0,0 +1,209 @@
+// Copyright (c) InsightFinder Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestCheckResponse(t *testing.T) {
+	tests := []struct {
+		name        string
+		statusCode  int
+		body        string
+		expectError bool
+		sentinel    error
+		message     string
+	}{
+		{
+			name:       "success",
+			statusCode: http.StatusOK,
+			body:       `{"success":true}`,
+		},
+		{
+			name:       "success without JSON body",
+			statusCode: http.StatusOK,
+			body:       `OK`,
+		},
+		{
+			name:        "success false",
+			statusCode:  http.StatusOK,
+			body:        `{"success":false,"message":"invalid setting","errorCode":12}`,
+			expectError: true,
+			message:     "invalid setting",
+		},
+		{
+			name:        "not found status",
+			statusCode:  http.StatusNotFound,
+			body:        ``,
+			expectError: true,
+			sentinel:    ErrNotFound,
+		},
+		{
+			name:        "not found message",
+			statusCode:  http.StatusOK,
+			body:        `{"success":false,"message":"Project does not exist"}`,
+			expectError: true,
+			sentinel:    ErrNotFound,
+		},
+		{
+			name:        "already exists",
+			statusCode:  http.StatusBadRequest,
+			body:        `{"success":false,"message":"Project name already existed"}`,
+			expectError: true,
+			sentinel:    ErrAlreadyExists,
+		},
+		{
+			name:        "unauthorized",
+			statusCode:  http.StatusUnauthorized,
+			body:        `{"error":"invalid license key"}`,
+			expectError: true,
+			sentinel:    ErrUnauthorized,
+			message:     "invalid license key",
+		},
+		{
+			name:        "forbidden",
+			statusCode:  http.StatusForbidden,
+			expectError: true,
+			sentinel:    ErrUnauthorized,
+		},
+		{
+			name:        "rate limited",
+			statusCode:  http.StatusTooManyRequests,
+			expectError: true,
+			sentinel:    ErrRateLimited,
+		},
+		{
+			name:        "server error with HTML body",
+			statusCode:  http.StatusBadGateway,
+			body:        `<html>Bad Gateway</html>`,
+			expectError: true,
+			sentinel:    ErrServer,
+			message:     "<html>Bad Gateway</html>",
+		},
+		{
+			name:        "server error mentioning not found",
+			statusCode:  http.StatusInternalServerError,
+			body:        `{"success":false,"message":"internal error: user settings not found"}`,
+			expectError: true,
+			sentinel:    ErrServer,
+			message:     "internal error: user settings not found",
+		},
+		{
+			name:        "bad request not found message",
+			statusCode:  http.StatusBadRequest,
+			body:        `{"success":false,"message":"Project doesn't exist"}`,
+			expectError: true,
+			sentinel:    ErrNotFound,
+		},
+		{
+			name:        "conflict mentioning not found",
+			statusCode:  http.StatusConflict,
+			body:        `{"success":false,"message":"dependency not found"}`,
+			expectError: true,
+			sentinel:    ErrBadRequest,
+		},
+		{
+			name:        "bad request",
+			statusCode:  http.StatusBadRequest,
+			body:        `{"success":false,"message":"missing projectName"}`,
+			expectError: true,
+			sentinel:    ErrBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkResponse("POST", "/api/test?projectName=p", tt.statusCode, []byte(tt.body))
+
+			if !tt.expectError {
+				if err != nil {
+					t.Errorf("Expected no error, got: %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatal("Expected error, got nil")
+			}
+
+			var apiErr *APIError
+			if !errors.As(err, &apiErr) {
+				t.Fatalf("Expected *APIError, got %T", err)
+			}
+			if apiErr.StatusCode != tt.statusCode {
+				t.Errorf("Expected status code %d, got %d", tt.statusCode, apiErr.StatusCode)
+			}
+			if apiErr.Endpoint != "POST /api/test" {
+				t.Errorf("Expected endpoint without query string, got '%s'", apiErr.Endpoint)
+			}
+			if tt.sentinel != nil && !errors.Is(err, tt.sentinel) {
+				t.Errorf("Expected error to match %v, got: %v", tt.sentinel, err)
+			}
+			if tt.message != "" && !strings.Contains(err.Error(), tt.message) {
+				t.Errorf("Expected error to contain '%s', got: %v", tt.message, err)
+			}
+		})
+	}
+}
+
+func TestGetProjectNotFound(t *testing.T) {
+	tests := []struct {
+		name       string
+		statusCode int
+		body       string
+	}{
+		{name: "no content", statusCode: http.StatusNoContent},
+		{name: "missing from setting list", statusCode: http.StatusOK, body: `{"settingList":{}}`},
+		{name: "not found status", statusCode: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.statusCode)
+				_, _ = w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			client, err := NewClient(server.URL, "test_user", "test_key")
+			if err != nil {
+				t.Fatalf("Failed to create client: %v", err)
+			}
+
+			project, err := client.GetProject(context.Background(), "missing-project", "test_user")
+			if !errors.Is(err, ErrNotFound) {
+				t.Errorf("Expected ErrNotFound, got: %v", err)
+			}
+			if project != nil {
+				t.Errorf("Expected nil project, got %+v", project)
+			}
+		})
+	}
+}
+
+func TestGetProjectServerError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(`{"success":false,"message":"database unavailable"}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(server.URL, "test_user", "test_key")
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	_, err = client.GetProject(context.Background(), "project", "test_user")
+	if errors.Is(err, ErrNotFound) {
+		t.Errorf("Server error must not be reported as not found: %v", err)
+	}
+	if !errors.Is(err, ErrServer) {
+		t.Errorf("Expected ErrServer, got: %v", err)
+	}
+}
@@ -4,6 +4,7 @@
 package client
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/url"
@@ -60,7 +61,7 @@ type JWTConfig struct {
 }
 
 // GetSystemFramework retrieves system framework configuration
-func (c *Client) GetSystemFramework(username string, needDetail bool) (*SystemFrameworkResponse, error) {
+func (c *Client) GetSystemFramework(ctx context.Context, username string, needDetail bool) (*SystemFrameworkResponse, error) {
 	params := url.Values{}
 	params.Add("customerName", username)
 	if needDetail {
@@ -71,7 +72,7 @@ func (c *Client) GetSystemFramework(username string, needDetail bool) (*SystemFr
 	params.Add("tzOffset", "0")
 
 	path := fmt.Sprintf("/api/external/v1/systemframework?%s", params.Encode())
-	body, statusCode, err := c.DoRequest("GET", path, nil)
+	body, statusCode, err := c.DoRequest(ctx, "GET", path, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -80,8 +81,8 @@ func (c *Client) GetSystemFramework(username string, needDetail bool) (*SystemFr
 		return nil, nil
 	}
 
-	if statusCode != 200 {
-		return nil, fmt.Errorf("failed to get system framework: HTTP %d", statusCode)
+	if err := checkResponse("GET", path, statusCode, body); err != nil {
+		return nil, fmt.Errorf("failed to get system framework: %w", err)
 	}
 
 	var response SystemFrameworkResponse
@@ -92,72 +93,29 @@ func (c *Client) GetSystemFramework(username string, needDetail bool) (*SystemFr
 	return &response, nil
 }
 
-// GetJWTConfig retrieves JWT configuration for a specific system
-func (c *Client) GetJWTConfig(systemName, username string) (*JWTConfig, error) {
+// GetJWTConfig retrieves JWT configuration for a specific system. It returns
+// an error matching ErrNotFound when the system does not exist.
+func (c *Client) GetJWTConfig(ctx context.Context, systemName, username string) (*JWTConfig, error) {
 	normalizedName := strings.TrimSpace(systemName)
 	if normalizedName == "" {
 		return nil, fmt.Errorf("system name is required to fetch JWT configuration")
 	}
 
-	resolvedIDs, err := c.ResolveSystemNameToIDs([]string{normalizedName}, username)
-	if err != nil {
-		if strings.Contains(strings.ToLower(err.Error()), "not found") {
-			return nil, nil
-		}
-		return nil, err
-	}
-
-	if len(resolvedIDs) == 0 {
-		return nil, nil
-	}
-
-	targetID := strings.TrimSpace(resolvedIDs[0])
-	if targetID == "" {
-		return nil, fmt.Errorf("system '%s' returned empty identifier", normalizedName)
-	}
-
-	response, err := c.GetSystemFramework(username, true)
+	// Name lookup, ID lookup and settings all come from one system framework
+	// download
+	idx, err := c.systemIndex(ctx, username)
 	if err != nil {
 		return nil, err
 	}
 
-	if response == nil {
-		return nil, nil // No systems found
-	}
-
-	systems := make([]string, 0, len(response.OwnSystemArr)+len(response.ShareSystemArr))
-	systems = append(systems, response.OwnSystemArr...)
-	systems = append(systems, response.ShareSystemArr...)
-
-	var matchingSystem *SystemFramework
-
-searchLoop:
-	for _, systemStr := range systems {
-		var system SystemFramework
-		if err := json.Unmarshal([]byte(systemStr), &system); err != nil {
-			continue
-		}
-
-		idCandidates := []string{
-			strings.TrimSpace(system.SystemKey.SystemName),
-			strings.TrimSpace(system.SystemID),
-			strings.TrimSpace(system.SystemName),
-		}
-
-		for _, candidate := range idCandidates {
-			if candidate == "" {
-				continue
-			}
-			if strings.EqualFold(candidate, targetID) {
-				systemCopy := system
-				matchingSystem = &systemCopy
-				break searchLoop
-			}
-		}
+	targetID, ok := idx.nameToID[strings.ToLower(normalizedName)]
+	if !ok {
+		return nil, fmt.Errorf("system '%s': %w", normalizedName, ErrNotFound)
 	}
 
+	matchingSystem := idx.find(targetID)
 	if matchingSystem == nil {
-		return nil, nil
+		return nil, fmt.Errorf("system '%s': %w", normalizedName, ErrNotFound)
 	}
 
 	var settings map[string]interface{}
@@ -168,10 +126,8 @@ searchLoop:
 	}
 
 	displayName := normalizedName
-	if resolvedNames, err := c.ResolveSystemIDsToNames([]string{targetID}, username); err == nil {
-		if len(resolvedNames) > 0 && strings.TrimSpace(resolvedNames[0]) != "" {
-			displayName = strings.TrimSpace(resolvedNames[0])
-		}
+	if name, ok := idx.idToName[targetID]; ok {
+		displayName = name
 	}
 
 	jwtConfig := &JWTConfig{
@@ -193,7 +149,7 @@ searchLoop:
 }
 
 // CreateOrUpdateJWTConfig creates or updates JWT configuration for a system
-func (c *Client) CreateOrUpdateJWTConfig(config *JWTConfig, username string) error {
+func (c *Client) CreateOrUpdateJWTConfig(ctx context.Context, config *JWTConfig, username string) error {
 	if config == nil {
 		return fmt.Errorf("config is required")
 	}
@@ -204,12 +160,12 @@ func (c *Client) CreateOrUpdateJWTConfig(config *JWTConfig, username string) err
 			return fmt.Errorf("either system_id or system_name must be provided")
 		}
 
-		ids, err := c.ResolveSystemNameToIDs([]string{trimmedName}, username)
+		ids, err := c.ResolveSystemNameToIDs(ctx, []string{trimmedName}, username)
 		if err != nil {
 			return err
 		}
 		if len(ids) == 0 {
-			return fmt.Errorf("system '%s' not found", trimmedName)
+			return fmt.Errorf("system '%s': %w", trimmedName, ErrNotFound)
 		}
 		config.SystemID = strings.TrimSpace(ids[0])
 		if config.SystemID == "" {
@@ -244,35 +200,24 @@ func (c *Client) CreateOrUpdateJWTConfig(config *JWTConfig, username string) err
 	formData.Set("systemKey", string(systemKeyJSON))
 	formData.Set("systemFrameworkSetting", string(systemFrameworkSettingJSON))
 
+	// The setting is overwritten as a whole, so repeating it is safe
 	path := "/api/external/v1/systemframework?tzOffset=0"
-	body, statusCode, err := c.DoFormRequest("POST", path, formData)
+	defer c.invalidateSystems(username)
+	body, statusCode, err := c.DoFormRequest(withIdempotent(ctx), "POST", path, formData)
 	if err != nil {
 		return err
 	}
 
-	if statusCode != 200 {
-		return fmt.Errorf("failed to configure JWT: HTTP %d - %s", statusCode, string(body))
-	}
-
-	// Check if response indicates success
-	var response map[string]interface{}
-	if err := json.Unmarshal(body, &response); err != nil {
-		// If we can't parse the response but got 200, assume success
-		return nil
-	}
-
-	if success, ok := response["success"].(bool); ok && !success {
-		if msg, ok := response["message"].(string); ok {
-			return fmt.Errorf("JWT configuration failed: %s", msg)
-		}
-		return fmt.Errorf("JWT configuration failed")
+	// A 200 with an unparseable body counts as success
+	if err := checkResponse("POST", path, statusCode, body); err != nil {
+		return fmt.Errorf("failed to configure JWT: %w", err)
 	}
 
 	return nil
 }
 
 // DeleteJWTConfig removes JWT configuration from a system
-func (c *Client) DeleteJWTConfig(config *JWTConfig, username string) error {
+func (c *Client) DeleteJWTConfig(ctx context.Context, config *JWTConfig, username string) error {
 	// To delete, we set an empty JWT secret
 	emptyConfig := &JWTConfig{
 		SystemName: config.SystemName,
@@ -280,5 +225,5 @@ func (c *Client) DeleteJWTConfig(config *JWTConfig, username string) error {
 		JWTSecret:  "",
 		JWTType:    0,
 	}
-	return c.CreateOrUpdateJWTConfig(emptyConfig, username)
+	return c.CreateOrUpdateJWTConfig(ctx, emptyConfig, username)
 }
@@ -0,0 +1,146 @@
+// Copyright (c) InsightFinder Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+)
+
+// LabelValueShape is the kind of entry the label list of a log label type
+// holds.
+type LabelValueShape int
+
+const (
+	// LabelValueObjects entries are rule objects, such as
+	// {"type": "fieldName", "keyword": "message", "patternNameKey": "message"}.
+	LabelValueObjects LabelValueShape = iota
+	// LabelValueStrings entries are plain strings, such as the keywords of a
+	// whitelist or the names of severity fields.
+	LabelValueStrings
+	// LabelValueRegex entries are strings holding regular expressions.
+	LabelValueRegex
+)
+
+// String describes the shape for documentation and diagnostics.
+func (s LabelValueShape) String() string {
+	switch s {
+	case LabelValueStrings:
+		return "strings"
+	case LabelValueRegex:
+		return "regular expressions"
+	default:
+		return "rule objects"
+	}
+}
+
+// LabelType describes a log label type.
+type LabelType struct {
+	// Name is the label type used in configuration and sent to the API.
+	Name string
+	// APIField is the key holding the label list in the project keywords
+	// returned by GetLogLabels.
+	APIField string
+	// Description says what the labels of the type do.
+	Description string
+	// Shape is the kind of entry the label list holds.
+	Shape LabelValueShape
+}
+
+// LabelTypes lists every log label type the provider manages, in the order
+// label settings read from the API are listed.
+var LabelTypes = []LabelType{
+	{Name: "trainingWhitelist", APIField: "trainingWhitelist", Description: "Log entries kept in model training", Shape: LabelValueStrings},
+	{Name: "featurelist", APIField: "featurelist", Description: "Fields extracted as features", Shape: LabelValueObjects},
+	{Name: "incidentlist", APIField: "incidentlist", Description: "Log entries reported as incidents", Shape: LabelValueObjects},
+	{Name: "triagelist", APIField: "triagelist", Description: "Log entries used for triage", Shape: LabelValueObjects},
+	{Name: "patternName", APIField: "patternNameLabels", Description: "Fields used to name log patterns", Shape: LabelValueObjects},
+	{Name: "whitelist", APIField: "whitelist", Description: "Log entries always treated as anomalies", Shape: LabelValueStrings},
+	{Name: "blacklist", APIField: "trainingBlacklistLabels", Description: "Log entries excluded from training and detection", Shape: LabelValueStrings},
+	{Name: "patternSignature", APIField: "patternSignatureLabels", Description: "Signatures identifying log patterns", Shape: LabelValueStrings},
+	{Name: "patternMatchRegex", APIField: "patternMatchRegexLabels", Description: "Expressions grouping matching entries into a pattern", Shape: LabelValueRegex},
+	{Name: "patternIgnoreRegex", APIField: "patternIgnoreRegexLabels", Description: "Expressions ignored when building patterns", Shape: LabelValueRegex},
+	{Name: "customAction", APIField: "customActionLabels", Description: "Actions triggered by matching entries", Shape: LabelValueObjects},
+	{Name: "logEventID", APIField: "logEventIDLabels", Description: "Fields holding the event ID", Shape: LabelValueStrings},
+	{Name: "logSeverity", APIField: "logSeverityLabels", Description: "Fields holding the severity", Shape: LabelValueStrings},
+	{Name: "logStatusCode", APIField: "logStatusCodeLabels", Description: "Fields holding the status code", Shape: LabelValueStrings},
+	{Name: "alertEventType", APIField: "alertEventTypeLabels", Description: "Alert event types", Shape: LabelValueStrings},
+	{Name: "anomalyFeature", APIField: "anomalyFeatureLabels", Description: "Fields reported with anomalies", Shape: LabelValueObjects},
+	{Name: "dataFilter", APIField: "dataFilterLabels", Description: "Log entries dropped on ingestion", Shape: LabelValueObjects},
+	{Name: "instanceName", APIField: "instanceNameLabels", Description: "Fields holding the instance name", Shape: LabelValueObjects},
+	{Name: "dataQualityCheck", APIField: "dataQualityCheckLabels", Description: "Data quality checks", Shape: LabelValueObjects},
+	{Name: "extractionBlacklist", APIField: "extractionBlacklist", Description: "Fields excluded from extraction", Shape: LabelValueObjects},
+}
+
+// LookupLabelType returns the log label type with the given name.
+func LookupLabelType(name string) (LabelType, bool) {
+	for _, t := range LabelTypes {
+		if t.Name == name {
+			return t, true
+		}
+	}
+	return LabelType{}, false
+}
+
+// LabelTypeForAPIField returns the log label type whose labels the project
+// keywords hold under field.
+func LabelTypeForAPIField(field string) (LabelType, bool) {
+	for _, t := range LabelTypes {
+		if t.APIField == field {
+			return t, true
+		}
+	}
+	return LabelType{}, false
+}
+
+// LabelTypeNames returns the names of all log label types.
+func LabelTypeNames() []string {
+	names := make([]string, len(LabelTypes))
+	for i, t := range LabelTypes {
+		names[i] = t.Name
+	}
+	return names
+}
+
+// MapLabelTypeToAPIField maps label type to API field name. Unknown label
+// types are returned as-is.
+func MapLabelTypeToAPIField(labelType string) string {
+	if t, ok := LookupLabelType(labelType); ok {
+		return t.APIField
+	}
+	return labelType
+}
+
+// CheckLabels checks that logLabelString is a JSON array whose entries have
+// the shape of the label type.
+func (t LabelType) CheckLabels(logLabelString string) error {
+	var labels []interface{}
+	if err := json.Unmarshal([]byte(logLabelString), &labels); err != nil {
+		return fmt.Errorf("must be a JSON array of %s: %w", t.Shape, err)
+	}
+	if labels == nil {
+		return fmt.Errorf("must be a JSON array of %s, got null", t.Shape)
+	}
+
+	for i, label := range labels {
+		switch t.Shape {
+		case LabelValueObjects:
+			if _, ok := label.(map[string]interface{}); !ok {
+				return fmt.Errorf("%s labels must be rule objects, entry %d is not an object", t.Name, i)
+			}
+		case LabelValueStrings, LabelValueRegex:
+			value, ok := label.(string)
+			if !ok {
+				return fmt.Errorf("%s labels must be %s, entry %d is not a string", t.Name, t.Shape, i)
+			}
+			if t.Shape == LabelValueRegex {
+				if _, err := regexp.Compile(value); err != nil {
+					return fmt.Errorf("%s labels must be %s, entry %d: %w", t.Name, t.Shape, i, err)
+				}
+			}
+		}
+	}
+	return nil
+}
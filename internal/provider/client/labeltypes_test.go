@@ -0,0 +1,66 @@
+// Copyright (c) InsightFinder Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import "testing"
+
+func TestLabelTypesAreUnique(t *testing.T) {
+	names := make(map[string]bool)
+	fields := make(map[string]bool)
+	for _, labelType := range LabelTypes {
+		if names[labelType.Name] {
+			t.Errorf("Duplicate label type %q", labelType.Name)
+		}
+		if fields[labelType.APIField] {
+			t.Errorf("Duplicate API field %q", labelType.APIField)
+		}
+		if labelType.Description == "" {
+			t.Errorf("Label type %q has no description", labelType.Name)
+		}
+		names[labelType.Name] = true
+		fields[labelType.APIField] = true
+
+		if got, ok := LabelTypeForAPIField(labelType.APIField); !ok || got.Name != labelType.Name {
+			t.Errorf("Expected API field %q to map back to %q, got %q", labelType.APIField, labelType.Name, got.Name)
+		}
+	}
+
+	if got := MapLabelTypeToAPIField("blacklist"); got != "trainingBlacklistLabels" {
+		t.Errorf("Expected blacklist to map to trainingBlacklistLabels, got %q", got)
+	}
+	if got := MapLabelTypeToAPIField("unknownType"); got != "unknownType" {
+		t.Errorf("Expected unknown label types to pass through, got %q", got)
+	}
+}
+
+func TestLabelTypeCheckLabels(t *testing.T) {
+	tests := []struct {
+		labelType string
+		labels    string
+		wantError bool
+	}{
+		{"patternName", `[{"type":"fieldName","keyword":"message","patternNameKey":"message"}]`, false},
+		{"patternName", `[]`, false},
+		{"patternName", `["message"]`, true},
+		{"whitelist", `["ERROR","CRITICAL|FATAL"]`, false},
+		{"whitelist", `[{"type":"fieldName","keyword":"severity=error"}]`, true},
+		{"whitelist", `{"type":"fieldName"}`, true},
+		{"whitelist", `null`, true},
+		{"logSeverity", `["level","severity"]`, false},
+		{"logSeverity", `[{"keyword":"level"}]`, true},
+		{"patternMatchRegex", `["timeout after \\d+ms"]`, false},
+		{"patternMatchRegex", `["unclosed (group"]`, true},
+		{"patternIgnoreRegex", `[1]`, true},
+	}
+
+	for _, tt := range tests {
+		labelType, ok := LookupLabelType(tt.labelType)
+		if !ok {
+			t.Fatalf("Unknown label type %q", tt.labelType)
+		}
+		if err := labelType.CheckLabels(tt.labels); (err != nil) != tt.wantError {
+			t.Errorf("%s %s: expected error %t, got %v", tt.labelType, tt.labels, tt.wantError, err)
+		}
+	}
+}
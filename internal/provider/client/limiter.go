@@ -0,0 +1,116 @@
+// Copyright (c) InsightFinder Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+)
+
+// rateLimiter is a token bucket that refills at a fixed rate. Callers that
+// find the bucket empty reserve a future token and wait for it, so requests
+// are released in arrival order.
+type rateLimiter struct {
+	mu     sync.Mutex
+	rate   float64 // tokens per second
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+// newRateLimiter returns a limiter allowing requestsPerSecond on average,
+// with bursts of up to one second's worth of requests.
+func newRateLimiter(requestsPerSecond float64) *rateLimiter {
+	burst := math.Max(1, math.Ceil(requestsPerSecond))
+	return &rateLimiter{
+		rate:   requestsPerSecond,
+		burst:  burst,
+		tokens: burst,
+		last:   time.Now(),
+	}
+}
+
+// Wait blocks until a token is available or ctx is done.
+func (l *rateLimiter) Wait(ctx context.Context) error {
+	l.mu.Lock()
+	now := time.Now()
+	l.tokens = math.Min(l.burst, l.tokens+now.Sub(l.last).Seconds()*l.rate)
+	l.last = now
+	l.tokens--
+	wait := time.Duration(-l.tokens / l.rate * float64(time.Second))
+	l.mu.Unlock()
+
+	if err := sleepContext(ctx, wait); err != nil {
+		// Hand the reserved token back so cancelled callers don't slow
+		// down the ones still waiting.
+		l.mu.Lock()
+		l.tokens++
+		l.mu.Unlock()
+		return err
+	}
+	return nil
+}
+
+// semaphore caps the number of requests in flight.
+type semaphore chan struct{}
+
+// Acquire blocks until a slot is free or ctx is done.
+func (s semaphore) Acquire(ctx context.Context) error {
+	select {
+	case s <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Release frees a slot taken by Acquire.
+func (s semaphore) Release() {
+	<-s
+}
+
+// SetRateLimit limits the client to requestsPerSecond API calls on average.
+// Retries count against the same budget. A value of zero or less removes
+// the limit.
+func (c *Client) SetRateLimit(requestsPerSecond float64) {
+	if requestsPerSecond <= 0 {
+		c.limiter = nil
+		return
+	}
+	c.limiter = newRateLimiter(requestsPerSecond)
+}
+
+// SetMaxConcurrentRequests caps the number of API calls in flight at once.
+// A value of zero or less removes the cap.
+func (c *Client) SetMaxConcurrentRequests(n int) {
+	if n <= 0 {
+		c.inFlight = nil
+		return
+	}
+	c.inFlight = make(semaphore, n)
+}
+
+// acquire waits for a concurrency slot and then for the rate limiter. The
+// slot is taken first so that a caller cancelled while queued for a slot
+// never spends a rate-limit token. The returned function must be called
+// once the request has completed.
+func (c *Client) acquire(ctx context.Context) (func(), error) {
+	release := func() {}
+	if c.inFlight != nil {
+		if err := c.inFlight.Acquire(ctx); err != nil {
+			return nil, err
+		}
+		release = c.inFlight.Release
+	}
+
+	if c.limiter != nil {
+		if err := c.limiter.Wait(ctx); err != nil {
+			release()
+			return nil, err
+		}
+	}
+	return release, nil
+}
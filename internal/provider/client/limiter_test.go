@@ -0,0 +1,147 @@
+// Copyright (c) InsightFinder Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestSetRateLimit(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client, err := NewClient(server.URL, "test_user", "test_key")
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	client.SetRateLimit(20)
+
+	// The first 20 requests use the initial burst; the next 10 have to wait
+	// for the bucket to refill at 20/s, which takes about half a second.
+	start := time.Now()
+	for i := 0; i < 30; i++ {
+		if _, _, err := client.DoRequest(context.Background(), "GET", "/api/test", nil); err != nil {
+			t.Fatalf("Request %d failed: %v", i, err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed < 400*time.Millisecond {
+		t.Errorf("Expected rate limit to spread 30 requests over ~500ms, took %s", elapsed)
+	}
+}
+
+func TestSetRateLimitCancellation(t *testing.T) {
+	client, err := NewClient("https://test.insightfinder.com", "test_user", "test_key")
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	client.SetRateLimit(0.1)
+
+	// Drain the single-token burst.
+	if err := client.limiter.Wait(context.Background()); err != nil {
+		t.Fatalf("Expected first token immediately, got: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	_, _, err = client.DoRequest(ctx, "GET", "/api/test", nil)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected context.DeadlineExceeded while waiting for a token, got: %v", err)
+	}
+}
+
+func TestCancelledRequestKeepsRateToken(t *testing.T) {
+	client, err := NewClient("https://test.insightfinder.com", "test_user", "test_key")
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	client.SetRateLimit(0.1)
+	client.SetMaxConcurrentRequests(1)
+
+	// Hold the only slot so the request below is cancelled while queued.
+	if err := client.inFlight.Acquire(context.Background()); err != nil {
+		t.Fatalf("Failed to take the slot: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if _, _, err := client.DoRequest(ctx, "GET", "/api/test", nil); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Expected context.DeadlineExceeded while waiting for a slot, got: %v", err)
+	}
+	client.inFlight.Release()
+
+	// The single-token burst must still be there for the next caller.
+	ctx, cancel = context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if err := client.limiter.Wait(ctx); err != nil {
+		t.Errorf("Expected the rate-limit token to be left for the next caller, got: %v", err)
+	}
+}
+
+func TestSetMaxConcurrentRequests(t *testing.T) {
+	var current, peak int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&current, 1)
+		for {
+			p := atomic.LoadInt32(&peak)
+			if n <= p || atomic.CompareAndSwapInt32(&peak, p, n) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+		atomic.AddInt32(&current, -1)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client, err := NewClient(server.URL, "test_user", "test_key")
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	client.SetMaxConcurrentRequests(2)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, _, err := client.DoRequest(context.Background(), "GET", "/api/test", nil); err != nil {
+				t.Errorf("Request failed: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got := atomic.LoadInt32(&peak); got > 2 {
+		t.Errorf("Expected at most 2 requests in flight, saw %d", got)
+	}
+}
+
+func TestSetLimitsDisabled(t *testing.T) {
+	client, err := NewClient("https://test.insightfinder.com", "test_user", "test_key")
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	client.SetRateLimit(5)
+	client.SetMaxConcurrentRequests(3)
+	client.SetRateLimit(0)
+	client.SetMaxConcurrentRequests(0)
+
+	if client.limiter != nil {
+		t.Error("Expected rate limiter to be removed")
+	}
+	if client.inFlight != nil {
+		t.Error("Expected concurrency cap to be removed")
+	}
+}
@@ -0,0 +1,67 @@
+// Copyright (c) InsightFinder Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"sync"
+)
+
+// keyedLocks hands out one lock per key, so that callers holding the same
+// key run one at a time while those holding different keys run in parallel.
+// The zero value is ready to use.
+type keyedLocks struct {
+	mu    sync.Mutex
+	locks map[string]*keyedLock
+}
+
+// keyedLock is the lock of one key. refs counts the callers holding or
+// waiting for it; the lock is dropped once it reaches zero.
+type keyedLock struct {
+	held semaphore
+	refs int
+}
+
+// Lock blocks until the lock of key is free or ctx is done. The returned
+// function releases the lock.
+func (l *keyedLocks) Lock(ctx context.Context, key string) (func(), error) {
+	l.mu.Lock()
+	if l.locks == nil {
+		l.locks = make(map[string]*keyedLock)
+	}
+	lock, ok := l.locks[key]
+	if !ok {
+		lock = &keyedLock{held: make(semaphore, 1)}
+		l.locks[key] = lock
+	}
+	lock.refs++
+	l.mu.Unlock()
+
+	if err := lock.held.Acquire(ctx); err != nil {
+		l.release(key, lock)
+		return nil, err
+	}
+	return func() {
+		lock.held.Release()
+		l.release(key, lock)
+	}, nil
+}
+
+// release drops a reference to the lock of key.
+func (l *keyedLocks) release(key string, lock *keyedLock) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	lock.refs--
+	if lock.refs == 0 {
+		delete(l.locks, key)
+	}
+}
+
+// lockProject serializes writes to the watch-tower settings of a project:
+// project settings, webhooks and log labels. Writes to other projects are
+// not blocked.
+func (c *Client) lockProject(ctx context.Context, projectName string) (func(), error) {
+	return c.projectLocks.Lock(ctx, projectName)
+}
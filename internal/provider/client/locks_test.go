@@ -0,0 +1,133 @@
+// Copyright (c) InsightFinder Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestKeyedLocks(t *testing.T) {
+	var locks keyedLocks
+	ctx := context.Background()
+
+	unlockA, err := locks.Lock(ctx, "project-a")
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	// Other keys are not blocked
+	unlockB, err := locks.Lock(ctx, "project-b")
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	unlockB()
+
+	// The same key waits until the holder releases it
+	waitCtx, cancel := context.WithTimeout(ctx, 20*time.Millisecond)
+	defer cancel()
+	if _, err := locks.Lock(waitCtx, "project-a"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Expected the held lock to block until the deadline, got: %v", err)
+	}
+
+	acquired := make(chan func())
+	go func() {
+		unlock, err := locks.Lock(ctx, "project-a")
+		if err != nil {
+			t.Errorf("Expected no error, got: %v", err)
+		}
+		acquired <- unlock
+	}()
+	select {
+	case <-acquired:
+		t.Fatal("Expected the lock to be held")
+	case <-time.After(20 * time.Millisecond):
+	}
+	unlockA()
+	(<-acquired)()
+
+	if len(locks.locks) != 0 {
+		t.Errorf("Expected released locks to be dropped, got %d", len(locks.locks))
+	}
+}
+
+func TestKeyedLocksSerializeSameKey(t *testing.T) {
+	var locks keyedLocks
+	var current, peak int32
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			unlock, err := locks.Lock(context.Background(), "project-a")
+			if err != nil {
+				t.Errorf("Expected no error, got: %v", err)
+				return
+			}
+			defer unlock()
+
+			n := atomic.AddInt32(&current, 1)
+			if n > atomic.LoadInt32(&peak) {
+				atomic.StoreInt32(&peak, n)
+			}
+			time.Sleep(time.Millisecond)
+			atomic.AddInt32(&current, -1)
+		}()
+	}
+	wg.Wait()
+
+	if got := atomic.LoadInt32(&peak); got != 1 {
+		t.Errorf("Expected one holder at a time, saw %d", got)
+	}
+}
+
+func TestLogLabelWritesToDifferentProjectsRunInParallel(t *testing.T) {
+	// Each request waits until the other project's request has arrived, which
+	// only happens if they are not serialized
+	arrived := map[string]chan struct{}{
+		"project-a": make(chan struct{}),
+		"project-b": make(chan struct{}),
+	}
+	other := map[string]string{"project-a": "project-b", "project-b": "project-a"}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		project := r.URL.Query().Get("projectName")
+		close(arrived[project])
+		select {
+		case <-arrived[other[project]]:
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`{"success": true}`))
+		case <-time.After(2 * time.Second):
+			w.WriteHeader(http.StatusConflict)
+		}
+	}))
+	defer server.Close()
+
+	client, err := NewClient(server.URL, "test_user", "test_key")
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	client.LabelConvergenceTimeout = 0
+
+	var wg sync.WaitGroup
+	for project := range arrived {
+		wg.Add(1)
+		go func(project string) {
+			defer wg.Done()
+			err := client.CreateOrUpdateLogLabels(context.Background(), project, "test_user", []*LogLabelSetting{
+				{LabelType: "whitelist", LogLabelString: `["ERROR"]`},
+			})
+			if err != nil {
+				t.Errorf("%s: expected no error, got: %v", project, err)
+			}
+		}(project)
+	}
+	wg.Wait()
+}
@@ -4,22 +4,42 @@
 package client
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/url"
-	"sync"
+	"reflect"
+	"strings"
+	"time"
 )
 
-// logLabelMutex ensures log label operations are serialized to prevent race conditions
-// when multiple projects are being created/updated simultaneously
-var logLabelMutex sync.Mutex
+// DefaultLabelConvergenceTimeout is how long log label writes wait for the
+// label lists to read back as written.
+const DefaultLabelConvergenceTimeout = 30 * time.Second
+
+// labelPollInterval is the delay between reads while waiting for label
+// lists to converge.
+var labelPollInterval = 500 * time.Millisecond
+
+// LabelsNotConvergedError reports the log label types of a project whose
+// label lists did not read back as written before the wait for them ended.
+// The writes themselves succeeded.
+type LabelsNotConvergedError struct {
+	ProjectName string
+	LabelTypes  []string
+}
+
+func (e *LabelsNotConvergedError) Error() string {
+	return fmt.Sprintf("log labels of project '%s' did not read back as written: %s",
+		e.ProjectName, strings.Join(e.LabelTypes, ", "))
+}
 
 // LogLabelSetting represents a log label configuration
 type LogLabelSetting struct {
-	ProjectName    string   `json:"projectName"`
-	LabelType      string   `json:"labelType"`
-	LogLabelString string   `json:"logLabelString"` // JSON array as string
-	Labels         []string `json:"-"`              // Parsed from LogLabelString
+	ProjectName    string `json:"projectName"`
+	LabelType      string `json:"labelType"`
+	LogLabelString string `json:"logLabelString"` // JSON array as string
 }
 
 // LogLabelsResponse represents the API response for log labels operations
@@ -29,23 +49,24 @@ type LogLabelsResponse struct {
 	Keywords map[string]interface{} `json:"keywords,omitempty"`
 }
 
-// GetLogLabels retrieves log labels for a project
-func (c *Client) GetLogLabels(projectName, username string) (map[string]string, error) {
+// GetLogLabels retrieves log labels for a project. It returns an error
+// matching ErrNotFound when the project has no label settings.
+func (c *Client) GetLogLabels(ctx context.Context, projectName, username string) (map[string]string, error) {
 	params := url.Values{}
 	params.Add("projectName", projectName)
 
 	path := fmt.Sprintf("/api/external/v1/projectkeywords?%s", params.Encode())
-	body, statusCode, err := c.DoRequest("GET", path, nil)
+	body, statusCode, err := c.DoRequest(ctx, "GET", path, nil)
 	if err != nil {
 		return nil, err
 	}
 
-	if statusCode == 404 || statusCode == 204 {
-		return nil, nil // No log labels found
+	if statusCode == 204 {
+		return nil, notFoundError("GET", path, statusCode, fmt.Sprintf("no log labels found for project '%s'", projectName))
 	}
 
-	if statusCode != 200 {
-		return nil, fmt.Errorf("failed to get log labels: HTTP %d", statusCode)
+	if err := checkResponse("GET", path, statusCode, body); err != nil {
+		return nil, fmt.Errorf("failed to get log labels: %w", err)
 	}
 
 	var response LogLabelsResponse
@@ -69,11 +90,43 @@ func (c *Client) GetLogLabels(projectName, username string) (map[string]string,
 }
 
 // CreateOrUpdateLogLabels creates or updates log labels for a project
-func (c *Client) CreateOrUpdateLogLabels(projectName, username string, settings []*LogLabelSetting) error {
-	// Lock to prevent race conditions when multiple projects are setting labels simultaneously
-	logLabelMutex.Lock()
-	defer logLabelMutex.Unlock()
+func (c *Client) CreateOrUpdateLogLabels(ctx context.Context, projectName, username string, settings []*LogLabelSetting) error {
+	unlock, err := c.lockProject(ctx, projectName)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	return c.writeLogLabels(ctx, projectName, username, settings)
+}
+
+// MergeLogLabels adds the entries of add to the label lists of a project and
+// removes those of remove, leaving entries managed elsewhere in place. The
+// label lists are read and written under the project's lock so that
+// concurrent merges do not drop each other's entries.
+func (c *Client) MergeLogLabels(ctx context.Context, projectName, username string, add, remove []*LogLabelSetting) error {
+	unlock, err := c.lockProject(ctx, projectName)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	// A project without label settings has nothing to merge with
+	current, err := c.GetLogLabels(ctx, projectName, username)
+	if err != nil && !errors.Is(err, ErrNotFound) {
+		return err
+	}
 
+	merged, err := MergeLogLabelSettings(current, add, remove)
+	if err != nil {
+		return err
+	}
+	return c.writeLogLabels(ctx, projectName, username, merged)
+}
+
+// writeLogLabels replaces the label list of each setting and waits for the
+// lists to read back as written. Callers hold the project's lock.
+func (c *Client) writeLogLabels(ctx context.Context, projectName, username string, settings []*LogLabelSetting) error {
 	// API endpoint for log labels
 	path := fmt.Sprintf("/api/external/v1/watch-tower-setting?projectName=%s&customerName=%s",
 		url.QueryEscape(projectName),
@@ -89,43 +142,38 @@ func (c *Client) CreateOrUpdateLogLabels(projectName, username string, settings
 			},
 		}
 
-		// Pass the map directly to DoRequest - it will marshal it
-		body, statusCode, err := c.DoRequest("POST", path, requestBody)
+		// Pass the map directly to DoRequest - it will marshal it.
+		// Each call replaces the whole label list, so it is safe to retry.
+		body, statusCode, err := c.DoRequest(withIdempotent(ctx), "POST", path, requestBody)
 		if err != nil {
 			return err
 		}
 
-		if statusCode != 200 {
-			return fmt.Errorf("failed to create/update log label: HTTP %d - %s", statusCode, string(body))
-		}
-
-		// Check response
-		var response map[string]interface{}
-		if err := json.Unmarshal(body, &response); err != nil {
-			// If we can't parse but got 200, assume success
-			continue
-		}
-
-		// Some APIs return success boolean, others don't
-		if success, ok := response["success"].(bool); ok && !success {
-			if msg, ok := response["message"].(string); ok {
-				return fmt.Errorf("log label configuration failed: %s", msg)
-			}
-			return fmt.Errorf("log label configuration failed")
+		// Some APIs return success boolean, others don't; a 200 with an
+		// unparseable body counts as success
+		if err := checkResponse("POST", path, statusCode, body); err != nil {
+			return fmt.Errorf("failed to create/update log label %s: %w", setting.LabelType, err)
 		}
 	}
 
-	return nil
+	return c.awaitLogLabels(ctx, projectName, username, settings)
 }
 
 // DeleteLogLabels removes log labels for a project
 // Note: The API doesn't have a direct delete endpoint, so we set empty arrays
-func (c *Client) DeleteLogLabels(projectName, username string, labelTypes []string) error {
+func (c *Client) DeleteLogLabels(ctx context.Context, projectName, username string, labelTypes []string) error {
+	unlock, err := c.lockProject(ctx, projectName)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
 	path := fmt.Sprintf("/api/external/v1/watch-tower-setting?projectName=%s&customerName=%s",
 		url.QueryEscape(projectName),
 		url.QueryEscape(username))
 
 	// For each label type, set an empty array
+	emptied := make([]*LogLabelSetting, 0, len(labelTypes))
 	for _, labelType := range labelTypes {
 		requestBody := map[string]interface{}{
 			"logLabelSettingCreate": map[string]interface{}{
@@ -134,45 +182,260 @@ func (c *Client) DeleteLogLabels(projectName, username string, labelTypes []stri
 			},
 		}
 
-		bodyJSON, err := json.Marshal(requestBody)
+		// DoRequest marshals the body; passing it pre-marshalled would send
+		// the bytes as a base64 string, which the API ignores
+		body, statusCode, err := c.DoRequest(withIdempotent(ctx), "POST", path, requestBody)
+		if err != nil {
+			return err
+		}
+
+		if err := checkResponse("POST", path, statusCode, body); err != nil {
+			return fmt.Errorf("failed to delete log label %s: %w", labelType, err)
+		}
+		emptied = append(emptied, &LogLabelSetting{LabelType: labelType, LogLabelString: "[]"})
+	}
+
+	return c.awaitLogLabels(ctx, projectName, username, emptied)
+}
+
+// awaitLogLabels re-reads the label lists of a project until each list in
+// expected holds the same entries, for at most LabelConvergenceTimeout or
+// until the deadline of ctx. Label types whose lists still differ are returned in a
+// *LabelsNotConvergedError. A zero LabelConvergenceTimeout skips the check.
+func (c *Client) awaitLogLabels(ctx context.Context, projectName, username string, expected []*LogLabelSetting) error {
+	if c.LabelConvergenceTimeout <= 0 || len(expected) == 0 {
+		return nil
+	}
+
+	waitCtx, cancel := context.WithTimeout(ctx, c.LabelConvergenceTimeout)
+	defer cancel()
+
+	pending := make([]string, 0, len(expected))
+	for _, setting := range expected {
+		pending = append(pending, setting.LabelType)
+	}
+
+	for {
+		// A project without label settings holds no labels
+		current, err := c.GetLogLabels(waitCtx, projectName, username)
+		if err == nil || errors.Is(err, ErrNotFound) {
+			pending, err = unconvergedLabelTypes(current, expected)
+			if err != nil {
+				return err
+			}
+			if len(pending) == 0 {
+				return nil
+			}
+			err = sleepContext(waitCtx, labelPollInterval)
+		}
+
+		// The wait also ends with the operation's deadline; only a
+		// cancelled operation or a failed read is reported as such
 		if err != nil {
-			return fmt.Errorf("failed to marshal log label delete request: %w", err)
+			if errors.Is(ctx.Err(), context.Canceled) || waitCtx.Err() == nil {
+				return err
+			}
+			return &LabelsNotConvergedError{ProjectName: projectName, LabelTypes: pending}
 		}
+	}
+}
 
-		body, statusCode, err := c.DoRequest("POST", path, bodyJSON)
+// unconvergedLabelTypes returns the label types whose lists in current, keyed
+// by API field, do not hold the entries expected. The lists are compared as
+// sets: order and duplicates are ignored, and a stored object entry matches
+// a written one when it holds every field that was written, since the API
+// may reorder, deduplicate or fill in defaults for the entries it stores.
+func unconvergedLabelTypes(current map[string]string, expected []*LogLabelSetting) ([]string, error) {
+	var pending []string
+	for _, setting := range expected {
+		want, err := logLabelValues(setting.LogLabelString)
 		if err != nil {
-			return err
+			return nil, fmt.Errorf("log label %s: %w", setting.LabelType, err)
+		}
+		got, err := logLabelValues(current[MapLabelTypeToAPIField(setting.LabelType)])
+		if err != nil {
+			return nil, fmt.Errorf("current log label %s: %w", setting.LabelType, err)
 		}
 
-		// 200 or 204 are acceptable
-		if statusCode != 200 && statusCode != 204 {
-			return fmt.Errorf("failed to delete log label: HTTP %d - %s", statusCode, string(body))
+		if !labelSetsMatch(want, got) {
+			pending = append(pending, setting.LabelType)
 		}
 	}
+	return pending, nil
+}
 
-	return nil
+// labelSetsMatch reports whether every written entry is stored and every
+// stored entry was written.
+func labelSetsMatch(written, stored []interface{}) bool {
+	for _, w := range written {
+		if !anyLabelEntry(stored, func(s interface{}) bool { return labelEntryMatches(w, s) }) {
+			return false
+		}
+	}
+	for _, s := range stored {
+		if !anyLabelEntry(written, func(w interface{}) bool { return labelEntryMatches(w, s) }) {
+			return false
+		}
+	}
+	return true
+}
+
+func anyLabelEntry(entries []interface{}, match func(interface{}) bool) bool {
+	for _, entry := range entries {
+		if match(entry) {
+			return true
+		}
+	}
+	return false
+}
+
+// labelEntryMatches reports whether stored is the entry written. Objects
+// match when stored holds every field of written with the same value;
+// fields only stored are defaults the API added.
+func labelEntryMatches(written, stored interface{}) bool {
+	w, wok := written.(map[string]interface{})
+	s, sok := stored.(map[string]interface{})
+	if !wok || !sok {
+		return reflect.DeepEqual(written, stored)
+	}
+	for key, value := range w {
+		if sv, ok := s[key]; !ok || !reflect.DeepEqual(value, sv) {
+			return false
+		}
+	}
+	return true
+}
+
+// MergeLogLabelSettings merges add and remove into the label lists current
+// holds, keyed by API field as GetLogLabels returns them, and returns the
+// resulting label list of every label type add or remove names. Entries are
+// compared by their JSON encoding; current entries keep their order, entries
+// of remove are dropped unless add holds them too, and entries of add that
+// are missing are appended.
+func MergeLogLabelSettings(current map[string]string, add, remove []*LogLabelSetting) ([]*LogLabelSetting, error) {
+	var labelTypes []string
+	added := make(map[string][]string)
+	removed := make(map[string]map[string]bool)
+
+	for _, setting := range add {
+		entries, err := logLabelEntries(setting.LogLabelString)
+		if err != nil {
+			return nil, fmt.Errorf("log label %s: %w", setting.LabelType, err)
+		}
+		if _, ok := added[setting.LabelType]; !ok {
+			if _, ok := removed[setting.LabelType]; !ok {
+				labelTypes = append(labelTypes, setting.LabelType)
+			}
+		}
+		added[setting.LabelType] = append(added[setting.LabelType], entries...)
+	}
+	for _, setting := range remove {
+		entries, err := logLabelEntries(setting.LogLabelString)
+		if err != nil {
+			return nil, fmt.Errorf("log label %s: %w", setting.LabelType, err)
+		}
+		if _, ok := removed[setting.LabelType]; !ok {
+			if _, ok := added[setting.LabelType]; !ok {
+				labelTypes = append(labelTypes, setting.LabelType)
+			}
+			removed[setting.LabelType] = make(map[string]bool)
+		}
+		for _, entry := range entries {
+			removed[setting.LabelType][entry] = true
+		}
+	}
+
+	result := make([]*LogLabelSetting, 0, len(labelTypes))
+	for _, labelType := range labelTypes {
+		entries, err := logLabelEntries(current[MapLabelTypeToAPIField(labelType)])
+		if err != nil {
+			return nil, fmt.Errorf("current log label %s: %w", labelType, err)
+		}
+
+		keep := make(map[string]bool, len(added[labelType]))
+		for _, entry := range added[labelType] {
+			keep[entry] = true
+		}
+
+		merged := make([]string, 0, len(entries)+len(added[labelType]))
+		present := make(map[string]bool, len(entries))
+		for _, entry := range entries {
+			if removed[labelType][entry] && !keep[entry] {
+				continue
+			}
+			merged = append(merged, entry)
+			present[entry] = true
+		}
+		for _, entry := range added[labelType] {
+			if !present[entry] {
+				merged = append(merged, entry)
+				present[entry] = true
+			}
+		}
+
+		result = append(result, &LogLabelSetting{
+			LabelType:      labelType,
+			LogLabelString: "[" + strings.Join(merged, ",") + "]",
+		})
+	}
+	return result, nil
+}
+
+// PresentLogLabels returns the entries of the label list labels that the
+// label list current also holds, as a JSON array in the order of labels.
+func PresentLogLabels(labels, current string) (string, error) {
+	entries, err := logLabelEntries(labels)
+	if err != nil {
+		return "", err
+	}
+	currentEntries, err := logLabelEntries(current)
+	if err != nil {
+		return "", err
+	}
+
+	present := make(map[string]bool, len(currentEntries))
+	for _, entry := range currentEntries {
+		present[entry] = true
+	}
+	kept := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		if present[entry] {
+			kept = append(kept, entry)
+		}
+	}
+	return "[" + strings.Join(kept, ",") + "]", nil
+}
+
+// logLabelEntries returns the entries of a label list, each in compact JSON
+// with sorted keys so that equal entries compare equal. An empty string is
+// an empty list.
+func logLabelEntries(labels string) ([]string, error) {
+	values, err := logLabelValues(labels)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]string, 0, len(values))
+	for _, value := range values {
+		encoded, err := json.Marshal(value)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, string(encoded))
+	}
+	return entries, nil
 }
 
-// MapLabelTypeToAPIField maps label type to API field name
-func MapLabelTypeToAPIField(labelType string) string {
-	// Based on the Terraform module mapping
-	mapping := map[string]string{
-		"whitelist":           "whitelist",
-		"trainingWhitelist":   "trainingWhitelist",
-		"blacklist":           "trainingBlacklistLabels",
-		"featurelist":         "featurelist",
-		"incidentlist":        "incidentlist",
-		"triagelist":          "triagelist",
-		"anomalyFeature":      "anomalyFeatureLabels",
-		"dataFilter":          "dataFilterLabels",
-		"patternName":         "patternNameLabels",
-		"instanceName":        "instanceNameLabels",
-		"dataQualityCheck":    "dataQualityCheckLabels",
-		"extractionBlacklist": "extractionBlacklist",
+// logLabelValues decodes the entries of a label list. An empty string is an
+// empty list.
+func logLabelValues(labels string) ([]interface{}, error) {
+	if labels == "" {
+		return nil, nil
 	}
 
-	if apiField, ok := mapping[labelType]; ok {
-		return apiField
+	var values []interface{}
+	if err := json.Unmarshal([]byte(labels), &values); err != nil {
+		return nil, fmt.Errorf("label list is not a JSON array: %w", err)
 	}
-	return labelType // Return as-is if not in mapping
+	return values, nil
 }
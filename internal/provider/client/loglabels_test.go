@@ -0,0 +1,305 @@
+// Copyright (c) InsightFinder Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestMergeLogLabelSettings(t *testing.T) {
+	tests := []struct {
+		name     string
+		current  map[string]string
+		add      []*LogLabelSetting
+		remove   []*LogLabelSetting
+		expected map[string]string
+	}{
+		{
+			name:     "appends missing entries",
+			current:  map[string]string{"whitelist": `["A","B"]`},
+			add:      []*LogLabelSetting{{LabelType: "whitelist", LogLabelString: `["C","A"]`}},
+			expected: map[string]string{"whitelist": `["A","B","C"]`},
+		},
+		{
+			name:     "no current labels",
+			add:      []*LogLabelSetting{{LabelType: "blacklist", LogLabelString: `["DEBUG"]`}},
+			expected: map[string]string{"blacklist": `["DEBUG"]`},
+		},
+		{
+			name:     "removes entries not added",
+			current:  map[string]string{"whitelist": `["A","B","C"]`},
+			add:      []*LogLabelSetting{{LabelType: "whitelist", LogLabelString: `["A","D"]`}},
+			remove:   []*LogLabelSetting{{LabelType: "whitelist", LogLabelString: `["A","C"]`}},
+			expected: map[string]string{"whitelist": `["A","B","D"]`},
+		},
+		{
+			name:     "removes the last entry",
+			current:  map[string]string{"trainingBlacklistLabels": `["DEBUG"]`},
+			remove:   []*LogLabelSetting{{LabelType: "blacklist", LogLabelString: `["DEBUG"]`}},
+			expected: map[string]string{"blacklist": `[]`},
+		},
+		{
+			name:    "compares objects by content",
+			current: map[string]string{"patternNameLabels": `[{"type":"fieldName","keyword":"message"},{"keyword":"host","type":"fieldName"}]`},
+			add:     []*LogLabelSetting{{LabelType: "patternName", LogLabelString: `[{"keyword":"message","type":"fieldName"}]`}},
+			remove:  []*LogLabelSetting{{LabelType: "patternName", LogLabelString: `[{"type":"fieldName", "keyword":"host"}]`}},
+			expected: map[string]string{
+				"patternName": `[{"keyword":"message","type":"fieldName"}]`,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			merged, err := MergeLogLabelSettings(tt.current, tt.add, tt.remove)
+			if err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+			if len(merged) != len(tt.expected) {
+				t.Fatalf("Expected %d settings, got %d", len(tt.expected), len(merged))
+			}
+			for _, setting := range merged {
+				if expected := tt.expected[setting.LabelType]; setting.LogLabelString != expected {
+					t.Errorf("Expected %s to be %s, got %s", setting.LabelType, expected, setting.LogLabelString)
+				}
+			}
+		})
+	}
+
+	if _, err := MergeLogLabelSettings(map[string]string{"whitelist": `{}`}, []*LogLabelSetting{{LabelType: "whitelist", LogLabelString: `["A"]`}}, nil); err == nil {
+		t.Error("Expected an error for a current label list that is not an array")
+	}
+}
+
+func TestPresentLogLabels(t *testing.T) {
+	present, err := PresentLogLabels(`["C",{"b":1,"a":2},"A"]`, `["A","B",{"a":2,"b":1}]`)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if present != `[{"a":2,"b":1},"A"]` {
+		t.Errorf("Unexpected present labels: %s", present)
+	}
+
+	if present, _ := PresentLogLabels(`["A"]`, ""); present != `[]` {
+		t.Errorf("Expected no labels to be present in an empty list, got %s", present)
+	}
+}
+
+func TestUnconvergedLabelTypes(t *testing.T) {
+	tests := []struct {
+		name     string
+		current  string
+		written  string
+		expected bool
+	}{
+		{name: "same entries", current: `["B","A"]`, written: `["A","B"]`, expected: true},
+		{name: "deduplicated", current: `["A"]`, written: `["A","A"]`, expected: true},
+		{name: "duplicated", current: `["A","A"]`, written: `["A"]`, expected: true},
+		{name: "default fields added", current: `[{"keyword":"host","type":"exact"}]`, written: `[{"keyword":"host"}]`, expected: true},
+		{name: "field changed", current: `[{"keyword":"hostname"}]`, written: `[{"keyword":"host"}]`, expected: false},
+		{name: "field dropped", current: `[{"keyword":"host"}]`, written: `[{"keyword":"host","type":"exact"}]`, expected: false},
+		{name: "entry missing", current: `["A"]`, written: `["A","B"]`, expected: false},
+		{name: "entry left over", current: `["A","B"]`, written: `["A"]`, expected: false},
+		{name: "emptied", current: ``, written: `[]`, expected: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pending, err := unconvergedLabelTypes(
+				map[string]string{"patternNameLabels": tt.current},
+				[]*LogLabelSetting{{LabelType: "patternName", LogLabelString: tt.written}},
+			)
+			if err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+			if converged := len(pending) == 0; converged != tt.expected {
+				t.Errorf("Expected converged %v, got pending %v", tt.expected, pending)
+			}
+		})
+	}
+}
+
+// newLabelsServer returns a server storing the label lists written through
+// the watch-tower settings endpoint and returning them from projectkeywords.
+// Writes are recorded in received unless ignoreWrites is set.
+func newLabelsServer(t *testing.T, keywords map[string]interface{}, ignoreWrites bool, received *[]map[string]interface{}) *httptest.Server {
+	var mu sync.Mutex
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		if r.Method == http.MethodGet {
+			body, _ := json.Marshal(map[string]interface{}{"success": true, "keywords": keywords})
+			w.WriteHeader(http.StatusOK)
+			w.Write(body)
+			return
+		}
+
+		var body map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("Expected a JSON object body, got: %v", err)
+		}
+		*received = append(*received, body)
+
+		setting, _ := body["logLabelSettingCreate"].(map[string]interface{})
+		labelType, _ := setting["labelType"].(string)
+		labelString, _ := setting["logLabelString"].(string)
+		var labels []interface{}
+		json.Unmarshal([]byte(labelString), &labels)
+		if !ignoreWrites {
+			if len(labels) == 0 {
+				delete(keywords, MapLabelTypeToAPIField(labelType))
+			} else {
+				keywords[MapLabelTypeToAPIField(labelType)] = labels
+			}
+		}
+
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"success": true}`))
+	}))
+}
+
+func TestMergeLogLabels(t *testing.T) {
+	var received []map[string]interface{}
+	server := newLabelsServer(t, map[string]interface{}{"whitelist": []interface{}{"A", "B"}}, false, &received)
+	defer server.Close()
+
+	client, err := NewClient(server.URL, "test_user", "test_key")
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	err = client.MergeLogLabels(context.Background(), "test-project", "test_user",
+		[]*LogLabelSetting{{LabelType: "whitelist", LogLabelString: `["C"]`}},
+		[]*LogLabelSetting{{LabelType: "whitelist", LogLabelString: `["A"]`}},
+	)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if len(received) != 1 {
+		t.Fatalf("Expected one label list to be written, got %d", len(received))
+	}
+	setting, _ := received[0]["logLabelSettingCreate"].(map[string]interface{})
+	if setting["labelType"] != "whitelist" || setting["logLabelString"] != `["B","C"]` {
+		t.Errorf("Unexpected label setting sent: %v", received[0])
+	}
+}
+
+func TestDeleteLogLabels(t *testing.T) {
+	var received []map[string]interface{}
+	keywords := map[string]interface{}{
+		"whitelist":               []interface{}{"ERROR"},
+		"trainingBlacklistLabels": []interface{}{"DEBUG"},
+		"patternNameLabels":       []interface{}{map[string]interface{}{"keyword": "message"}},
+	}
+	server := newLabelsServer(t, keywords, false, &received)
+	defer server.Close()
+
+	client, err := NewClient(server.URL, "test_user", "test_key")
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	if err := client.DeleteLogLabels(context.Background(), "test-project", "test_user", []string{"whitelist", "blacklist"}); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if len(received) != 2 {
+		t.Fatalf("Expected two label lists to be cleared, got %d", len(received))
+	}
+	for _, body := range received {
+		setting, _ := body["logLabelSettingCreate"].(map[string]interface{})
+		if setting["logLabelString"] != "[]" {
+			t.Errorf("Expected an empty label list to be sent, got: %v", body)
+		}
+	}
+	if len(keywords) != 1 || keywords["patternNameLabels"] == nil {
+		t.Errorf("Expected only the other label types to remain, got: %v", keywords)
+	}
+}
+
+func TestLogLabelsNotConverged(t *testing.T) {
+	defer func(interval time.Duration) { labelPollInterval = interval }(labelPollInterval)
+	labelPollInterval = 10 * time.Millisecond
+
+	var received []map[string]interface{}
+	keywords := map[string]interface{}{
+		"whitelist":         []interface{}{"ERROR"},
+		"patternNameLabels": []interface{}{map[string]interface{}{"keyword": "message"}},
+	}
+	server := newLabelsServer(t, keywords, true, &received)
+	defer server.Close()
+
+	client, err := NewClient(server.URL, "test_user", "test_key")
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	client.LabelConvergenceTimeout = 50 * time.Millisecond
+
+	tests := []struct {
+		name     string
+		write    func() error
+		expected []string
+	}{
+		{
+			name: "create",
+			write: func() error {
+				return client.CreateOrUpdateLogLabels(context.Background(), "test-project", "test_user", []*LogLabelSetting{
+					{LabelType: "whitelist", LogLabelString: `["ERROR"]`},
+					{LabelType: "blacklist", LogLabelString: `["DEBUG"]`},
+					{LabelType: "patternName", LogLabelString: `[{"keyword":"host"}]`},
+				})
+			},
+			expected: []string{"blacklist", "patternName"},
+		},
+		{
+			name: "delete",
+			write: func() error {
+				return client.DeleteLogLabels(context.Background(), "test-project", "test_user", []string{"whitelist", "blacklist"})
+			},
+			expected: []string{"whitelist"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.write()
+
+			var notConverged *LabelsNotConvergedError
+			if !errors.As(err, &notConverged) {
+				t.Fatalf("Expected a LabelsNotConvergedError, got: %v", err)
+			}
+			if notConverged.ProjectName != "test-project" || fmt.Sprint(notConverged.LabelTypes) != fmt.Sprint(tt.expected) {
+				t.Errorf("Expected label types %v of test-project, got %v of %s", tt.expected, notConverged.LabelTypes, notConverged.ProjectName)
+			}
+		})
+	}
+
+	// The wait ends with the operation's deadline when that comes first
+	client.LabelConvergenceTimeout = time.Minute
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	err = client.DeleteLogLabels(ctx, "test-project", "test_user", []string{"whitelist"})
+	var notConverged *LabelsNotConvergedError
+	if !errors.As(err, &notConverged) || fmt.Sprint(notConverged.LabelTypes) != "[whitelist]" {
+		t.Errorf("Expected the operation deadline to end the wait, got: %v", err)
+	}
+
+	// A cancelled operation is reported as such
+	ctx, cancel = context.WithCancel(context.Background())
+	time.AfterFunc(50*time.Millisecond, cancel)
+	err = client.DeleteLogLabels(ctx, "test-project", "test_user", []string{"whitelist"})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected the cancellation to be reported, got: %v", err)
+	}
+}
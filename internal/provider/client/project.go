@@ -4,10 +4,10 @@
 package client
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/url"
-	"strings"
 )
 
 // ProjectConfig represents a project configuration
@@ -23,8 +23,20 @@ type ProjectConfig struct {
 	CValue              int                    `json:"cValue,omitempty"`
 	PValue              float64                `json:"pValue,omitempty"`
 	Settings            map[string]interface{} `json:"settings,omitempty"`
+	// PartialSettings lists the keys of Settings holding JSON objects with
+	// only some of their keys set. UpdateProject merges them into the
+	// project's current objects, so the keys they leave out keep their
+	// values.
+	PartialSettings []string `json:"-"`
 }
 
+// Values the project creation fields of ProjectConfig accept.
+var (
+	DataTypes         = []string{"Log", "Metric", "Alert", "Trace"}
+	InstanceTypes     = []string{"AWS", "Azure", "GCP", "PrivateCloud", "OnPremise"}
+	InsightAgentTypes = []string{"LogStreaming", "MetricFile", "Historical"}
+)
+
 // Settings fields struct
 type ProjectSettings struct {
 	DailyModelSpan            int    `json:"dailyModelSpan,omitempty"`
@@ -86,96 +98,106 @@ type ProjectSettings struct {
 	} `json:"logToMetricDelete,omitempty"`
 	LogJSONTypeUpdate struct {
 	} `json:"logJsonTypeUpdate,omitempty"`
-	IsTracePrompt        bool          `json:"isTracePrompt,omitempty"`
-	LogToLogSettingList  []interface{} `json:"logToLogSettingList,omitempty"`
-	LlmEvaluationSetting struct {
-		IsHallucinationEvaluation     bool `json:"isHallucinationEvaluation,omitempty"`
-		IsAnswerRelevantEvaluation    bool `json:"isAnswerRelevantEvaluation,omitempty"`
-		IsLogicConsistencyEvaluation  bool `json:"isLogicConsistencyEvaluation,omitempty"`
-		IsFactualInaccuracyEvaluation bool `json:"isFactualInaccuracyEvaluation,omitempty"`
-		IsMaliciousPromptEvaluation   bool `json:"isMaliciousPromptEvaluation,omitempty"`
-		IsToxicityEvaluation          bool `json:"isToxicityEvaluation,omitempty"`
-		IsPiiPhiLeakageEvaluation     bool `json:"isPiiPhiLeakageEvaluation,omitempty"`
-		IsTopicGuardrailsEvaluation   bool `json:"isTopicGuardrailsEvaluation,omitempty"`
-		IsToneDetectionEvaluation     bool `json:"isToneDetectionEvaluation,omitempty"`
-		IsAnomalousOutliersEvaluation bool `json:"isAnomalousOutliersEvaluation,omitempty"`
-		ShowSafetyTemplate            bool `json:"showSafetyTemplate,omitempty"`
-		IsGenderBiasEvaluation        bool `json:"isGenderBiasEvaluation,omitempty"`
-		IsRacialBiasEvaluation        bool `json:"isRacialBiasEvaluation,omitempty"`
-		IsSocioeconomicBiasEvaluation bool `json:"isSocioeconomicBiasEvaluation,omitempty"`
-		IsCulturalBiasEvaluation      bool `json:"isCulturalBiasEvaluation,omitempty"`
-		IsReligiousBiasEvaluation     bool `json:"isReligiousBiasEvaluation,omitempty"`
-		IsPoliticalBiasEvaluation     bool `json:"isPoliticalBiasEvaluation,omitempty"`
-		IsDisabilityBiasEvaluation    bool `json:"isDisabilityBiasEvaluation,omitempty"`
-		IsAgeBiasEvaluation           bool `json:"isAgeBiasEvaluation,omitempty"`
-	} `json:"llmEvaluationSetting,,omitempty"`
-	IsEdgeBrain                          bool          `json:"isEdgeBrain,omitempty"`
-	ProjectName                          string        `json:"projectName,omitempty"`
-	CValue                               int           `json:"cValue,omitempty"`
-	PValue                               float64       `json:"pValue,omitempty"`
-	IncidentPredictionWindow             int           `json:"incidentPredictionWindow,omitempty"`
-	MinIncidentPredictionWindow          int           `json:"minIncidentPredictionWindow,omitempty"`
-	IncidentRelationSearchWindow         int           `json:"incidentRelationSearchWindow,omitempty"`
-	IncidentPredictionEventLimit         int           `json:"incidentPredictionEventLimit,omitempty"`
-	RootCauseCountThreshold              int           `json:"rootCauseCountThreshold,omitempty"`
-	RootCauseProbabilityThreshold        float64       `json:"rootCauseProbabilityThreshold,omitempty"`
-	CompositeRCALimit                    int           `json:"compositeRCALimit,omitempty"`
-	RootCauseLogMessageSearchRange       int           `json:"rootCauseLogMessageSearchRange,omitempty"`
-	CausalPredictionSetting              int           `json:"causalPredictionSetting,omitempty"`
-	CausalMinDelay                       string        `json:"causalMinDelay,omitempty"`
-	RootCauseRankSetting                 int           `json:"rootCauseRankSetting,omitempty"`
-	MaximumRootCauseResultSize           int           `json:"maximumRootCauseResultSize,omitempty"`
-	MultiHopSearchLevel                  int           `json:"multiHopSearchLevel,omitempty"`
-	AvgPerIncidentDowntimeCost           float64       `json:"avgPerIncidentDowntimeCost,omitempty"`
-	PredictionRuleActiveCondition        int           `json:"predictionRuleActiveCondition,omitempty"`
-	PredictionRuleFalsePositiveThreshold int           `json:"predictionRuleFalsePositiveThreshold,omitempty"`
-	PredictionRuleActiveThreshold        float64       `json:"predictionRuleActiveThreshold,omitempty"`
-	PredictionRuleInactiveThreshold      float64       `json:"predictionRuleInactiveThreshold,omitempty"`
-	PredictionProbabilityThreshold       float64       `json:"predictionProbabilityThreshold,omitempty"`
-	AlertHourlyCost                      float64       `json:"alertHourlyCost,omitempty"`
-	AlertAverageTime                     int           `json:"alertAverageTime,omitempty"`
-	IgnoreInstanceForKB                  bool          `json:"ignoreInstanceForKB,omitempty"`
-	ShowInstanceDown                     bool          `json:"showInstanceDown,omitempty"`
-	RetentionTime                        int           `json:"retentionTime,omitempty"`
-	UBLRetentionTime                     int           `json:"UBLRetentionTime,omitempty"`
-	TrainingFilter                       bool          `json:"trainingFilter,omitempty"`
-	SharedUsernames                      []interface{} `json:"sharedUsernames,omitempty"`
-	MultiHopSearchLimit                  string        `json:"multiHopSearchLimit,omitempty"`
-	ProjectDisplayName                   string        `json:"projectDisplayName,omitempty"`
-	EnableNewAlertEmail                  bool          `json:"enableNewAlertEmail,omitempty"`
-	ProjectTimeZone                      string        `json:"projectTimeZone,omitempty"`
-	PredictionCountThreshold             int           `json:"predictionCountThreshold,omitempty"`
-	SamplingInterval                     int           `json:"samplingInterval,omitempty"`
-	MinValidModelSpan                    int           `json:"minValidModelSpan,omitempty"`
-	MaxWebHookRequestSize                int           `json:"maxWebHookRequestSize,omitempty"`
-	WebhookURL                           string        `json:"webhookUrl,omitempty"`
-	WebhookHeaderList                    []interface{} `json:"webhookHeaderList,omitempty"`
-	WebhookTypeSetStr                    string        `json:"webhookTypeSetStr,omitempty"`
-	WebhookBlackListSetStr               string        `json:"webhookBlackListSetStr,omitempty"`
-	WebhookCriticalKeywordSetStr         string        `json:"webhookCriticalKeywordSetStr,omitempty"`
-	WebhookAlertDampening                int           `json:"webhookAlertDampening,omitempty"`
-	Proxy                                string        `json:"proxy,omitempty"`
-	NewPatternRange                      int           `json:"newPatternRange,omitempty"`
-	LargeProject                         bool          `json:"largeProject,omitempty"`
-	EnableAnomalyScoreEscalation         bool          `json:"enableAnomalyScoreEscalation,omitempty"`
-	EscalationAnomalyScoreThreshold      string        `json:"escalationAnomalyScoreThreshold,omitempty"`
-	IgnoreAnomalyScoreThreshold          string        `json:"ignoreAnomalyScoreThreshold,omitempty"`
-	EnableStreamDetection                bool          `json:"enableStreamDetection,omitempty"`
+	IsTracePrompt                        bool                 `json:"isTracePrompt,omitempty"`
+	LogToLogSettingList                  []interface{}        `json:"logToLogSettingList,omitempty"`
+	LlmEvaluationSetting                 LlmEvaluationSetting `json:"llmEvaluationSetting,omitempty"`
+	IsEdgeBrain                          bool                 `json:"isEdgeBrain,omitempty"`
+	ProjectName                          string               `json:"projectName,omitempty"`
+	CValue                               int                  `json:"cValue,omitempty"`
+	PValue                               float64              `json:"pValue,omitempty"`
+	IncidentPredictionWindow             int                  `json:"incidentPredictionWindow,omitempty"`
+	MinIncidentPredictionWindow          int                  `json:"minIncidentPredictionWindow,omitempty"`
+	IncidentRelationSearchWindow         int                  `json:"incidentRelationSearchWindow,omitempty"`
+	IncidentPredictionEventLimit         int                  `json:"incidentPredictionEventLimit,omitempty"`
+	RootCauseCountThreshold              int                  `json:"rootCauseCountThreshold,omitempty"`
+	RootCauseProbabilityThreshold        float64              `json:"rootCauseProbabilityThreshold,omitempty"`
+	CompositeRCALimit                    int                  `json:"compositeRCALimit,omitempty"`
+	RootCauseLogMessageSearchRange       int                  `json:"rootCauseLogMessageSearchRange,omitempty"`
+	CausalPredictionSetting              int                  `json:"causalPredictionSetting,omitempty"`
+	CausalMinDelay                       string               `json:"causalMinDelay,omitempty"`
+	RootCauseRankSetting                 int                  `json:"rootCauseRankSetting,omitempty"`
+	MaximumRootCauseResultSize           int                  `json:"maximumRootCauseResultSize,omitempty"`
+	MultiHopSearchLevel                  int                  `json:"multiHopSearchLevel,omitempty"`
+	AvgPerIncidentDowntimeCost           float64              `json:"avgPerIncidentDowntimeCost,omitempty"`
+	PredictionRuleActiveCondition        int                  `json:"predictionRuleActiveCondition,omitempty"`
+	PredictionRuleFalsePositiveThreshold int                  `json:"predictionRuleFalsePositiveThreshold,omitempty"`
+	PredictionRuleActiveThreshold        float64              `json:"predictionRuleActiveThreshold,omitempty"`
+	PredictionRuleInactiveThreshold      float64              `json:"predictionRuleInactiveThreshold,omitempty"`
+	PredictionProbabilityThreshold       float64              `json:"predictionProbabilityThreshold,omitempty"`
+	AlertHourlyCost                      float64              `json:"alertHourlyCost,omitempty"`
+	AlertAverageTime                     int                  `json:"alertAverageTime,omitempty"`
+	IgnoreInstanceForKB                  bool                 `json:"ignoreInstanceForKB,omitempty"`
+	ShowInstanceDown                     bool                 `json:"showInstanceDown,omitempty"`
+	RetentionTime                        int                  `json:"retentionTime,omitempty"`
+	UBLRetentionTime                     int                  `json:"UBLRetentionTime,omitempty"`
+	TrainingFilter                       bool                 `json:"trainingFilter,omitempty"`
+	SharedUsernames                      []interface{}        `json:"sharedUsernames,omitempty"`
+	MultiHopSearchLimit                  string               `json:"multiHopSearchLimit,omitempty"`
+	ProjectDisplayName                   string               `json:"projectDisplayName,omitempty"`
+	EnableNewAlertEmail                  bool                 `json:"enableNewAlertEmail,omitempty"`
+	ProjectTimeZone                      string               `json:"projectTimeZone,omitempty"`
+	PredictionCountThreshold             int                  `json:"predictionCountThreshold,omitempty"`
+	SamplingInterval                     int                  `json:"samplingInterval,omitempty"`
+	MinValidModelSpan                    int                  `json:"minValidModelSpan,omitempty"`
+	MaxWebHookRequestSize                int                  `json:"maxWebHookRequestSize,omitempty"`
+	WebhookURL                           string               `json:"webhookUrl,omitempty"`
+	WebhookHeaderList                    []interface{}        `json:"webhookHeaderList,omitempty"`
+	WebhookTypeSetStr                    string               `json:"webhookTypeSetStr,omitempty"`
+	WebhookBlackListSetStr               string               `json:"webhookBlackListSetStr,omitempty"`
+	WebhookCriticalKeywordSetStr         string               `json:"webhookCriticalKeywordSetStr,omitempty"`
+	WebhookAlertDampening                int                  `json:"webhookAlertDampening,omitempty"`
+	Proxy                                string               `json:"proxy,omitempty"`
+	NewPatternRange                      int                  `json:"newPatternRange,omitempty"`
+	LargeProject                         bool                 `json:"largeProject,omitempty"`
+	EnableAnomalyScoreEscalation         bool                 `json:"enableAnomalyScoreEscalation,omitempty"`
+	EscalationAnomalyScoreThreshold      string               `json:"escalationAnomalyScoreThreshold,omitempty"`
+	IgnoreAnomalyScoreThreshold          string               `json:"ignoreAnomalyScoreThreshold,omitempty"`
+	EnableStreamDetection                bool                 `json:"enableStreamDetection,omitempty"`
 	InstanceGroupingUpdate               struct {
 		AutoFill bool `json:"autoFill,omitempty"`
 	} `json:"instanceGroupingUpdate,omitempty"`
-	EmailSetting struct {
-		OnlySendWithRCA                    bool   `json:"onlySendWithRCA,omitempty"`
-		EnableNotificationAW               bool   `json:"enableNotificationAW,omitempty"`
-		EnableIncidentPredictionEmailAlert bool   `json:"enableIncidentPredictionEmailAlert,omitempty"`
-		EnableIncidentDetectionEmailAlert  bool   `json:"enableIncidentDetectionEmailAlert,omitempty"`
-		EnableAlertsEmail                  bool   `json:"enableAlertsEmail,omitempty"`
-		EnableRootCauseEmailAlert          bool   `json:"enableRootCauseEmailAlert,omitempty"`
-		EmailDampeningPeriod               int    `json:"emailDampeningPeriod"`
-		AlertsEmailDampeningPeriod         int    `json:"alertsEmailDampeningPeriod"`
-		PredictionEmailDampeningPeriod     int    `json:"predictionEmailDampeningPeriod"`
-		AwSeverityLevel                    string `json:"awSeverityLevel,omitempty"`
-	} `json:"emailSetting,omitempty"`
+	EmailSetting EmailSetting `json:"emailSetting,omitempty"`
+}
+
+// EmailSetting holds a project's email notification settings.
+type EmailSetting struct {
+	OnlySendWithRCA                    bool   `json:"onlySendWithRCA,omitempty"`
+	EnableNotificationAW               bool   `json:"enableNotificationAW,omitempty"`
+	EnableIncidentPredictionEmailAlert bool   `json:"enableIncidentPredictionEmailAlert,omitempty"`
+	EnableIncidentDetectionEmailAlert  bool   `json:"enableIncidentDetectionEmailAlert,omitempty"`
+	EnableAlertsEmail                  bool   `json:"enableAlertsEmail,omitempty"`
+	EnableRootCauseEmailAlert          bool   `json:"enableRootCauseEmailAlert,omitempty"`
+	EmailDampeningPeriod               int    `json:"emailDampeningPeriod"`
+	AlertsEmailDampeningPeriod         int    `json:"alertsEmailDampeningPeriod"`
+	PredictionEmailDampeningPeriod     int    `json:"predictionEmailDampeningPeriod"`
+	AwSeverityLevel                    string `json:"awSeverityLevel,omitempty"`
+}
+
+// AwSeverityLevels are the values EmailSetting.AwSeverityLevel accepts.
+var AwSeverityLevels = []string{"Critical", "Major", "Minor", "Warning"}
+
+// LlmEvaluationSetting holds the evaluations run on an LLM project's
+// prompts and responses.
+type LlmEvaluationSetting struct {
+	IsHallucinationEvaluation     bool `json:"isHallucinationEvaluation,omitempty"`
+	IsAnswerRelevantEvaluation    bool `json:"isAnswerRelevantEvaluation,omitempty"`
+	IsLogicConsistencyEvaluation  bool `json:"isLogicConsistencyEvaluation,omitempty"`
+	IsFactualInaccuracyEvaluation bool `json:"isFactualInaccuracyEvaluation,omitempty"`
+	IsMaliciousPromptEvaluation   bool `json:"isMaliciousPromptEvaluation,omitempty"`
+	IsToxicityEvaluation          bool `json:"isToxicityEvaluation,omitempty"`
+	IsPiiPhiLeakageEvaluation     bool `json:"isPiiPhiLeakageEvaluation,omitempty"`
+	IsTopicGuardrailsEvaluation   bool `json:"isTopicGuardrailsEvaluation,omitempty"`
+	IsToneDetectionEvaluation     bool `json:"isToneDetectionEvaluation,omitempty"`
+	IsAnomalousOutliersEvaluation bool `json:"isAnomalousOutliersEvaluation,omitempty"`
+	ShowSafetyTemplate            bool `json:"showSafetyTemplate,omitempty"`
+	IsGenderBiasEvaluation        bool `json:"isGenderBiasEvaluation,omitempty"`
+	IsRacialBiasEvaluation        bool `json:"isRacialBiasEvaluation,omitempty"`
+	IsSocioeconomicBiasEvaluation bool `json:"isSocioeconomicBiasEvaluation,omitempty"`
+	IsCulturalBiasEvaluation      bool `json:"isCulturalBiasEvaluation,omitempty"`
+	IsReligiousBiasEvaluation     bool `json:"isReligiousBiasEvaluation,omitempty"`
+	IsPoliticalBiasEvaluation     bool `json:"isPoliticalBiasEvaluation,omitempty"`
+	IsDisabilityBiasEvaluation    bool `json:"isDisabilityBiasEvaluation,omitempty"`
+	IsAgeBiasEvaluation           bool `json:"isAgeBiasEvaluation,omitempty"`
 }
 
 // ProjectResponse represents the API response for project operations
@@ -185,24 +207,25 @@ type ProjectResponse struct {
 	Data    interface{} `json:"data,omitempty"`
 }
 
-// GetProject retrieves a project's configuration
-func (c *Client) GetProject(projectName, username string) (*ProjectConfig, error) {
+// GetProject retrieves a project's configuration. It returns an error
+// matching ErrNotFound when the project does not exist.
+func (c *Client) GetProject(ctx context.Context, projectName, username string) (*ProjectConfig, error) {
 	params := url.Values{}
 	params.Add("projectList", fmt.Sprintf(`[{"customerName":"%s","projectName":"%s"}]`, username, projectName))
 
 	path := fmt.Sprintf("/api/external/v1/watch-tower-setting?%s", params.Encode())
-	body, statusCode, err := c.DoRequest("GET", path, nil)
+	body, statusCode, err := c.DoRequest(ctx, "GET", path, nil)
 	if err != nil {
 		return nil, err
 	}
 
-	// 404 = endpoint not found, 204 = no content (project deleted)
-	if statusCode == 404 || statusCode == 204 {
-		return nil, nil // Project doesn't exist
+	// 204 = no content (project deleted)
+	if statusCode == 204 {
+		return nil, notFoundError("GET", path, statusCode, fmt.Sprintf("project '%s' not found", projectName))
 	}
 
-	if statusCode != 200 {
-		return nil, fmt.Errorf("failed to get project: HTTP %d", statusCode)
+	if err := checkResponse("GET", path, statusCode, body); err != nil {
+		return nil, err
 	}
 
 	var response map[string]interface{}
@@ -212,7 +235,7 @@ func (c *Client) GetProject(projectName, username string) (*ProjectConfig, error
 
 	settingList, ok := response["settingList"].(map[string]interface{})
 	if !ok || settingList[projectName] == nil {
-		return nil, nil // Project doesn't exist
+		return nil, notFoundError("GET", path, statusCode, fmt.Sprintf("project '%s' not found", projectName))
 	}
 
 	// Parse the project settings
@@ -252,7 +275,7 @@ func (c *Client) GetProject(projectName, username string) (*ProjectConfig, error
 }
 
 // CreateProject creates a new project
-func (c *Client) CreateProject(project *ProjectConfig) error {
+func (c *Client) CreateProject(ctx context.Context, project *ProjectConfig) error {
 	formData := url.Values{}
 	formData.Set("operation", "create")
 	formData.Set("projectName", project.ProjectName)
@@ -271,26 +294,18 @@ func (c *Client) CreateProject(project *ProjectConfig) error {
 		formData.Set("projectCreationType", project.ProjectCreationType)
 	}
 
-	body, statusCode, err := c.DoFormRequest("POST", "/api/v1/check-and-add-custom-project", formData)
+	path := "/api/v1/check-and-add-custom-project"
+	// Projects can create or remove systems
+	defer c.invalidateSystems(c.Username)
+	body, statusCode, err := c.DoFormRequest(ctx, "POST", path, formData)
 	if err != nil {
 		return err
 	}
 
-	// Handle the response
-	if statusCode == 400 {
-		// Check if it's a "project already exists" error
-		var response ProjectResponse
-		if err := json.Unmarshal(body, &response); err == nil {
-			if !response.Success && (strings.Contains(response.Message, "already existed") || strings.Contains(response.Message, "already exists")) {
-				// Project already exists, treat as success
-				return nil
-			}
-		}
-		return fmt.Errorf("failed to create project: HTTP %d - %s", statusCode, string(body))
-	}
-
-	if statusCode != 200 {
-		return fmt.Errorf("failed to create project: HTTP %d - %s", statusCode, string(body))
+	// A name collision is returned as ErrAlreadyExists; whether to adopt the
+	// existing project is up to the caller
+	if err := checkResponse("POST", path, statusCode, body); err != nil {
+		return fmt.Errorf("failed to create project: %w", err)
 	}
 
 	var response ProjectResponse
@@ -298,23 +313,20 @@ func (c *Client) CreateProject(project *ProjectConfig) error {
 		return fmt.Errorf("failed to parse response: %w", err)
 	}
 
-	if !response.Success {
-		return fmt.Errorf("failed to create project: %s", response.Message)
-	}
-
 	return nil
 }
 
 // UpdateProject updates an existing project's configuration
-func (c *Client) UpdateProject(project *ProjectConfig) error {
+func (c *Client) UpdateProject(ctx context.Context, project *ProjectConfig) error {
 	// Build the settings JSON
 	settings := project.Settings
 	if settings == nil {
 		settings = make(map[string]interface{})
 	}
 
-	// Convert settings map to ProjectSettings struct to ensure type safety
-	// and only send fields that are present in the map
+	// Decode into ProjectSettings to catch values of the wrong type, but send
+	// the map itself: the struct's omitempty tags would drop explicit false,
+	// 0 and "" values, making it impossible to turn a setting back off
 	settingsJSON, err := json.Marshal(settings)
 	if err != nil {
 		return fmt.Errorf("failed to marshal settings: %w", err)
@@ -325,64 +337,88 @@ func (c *Client) UpdateProject(project *ProjectConfig) error {
 		return fmt.Errorf("failed to unmarshal to ProjectSettings: %w", err)
 	}
 
-	// Marshal back to map[string]interface{} with omitempty fields excluded
-	finalSettingsJSON, err := json.Marshal(projectSettings)
-	if err != nil {
-		return fmt.Errorf("failed to marshal ProjectSettings: %w", err)
-	}
-
-	var finalSettings map[string]interface{}
-	if err := json.Unmarshal(finalSettingsJSON, &finalSettings); err != nil {
-		return fmt.Errorf("failed to unmarshal final settings: %w", err)
-	}
-
 	path := fmt.Sprintf("/api/external/v1/watch-tower-setting?projectName=%s&customerName=%s",
 		url.QueryEscape(project.ProjectName), url.QueryEscape(c.Username))
 
-	body, statusCode, err := c.DoRequest("POST", path, finalSettings)
+	// Webhook and log label writes go through the same endpoint
+	unlock, err := c.lockProject(ctx, project.ProjectName)
 	if err != nil {
 		return err
 	}
-
-	if statusCode != 200 {
-		return fmt.Errorf("failed to update project: HTTP %d - %s", statusCode, string(body))
+	defer unlock()
+
+	// The API replaces nested objects as a whole; read them under the lock
+	// so that no other write lands between the read and the update
+	if len(project.PartialSettings) > 0 {
+		current, err := c.GetProject(ctx, project.ProjectName, c.Username)
+		if err != nil {
+			return fmt.Errorf("failed to read current project settings: %w", err)
+		}
+		settings = MergePartialSettings(settings, current.Settings, project.PartialSettings)
 	}
 
-	// The update endpoint might return empty body or simple success message
-	if len(body) == 0 {
-		return nil
+	// Settings updates overwrite the stored values, so repeating them is safe
+	body, statusCode, err := c.DoRequest(withIdempotent(ctx), "POST", path, settings)
+	if err != nil {
+		return err
 	}
 
-	var response ProjectResponse
-	if err := json.Unmarshal(body, &response); err != nil {
-		// If response is not JSON, just log and continue
-		return nil
+	// The update endpoint might return empty body or simple success message;
+	// only an error status or "success": false counts as a failure
+	if err := checkResponse("POST", path, statusCode, body); err != nil {
+		return fmt.Errorf("failed to update project: %w", err)
 	}
 
-	if !response.Success {
-		return fmt.Errorf("failed to update project: %s", response.Message)
+	return nil
+}
+
+// MergePartialSettings returns settings with the JSON objects under keys
+// merged over the objects current holds under the same keys. Keys current
+// does not hold as objects are sent as they are; settings is not modified.
+func MergePartialSettings(settings, current map[string]interface{}, keys []string) map[string]interface{} {
+	merged := make(map[string]interface{}, len(settings))
+	for key, value := range settings {
+		merged[key] = value
 	}
 
-	return nil
+	for _, key := range keys {
+		partial, ok := settings[key].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		existing, _ := current[key].(map[string]interface{})
+		object := make(map[string]interface{}, len(existing)+len(partial))
+		for k, v := range existing {
+			object[k] = v
+		}
+		for k, v := range partial {
+			object[k] = v
+		}
+		merged[key] = object
+	}
+	return merged
 }
 
 // DeleteProject deletes a project
-func (c *Client) DeleteProject(projectName string) error {
+func (c *Client) DeleteProject(ctx context.Context, projectName string) error {
 	formData := url.Values{}
 	formData.Set("projectName", projectName)
 
-	body, statusCode, err := c.DoFormRequest("POST", "/api/v1/delete-project", formData)
+	path := "/api/v1/delete-project"
+	// Projects can create or remove systems
+	defer c.invalidateSystems(c.Username)
+	body, statusCode, err := c.DoFormRequest(ctx, "POST", path, formData)
 	if err != nil {
 		return err
 	}
 
-	// Treat 404 and 405 as success (project doesn't exist or endpoint not implemented)
-	if statusCode == 404 || statusCode == 405 {
-		return nil // Already deleted or deletion not supported
+	// Treat 405 as success (deletion endpoint not implemented)
+	if statusCode == 405 {
+		return nil
 	}
 
-	if statusCode != 200 {
-		return fmt.Errorf("failed to delete project: HTTP %d - %s", statusCode, string(body))
+	if err := checkResponse("POST", path, statusCode, body); err != nil {
+		return fmt.Errorf("failed to delete project: %w", err)
 	}
 
 	var response ProjectResponse
@@ -390,9 +426,5 @@ func (c *Client) DeleteProject(projectName string) error {
 		return fmt.Errorf("failed to parse response: %w", err)
 	}
 
-	if !response.Success {
-		return fmt.Errorf("failed to delete project: %s", response.Message)
-	}
-
 	return nil
 }
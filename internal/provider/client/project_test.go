@@ -0,0 +1,79 @@
+// Copyright (c) InsightFinder Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestUpdateProjectSendsExplicitZeroValues(t *testing.T) {
+	var received map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&received); err != nil {
+			t.Errorf("Failed to decode request body: %v", err)
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"success": true}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(server.URL, "test_user", "test_key")
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	err = client.UpdateProject(context.Background(), &ProjectConfig{
+		ProjectName: "test-project",
+		Settings: map[string]interface{}{
+			"enableHotEvent":  false,
+			"nlpFlag":         true,
+			"hotNumberLimit":  0,
+			"proxy":           "",
+			"projectTimeZone": "UTC",
+		},
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	expected := map[string]interface{}{
+		"enableHotEvent":  false,
+		"nlpFlag":         true,
+		"hotNumberLimit":  float64(0),
+		"proxy":           "",
+		"projectTimeZone": "UTC",
+	}
+	if len(received) != len(expected) {
+		t.Errorf("Expected exactly %d settings, got: %v", len(expected), received)
+	}
+	for key, value := range expected {
+		if got, ok := received[key]; !ok || got != value {
+			t.Errorf("Expected %s=%v to be sent, got %v (present: %t)", key, value, got, ok)
+		}
+	}
+}
+
+func TestUpdateProjectRejectsMistypedSettings(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("Expected no request for invalid settings")
+	}))
+	defer server.Close()
+
+	client, err := NewClient(server.URL, "test_user", "test_key")
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	err = client.UpdateProject(context.Background(), &ProjectConfig{
+		ProjectName: "test-project",
+		Settings:    map[string]interface{}{"enableHotEvent": "yes"},
+	})
+	if err == nil {
+		t.Error("Expected error for a string in a boolean setting, got nil")
+	}
+}
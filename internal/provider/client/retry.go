@@ -0,0 +1,156 @@
+// Copyright (c) InsightFinder Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Default retry settings, used when the provider configuration does not
+// override them.
+const (
+	DefaultRetryMaxAttempts = 4
+	DefaultRetryMinBackoff  = 1 * time.Second
+	DefaultRetryMaxBackoff  = 30 * time.Second
+)
+
+// MaxRetryAfter caps the wait a Retry-After header can ask for. Operations
+// without a deadline, such as provider-level calls, would otherwise block
+// for as long as the server says.
+const MaxRetryAfter = 5 * time.Minute
+
+// RetryPolicy controls how failed API calls are retried.
+//
+// Throttled responses (429) are always retried because the server rejected
+// the request before doing any work. Gateway errors (502, 503, 504) and
+// transport failures are only retried for idempotent operations, since the
+// original request may already have been applied.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts, including the first one.
+	// Values below 1 disable retries.
+	MaxAttempts int
+
+	// MinBackoff is the base delay before the first retry. Each following
+	// retry doubles it, up to MaxBackoff, with random jitter applied.
+	MinBackoff time.Duration
+	MaxBackoff time.Duration
+}
+
+// DefaultRetryPolicy returns the retry policy used by NewClient.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts: DefaultRetryMaxAttempts,
+		MinBackoff:  DefaultRetryMinBackoff,
+		MaxBackoff:  DefaultRetryMaxBackoff,
+	}
+}
+
+// backoff returns the jittered delay before the given retry (1-based).
+func (p RetryPolicy) backoff(retry int) time.Duration {
+	if p.MinBackoff <= 0 {
+		return 0
+	}
+
+	delay := p.MinBackoff
+	for i := 1; i < retry; i++ {
+		delay *= 2
+		if p.MaxBackoff > 0 && delay >= p.MaxBackoff {
+			delay = p.MaxBackoff
+			break
+		}
+	}
+	if p.MaxBackoff > 0 && delay > p.MaxBackoff {
+		delay = p.MaxBackoff
+	}
+
+	// Equal jitter: keep half of the delay and randomize the rest so that
+	// parallel resources don't retry in lockstep.
+	half := delay / 2
+	return half + time.Duration(rand.Int63n(int64(delay-half)+1))
+}
+
+type idempotentKey struct{}
+
+// withIdempotent marks a request as safe to repeat. Use it for POST endpoints
+// that overwrite a setting rather than create something new.
+func withIdempotent(ctx context.Context) context.Context {
+	return context.WithValue(ctx, idempotentKey{}, true)
+}
+
+// isIdempotent reports whether a request may be retried after a gateway
+// error or transport failure.
+func isIdempotent(ctx context.Context, method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	marked, _ := ctx.Value(idempotentKey{}).(bool)
+	return marked
+}
+
+// shouldRetry decides whether an attempt that produced the given status code
+// (0 for transport errors) is worth repeating.
+func shouldRetry(statusCode int, idempotent bool) bool {
+	switch statusCode {
+	case http.StatusTooManyRequests:
+		return true
+	case 0, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return idempotent
+	}
+	return false
+}
+
+// parseRetryAfter reads a Retry-After header given either in seconds or as an
+// HTTP date, capped at MaxRetryAfter. It returns false when the header is
+// absent or malformed.
+func parseRetryAfter(header http.Header, now time.Time) (time.Duration, bool) {
+	value := header.Get("Retry-After")
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		if seconds > int(MaxRetryAfter/time.Second) {
+			return MaxRetryAfter, true
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		wait := date.Sub(now)
+		if wait > MaxRetryAfter {
+			return MaxRetryAfter, true
+		}
+		if wait > 0 {
+			return wait, true
+		}
+		return 0, true
+	}
+
+	return 0, false
+}
+
+// sleepContext waits for the given duration or until ctx is done.
+func sleepContext(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
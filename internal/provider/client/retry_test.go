@@ -0,0 +1,298 @@
+// Copyright (c) InsightFinder Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newRetryTestClient returns a client pointed at server with a fast retry
+// policy so tests don't spend seconds sleeping.
+func newRetryTestClient(t *testing.T, serverURL string, maxAttempts int) *Client {
+	t.Helper()
+
+	client, err := NewClient(serverURL, "test_user", "test_key")
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	client.Retry = RetryPolicy{
+		MaxAttempts: maxAttempts,
+		MinBackoff:  time.Millisecond,
+		MaxBackoff:  5 * time.Millisecond,
+	}
+	return client
+}
+
+func TestDoRequestRetry(t *testing.T) {
+	tests := []struct {
+		name             string
+		method           string
+		idempotent       bool
+		failures         int
+		failStatus       int
+		maxAttempts      int
+		expectedStatus   int
+		expectedAttempts int32
+	}{
+		{
+			name:             "GET retried on 503",
+			method:           "GET",
+			failures:         2,
+			failStatus:       http.StatusServiceUnavailable,
+			maxAttempts:      4,
+			expectedStatus:   http.StatusOK,
+			expectedAttempts: 3,
+		},
+		{
+			name:             "GET gives up after max attempts",
+			method:           "GET",
+			failures:         10,
+			failStatus:       http.StatusBadGateway,
+			maxAttempts:      3,
+			expectedStatus:   http.StatusBadGateway,
+			expectedAttempts: 3,
+		},
+		{
+			name:             "POST not retried on 502",
+			method:           "POST",
+			failures:         1,
+			failStatus:       http.StatusBadGateway,
+			maxAttempts:      4,
+			expectedStatus:   http.StatusBadGateway,
+			expectedAttempts: 1,
+		},
+		{
+			name:             "idempotent POST retried on 504",
+			method:           "POST",
+			idempotent:       true,
+			failures:         1,
+			failStatus:       http.StatusGatewayTimeout,
+			maxAttempts:      4,
+			expectedStatus:   http.StatusOK,
+			expectedAttempts: 2,
+		},
+		{
+			name:             "POST retried on 429",
+			method:           "POST",
+			failures:         2,
+			failStatus:       http.StatusTooManyRequests,
+			maxAttempts:      4,
+			expectedStatus:   http.StatusOK,
+			expectedAttempts: 3,
+		},
+		{
+			name:             "500 not retried",
+			method:           "GET",
+			failures:         1,
+			failStatus:       http.StatusInternalServerError,
+			maxAttempts:      4,
+			expectedStatus:   http.StatusInternalServerError,
+			expectedAttempts: 1,
+		},
+		{
+			name:             "retries disabled",
+			method:           "GET",
+			failures:         1,
+			failStatus:       http.StatusServiceUnavailable,
+			maxAttempts:      1,
+			expectedStatus:   http.StatusServiceUnavailable,
+			expectedAttempts: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var attempts int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if n := atomic.AddInt32(&attempts, 1); int(n) <= tt.failures {
+					w.WriteHeader(tt.failStatus)
+					return
+				}
+				w.WriteHeader(http.StatusOK)
+				_, _ = w.Write([]byte(`{"success":true}`))
+			}))
+			defer server.Close()
+
+			client := newRetryTestClient(t, server.URL, tt.maxAttempts)
+
+			ctx := context.Background()
+			if tt.idempotent {
+				ctx = withIdempotent(ctx)
+			}
+
+			_, statusCode, err := client.DoRequest(ctx, tt.method, "/api/test", map[string]string{"key": "value"})
+			if err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+			if statusCode != tt.expectedStatus {
+				t.Errorf("Expected status code %d, got %d", tt.expectedStatus, statusCode)
+			}
+			if got := atomic.LoadInt32(&attempts); got != tt.expectedAttempts {
+				t.Errorf("Expected %d attempts, got %d", tt.expectedAttempts, got)
+			}
+		})
+	}
+}
+
+func TestDoRequestRetryResendsBody(t *testing.T) {
+	var attempts int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			t.Errorf("Failed to parse form: %v", err)
+		}
+		if r.FormValue("field") != "value" {
+			t.Errorf("Expected form field on attempt %d, got '%s'", atomic.LoadInt32(&attempts)+1, r.FormValue("field"))
+		}
+		if atomic.AddInt32(&attempts, 1) == 1 {
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := newRetryTestClient(t, server.URL, 3)
+
+	_, statusCode, err := client.DoFormRequest(context.Background(), "POST", "/api/form", url.Values{"field": []string{"value"}})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if statusCode != http.StatusOK {
+		t.Errorf("Expected status code 200, got %d", statusCode)
+	}
+	if got := atomic.LoadInt32(&attempts); got != 2 {
+		t.Errorf("Expected 2 attempts, got %d", got)
+	}
+}
+
+func TestDoRequestRetryAfter(t *testing.T) {
+	var attempts int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&attempts, 1) == 1 {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := newRetryTestClient(t, server.URL, 2)
+
+	start := time.Now()
+	_, statusCode, err := client.DoRequest(context.Background(), "GET", "/api/test", nil)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if statusCode != http.StatusOK {
+		t.Errorf("Expected status code 200, got %d", statusCode)
+	}
+	if elapsed := time.Since(start); elapsed < time.Second {
+		t.Errorf("Expected Retry-After of 1s to be honored, retried after %s", elapsed)
+	}
+}
+
+func TestDoRequestRetryAfterBeyondDeadline(t *testing.T) {
+	var attempts int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&attempts, 1)
+		w.Header().Set("Retry-After", "120")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	client := newRetryTestClient(t, server.URL, 4)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, statusCode, err := client.DoRequest(ctx, "GET", "/api/test", nil)
+	if err != nil {
+		t.Fatalf("Expected the last response instead of an error, got: %v", err)
+	}
+	if statusCode != http.StatusTooManyRequests {
+		t.Errorf("Expected status code 429, got %d", statusCode)
+	}
+	if got := atomic.LoadInt32(&attempts); got != 1 {
+		t.Errorf("Expected 1 attempt, got %d", got)
+	}
+}
+
+func TestDoRequestRetryTransportError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	serverURL := server.URL
+	server.Close()
+
+	client := newRetryTestClient(t, serverURL, 3)
+
+	if _, _, err := client.DoRequest(context.Background(), "GET", "/api/test", nil); err == nil {
+		t.Error("Expected error from closed server, got nil")
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		value    string
+		expected time.Duration
+		ok       bool
+	}{
+		{name: "absent", value: "", ok: false},
+		{name: "seconds", value: "7", expected: 7 * time.Second, ok: true},
+		{name: "negative seconds", value: "-1", ok: false},
+		{name: "http date", value: now.Add(90 * time.Second).Format(http.TimeFormat), expected: 90 * time.Second, ok: true},
+		{name: "http date in the past", value: now.Add(-time.Minute).Format(http.TimeFormat), expected: 0, ok: true},
+		{name: "oversized seconds", value: "3600", expected: MaxRetryAfter, ok: true},
+		{name: "overflowing seconds", value: "99999999999999999", expected: MaxRetryAfter, ok: true},
+		{name: "far-future http date", value: now.Add(24 * time.Hour).Format(http.TimeFormat), expected: MaxRetryAfter, ok: true},
+		{name: "malformed", value: "soon", ok: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			header := http.Header{}
+			if tt.value != "" {
+				header.Set("Retry-After", tt.value)
+			}
+
+			got, ok := parseRetryAfter(header, now)
+			if ok != tt.ok {
+				t.Fatalf("Expected ok=%v, got %v", tt.ok, ok)
+			}
+			if got != tt.expected {
+				t.Errorf("Expected %s, got %s", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	policy := RetryPolicy{
+		MaxAttempts: 10,
+		MinBackoff:  100 * time.Millisecond,
+		MaxBackoff:  time.Second,
+	}
+
+	for retry := 1; retry <= 8; retry++ {
+		ceiling := 100 * time.Millisecond << (retry - 1)
+		if ceiling > time.Second {
+			ceiling = time.Second
+		}
+
+		for i := 0; i < 20; i++ {
+			got := policy.backoff(retry)
+			if got < ceiling/2 || got > ceiling {
+				t.Fatalf("Retry %d: expected backoff in [%s, %s], got %s", retry, ceiling/2, ceiling, got)
+			}
+		}
+	}
+}
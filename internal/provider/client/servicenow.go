@@ -4,6 +4,7 @@
 package client
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/url"
@@ -33,8 +34,9 @@ type ServiceNowResponse struct {
 	Data    interface{} `json:"data,omitempty"`
 }
 
-// GetServiceNowConfig retrieves ServiceNow integration configuration
-func (c *Client) GetServiceNowConfig(account, serviceHost, username string) (*ServiceNowConfig, error) {
+// GetServiceNowConfig retrieves ServiceNow integration configuration. It
+// returns an error matching ErrNotFound when the integration does not exist.
+func (c *Client) GetServiceNowConfig(ctx context.Context, account, serviceHost, username string) (*ServiceNowConfig, error) {
 	params := url.Values{}
 	params.Add("tzOffset", "0")
 	params.Add("account", account)
@@ -44,17 +46,17 @@ func (c *Client) GetServiceNowConfig(account, serviceHost, username string) (*Se
 	params.Add("service_host", serviceHost)
 
 	path := fmt.Sprintf("/api/external/v1/service-integration?%s", params.Encode())
-	body, statusCode, err := c.DoRequest("GET", path, nil)
+	body, statusCode, err := c.DoRequest(ctx, "GET", path, nil)
 	if err != nil {
 		return nil, err
 	}
 
-	if statusCode == 404 || statusCode == 204 {
-		return nil, nil // Configuration doesn't exist
+	if statusCode == 204 {
+		return nil, notFoundError("GET", path, statusCode, "ServiceNow integration not found")
 	}
 
-	if statusCode != 200 {
-		return nil, fmt.Errorf("failed to get ServiceNow config: HTTP %d", statusCode)
+	if err := checkResponse("GET", path, statusCode, body); err != nil {
+		return nil, fmt.Errorf("failed to get ServiceNow config: %w", err)
 	}
 
 	var response map[string]interface{}
@@ -64,7 +66,7 @@ func (c *Client) GetServiceNowConfig(account, serviceHost, username string) (*Se
 
 	// Check if the key exists - if not, configuration doesn't exist
 	if _, ok := response["key"]; !ok {
-		return nil, nil // Configuration doesn't exist
+		return nil, notFoundError("GET", path, statusCode, "ServiceNow integration not found")
 	}
 
 	// Parse the configuration
@@ -127,7 +129,7 @@ func (c *Client) GetServiceNowConfig(account, serviceHost, username string) (*Se
 	}
 
 	if len(config.SystemNames) == 0 && len(config.SystemIDs) > 0 {
-		if names, err := c.ResolveSystemIDsToNames(config.SystemIDs, username); err == nil {
+		if names, err := c.ResolveSystemIDsToNames(ctx, config.SystemIDs, username); err == nil {
 			config.SystemNames = names
 		}
 	}
@@ -136,7 +138,7 @@ func (c *Client) GetServiceNowConfig(account, serviceHost, username string) (*Se
 }
 
 // CreateOrUpdateServiceNowConfig creates or updates ServiceNow integration
-func (c *Client) CreateOrUpdateServiceNowConfig(config *ServiceNowConfig, username string, verify bool) error {
+func (c *Client) CreateOrUpdateServiceNowConfig(ctx context.Context, config *ServiceNowConfig, username string, verify bool) error {
 	// Format system IDs as JSON array string
 	systemIDsJSON, err := json.Marshal(config.SystemIDs)
 	if err != nil {
@@ -177,35 +179,23 @@ func (c *Client) CreateOrUpdateServiceNowConfig(config *ServiceNowConfig, userna
 	formData.Set("options", string(optionsJSON))
 	formData.Set("contentOption", string(contentOptionJSON))
 
+	// Integrations are keyed by account and host, so repeating the call is safe
 	path := "/api/external/v1/service-integration"
-	body, statusCode, err := c.DoFormRequest("POST", path, formData)
+	body, statusCode, err := c.DoFormRequest(withIdempotent(ctx), "POST", path, formData)
 	if err != nil {
 		return err
 	}
 
-	if statusCode != 200 {
-		return fmt.Errorf("failed to configure ServiceNow: HTTP %d - %s", statusCode, string(body))
-	}
-
-	// Check if response indicates success
-	var response ServiceNowResponse
-	if err := json.Unmarshal(body, &response); err != nil {
-		// If we can't parse the response but got 200, assume success
-		return nil
-	}
-
-	if !response.Success {
-		if response.Message != "" {
-			return fmt.Errorf("ServiceNow configuration failed: %s", response.Message)
-		}
-		return fmt.Errorf("ServiceNow configuration failed")
+	// A 200 with an unparseable body counts as success
+	if err := checkResponse("POST", path, statusCode, body); err != nil {
+		return fmt.Errorf("failed to configure ServiceNow: %w", err)
 	}
 
 	return nil
 }
 
 // DeleteServiceNowConfig removes ServiceNow integration
-func (c *Client) DeleteServiceNowConfig(account, serviceHost, username string) error {
+func (c *Client) DeleteServiceNowConfig(ctx context.Context, account, serviceHost, username string) error {
 	serviceHost = strings.TrimSpace(serviceHost)
 	if serviceHost == "" {
 		return fmt.Errorf("service_host is required for deletion")
@@ -220,87 +210,33 @@ func (c *Client) DeleteServiceNowConfig(account, serviceHost, username string) e
 	formData.Set("serviceOwner", username)
 	formData.Set("customerName", username)
 
+	// Integrations are keyed by account and host, so repeating the call is safe
 	path := "/api/external/v1/service-integration"
-	body, statusCode, err := c.DoFormRequest("POST", path, formData)
+	body, statusCode, err := c.DoFormRequest(withIdempotent(ctx), "POST", path, formData)
 	if err != nil {
 		return err
 	}
 
-	// 200 or 404 are both acceptable for deletion
-	if statusCode != 200 && statusCode != 404 {
-		return fmt.Errorf("failed to delete ServiceNow config: HTTP %d - %s", statusCode, string(body))
+	if err := checkResponse("POST", path, statusCode, body); err != nil {
+		return fmt.Errorf("failed to delete ServiceNow config: %w", err)
 	}
 
 	return nil
 }
 
 // ResolveSystemNameToIDs converts system names to system IDs
-func (c *Client) ResolveSystemNameToIDs(systemNames []string, username string) ([]string, error) {
+func (c *Client) ResolveSystemNameToIDs(ctx context.Context, systemNames []string, username string) ([]string, error) {
 	if len(systemNames) == 0 {
 		return []string{}, nil
 	}
 
-	systemFramework, err := c.GetSystemFramework(username, true)
+	idx, err := c.systemIndex(ctx, username)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get system framework: %w", err)
 	}
 
-	if systemFramework == nil {
-		return nil, fmt.Errorf("no systems found")
-	}
-
-	systems := make([]string, 0, len(systemFramework.OwnSystemArr)+len(systemFramework.ShareSystemArr))
-	systems = append(systems, systemFramework.OwnSystemArr...)
-	systems = append(systems, systemFramework.ShareSystemArr...)
-
-	if len(systems) == 0 {
-		return nil, fmt.Errorf("no systems found")
-	}
-
-	nameToID := make(map[string]string)
-
-	for _, systemStr := range systems {
-		var system SystemFramework
-		if err := json.Unmarshal([]byte(systemStr), &system); err != nil {
-			continue
-		}
-
-		idCandidates := []string{
-			strings.TrimSpace(system.SystemKey.SystemName),
-			strings.TrimSpace(system.SystemID),
-			strings.TrimSpace(system.SystemName),
-		}
-
-		var resolvedID string
-		for _, candidate := range idCandidates {
-			if candidate != "" {
-				resolvedID = candidate
-				break
-			}
-		}
-		if resolvedID == "" {
-			continue
-		}
-
-		nameCandidates := []string{
-			strings.TrimSpace(system.SystemDisplayName),
-			strings.TrimSpace(system.SystemName),
-		}
-
-		for _, candidate := range nameCandidates {
-			if candidate == "" {
-				continue
-			}
-
-			normalized := strings.ToLower(candidate)
-			if _, exists := nameToID[normalized]; !exists {
-				nameToID[normalized] = resolvedID
-			}
-		}
-	}
-
-	if len(nameToID) == 0 {
-		return nil, fmt.Errorf("no systems found")
+	if len(idx.nameToID) == 0 {
+		return nil, fmt.Errorf("no systems found: %w", ErrNotFound)
 	}
 
 	systemIDs := make([]string, 0, len(systemNames))
@@ -313,7 +249,7 @@ func (c *Client) ResolveSystemNameToIDs(systemNames []string, username string) (
 		}
 
 		normalized := strings.ToLower(trimmed)
-		if id, ok := nameToID[normalized]; ok {
+		if id, ok := idx.nameToID[normalized]; ok {
 			systemIDs = append(systemIDs, id)
 		} else {
 			missing = append(missing, trimmed)
@@ -321,79 +257,35 @@ func (c *Client) ResolveSystemNameToIDs(systemNames []string, username string) (
 	}
 
 	if len(missing) > 0 {
-		return nil, fmt.Errorf("system(s) not found: %s", strings.Join(missing, ", "))
+		return nil, fmt.Errorf("system(s) %s: %w", strings.Join(missing, ", "), ErrNotFound)
 	}
 
 	return systemIDs, nil
 }
 
 // ResolveSystemIDsToNames converts system IDs to system names
-func (c *Client) ResolveSystemIDsToNames(systemIDs []string, username string) ([]string, error) {
+func (c *Client) ResolveSystemIDsToNames(ctx context.Context, systemIDs []string, username string) ([]string, error) {
 	if len(systemIDs) == 0 {
 		return []string{}, nil
 	}
 
-	systemFramework, err := c.GetSystemFramework(username, true)
+	idx, err := c.systemIndex(ctx, username)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get system framework: %w", err)
 	}
 
-	if systemFramework == nil {
-		return nil, fmt.Errorf("no systems found")
-	}
-
-	systems := make([]string, 0, len(systemFramework.OwnSystemArr)+len(systemFramework.ShareSystemArr))
-	systems = append(systems, systemFramework.OwnSystemArr...)
-	systems = append(systems, systemFramework.ShareSystemArr...)
-
-	if len(systems) == 0 {
-		return nil, fmt.Errorf("no systems found")
+	if len(idx.systems) == 0 {
+		return nil, fmt.Errorf("no systems found: %w", ErrNotFound)
 	}
 
 	systemNames := make([]string, 0, len(systemIDs))
-	idToName := make(map[string]string)
-
-	for _, systemStr := range systems {
-		var system SystemFramework
-		if err := json.Unmarshal([]byte(systemStr), &system); err != nil {
-			continue
-		}
-
-		idCandidates := []string{
-			strings.TrimSpace(system.SystemKey.SystemName),
-			strings.TrimSpace(system.SystemID),
-			strings.TrimSpace(system.SystemName),
-		}
-
-		var resolvedID string
-		for _, candidate := range idCandidates {
-			if candidate != "" {
-				resolvedID = candidate
-				break
-			}
-		}
-		if resolvedID == "" {
-			continue
-		}
-
-		displayName := strings.TrimSpace(system.SystemDisplayName)
-		if displayName == "" {
-			displayName = strings.TrimSpace(system.SystemName)
-		}
-		if displayName == "" {
-			continue
-		}
-
-		idToName[resolvedID] = displayName
-	}
-
 	for _, id := range systemIDs {
 		trimmedID := strings.TrimSpace(id)
 		if trimmedID == "" {
 			continue
 		}
 
-		if name, ok := idToName[trimmedID]; ok {
+		if name, ok := idx.idToName[trimmedID]; ok {
 			systemNames = append(systemNames, name)
 		} else {
 			systemNames = append(systemNames, trimmedID)
@@ -0,0 +1,164 @@
+// Copyright (c) InsightFinder Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultSystemCacheTTL is how long a downloaded system framework is reused
+// for name and ID resolution before it is fetched again.
+const DefaultSystemCacheTTL = 5 * time.Minute
+
+// systemIndex is a parsed system framework with lookups by name and ID.
+type systemIndex struct {
+	// systems holds own systems followed by shared ones, in API order.
+	systems []SystemFramework
+
+	// nameToID maps lower-cased display and system names to system IDs.
+	// When two systems share a name, the first one wins.
+	nameToID map[string]string
+
+	// idToName maps system IDs to display names.
+	idToName map[string]string
+
+	fetchedAt time.Time
+}
+
+// systemID returns the identifier of a system: the system key name (a
+// hash), falling back to the system ID and then the system name.
+func systemID(system SystemFramework) string {
+	for _, candidate := range []string{
+		strings.TrimSpace(system.SystemKey.SystemName),
+		strings.TrimSpace(system.SystemID),
+		strings.TrimSpace(system.SystemName),
+	} {
+		if candidate != "" {
+			return candidate
+		}
+	}
+	return ""
+}
+
+// newSystemIndex parses a system framework response. Entries that fail to
+// parse or have no identifier are skipped.
+func newSystemIndex(response *SystemFrameworkResponse) *systemIndex {
+	idx := &systemIndex{
+		nameToID:  make(map[string]string),
+		idToName:  make(map[string]string),
+		fetchedAt: time.Now(),
+	}
+	if response == nil {
+		return idx
+	}
+
+	raw := make([]string, 0, len(response.OwnSystemArr)+len(response.ShareSystemArr))
+	raw = append(raw, response.OwnSystemArr...)
+	raw = append(raw, response.ShareSystemArr...)
+
+	for _, systemStr := range raw {
+		var system SystemFramework
+		if err := json.Unmarshal([]byte(systemStr), &system); err != nil {
+			continue
+		}
+
+		id := systemID(system)
+		if id == "" {
+			continue
+		}
+		idx.systems = append(idx.systems, system)
+
+		for _, candidate := range []string{
+			strings.TrimSpace(system.SystemDisplayName),
+			strings.TrimSpace(system.SystemName),
+		} {
+			if candidate == "" {
+				continue
+			}
+			normalized := strings.ToLower(candidate)
+			if _, exists := idx.nameToID[normalized]; !exists {
+				idx.nameToID[normalized] = id
+			}
+		}
+
+		displayName := strings.TrimSpace(system.SystemDisplayName)
+		if displayName == "" {
+			displayName = strings.TrimSpace(system.SystemName)
+		}
+		if displayName != "" {
+			idx.idToName[id] = displayName
+		}
+	}
+
+	return idx
+}
+
+// find returns the system matching id on any of its identifiers.
+func (idx *systemIndex) find(id string) *SystemFramework {
+	for i := range idx.systems {
+		system := &idx.systems[i]
+		for _, candidate := range []string{
+			strings.TrimSpace(system.SystemKey.SystemName),
+			strings.TrimSpace(system.SystemID),
+			strings.TrimSpace(system.SystemName),
+		} {
+			if candidate != "" && strings.EqualFold(candidate, id) {
+				return system
+			}
+		}
+	}
+	return nil
+}
+
+// systemCache keeps one system index per customer.
+type systemCache struct {
+	mu      sync.Mutex
+	entries map[string]*systemIndex
+}
+
+// systemIndex returns the parsed system framework for username, using the
+// cache when it is enabled and fresh. Concurrent callers wait for a single
+// download instead of each fetching the framework.
+func (c *Client) systemIndex(ctx context.Context, username string) (*systemIndex, error) {
+	if c.SystemCacheTTL <= 0 {
+		response, err := c.GetSystemFramework(ctx, username, true)
+		if err != nil {
+			return nil, err
+		}
+		return newSystemIndex(response), nil
+	}
+
+	c.systems.mu.Lock()
+	defer c.systems.mu.Unlock()
+
+	if idx, ok := c.systems.entries[username]; ok && time.Since(idx.fetchedAt) < c.SystemCacheTTL {
+		return idx, nil
+	}
+
+	response, err := c.GetSystemFramework(ctx, username, true)
+	if err != nil {
+		return nil, err
+	}
+
+	idx := newSystemIndex(response)
+	if c.systems.entries == nil {
+		c.systems.entries = make(map[string]*systemIndex)
+	}
+	c.systems.entries[username] = idx
+
+	return idx, nil
+}
+
+// invalidateSystems drops the cached system framework for username. Call it
+// after any request that may create, delete or modify a system.
+func (c *Client) invalidateSystems(username string) {
+	c.systems.mu.Lock()
+	defer c.systems.mu.Unlock()
+
+	delete(c.systems.entries, username)
+}
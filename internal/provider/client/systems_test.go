@@ -0,0 +1,207 @@
+// Copyright (c) InsightFinder Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newSystemFrameworkServer serves a system framework with two systems and
+// counts GET requests. POSTs are accepted and update the JWT secret of the
+// first system.
+func newSystemFrameworkServer(t *testing.T) (*httptest.Server, *int32) {
+	t.Helper()
+
+	var (
+		mu     sync.Mutex
+		gets   int32
+		secret = "initial-secret"
+	)
+
+	system := func(id, name, display, setting string) string {
+		raw, err := json.Marshal(map[string]interface{}{
+			"systemKey":         map[string]string{"userName": "test_user", "systemName": id, "environmentName": "All"},
+			"systemName":        name,
+			"systemDisplayName": display,
+			"systemSetting":     setting,
+		})
+		if err != nil {
+			t.Fatalf("Failed to marshal system: %v", err)
+		}
+		return string(raw)
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		if r.Method == http.MethodPost {
+			if err := r.ParseForm(); err != nil {
+				t.Errorf("Failed to parse form: %v", err)
+			}
+			var setting map[string]interface{}
+			_ = json.Unmarshal([]byte(r.FormValue("systemFrameworkSetting")), &setting)
+			secret, _ = setting["systemLevelJWTSecret"].(string)
+			_, _ = w.Write([]byte(`{"success":true}`))
+			return
+		}
+
+		atomic.AddInt32(&gets, 1)
+		setting, _ := json.Marshal(map[string]interface{}{"systemLevelJWTSecret": secret, "jwtType": 1})
+		_ = json.NewEncoder(w).Encode(SystemFrameworkResponse{
+			Success: true,
+			OwnSystemArr: []string{
+				system("hash-a", "hash-a", "System A", string(setting)),
+				system("hash-b", "hash-b", "System B", ""),
+			},
+		})
+	}))
+
+	return server, &gets
+}
+
+func TestSystemCacheResolution(t *testing.T) {
+	server, gets := newSystemFrameworkServer(t)
+	defer server.Close()
+
+	client, err := NewClient(server.URL, "test_user", "test_key")
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	ctx := context.Background()
+
+	ids, err := client.ResolveSystemNameToIDs(ctx, []string{"system a", "System B"}, "test_user")
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if len(ids) != 2 || ids[0] != "hash-a" || ids[1] != "hash-b" {
+		t.Errorf("Expected [hash-a hash-b], got %v", ids)
+	}
+
+	names, err := client.ResolveSystemIDsToNames(ctx, []string{"hash-b", "unknown"}, "test_user")
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if len(names) != 2 || names[0] != "System B" || names[1] != "unknown" {
+		t.Errorf("Expected [System B unknown], got %v", names)
+	}
+
+	jwt, err := client.GetJWTConfig(ctx, "System A", "test_user")
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if jwt.SystemID != "hash-a" || jwt.JWTSecret != "initial-secret" {
+		t.Errorf("Unexpected JWT config: %+v", jwt)
+	}
+
+	if got := atomic.LoadInt32(gets); got != 1 {
+		t.Errorf("Expected one system framework download, got %d", got)
+	}
+
+	_, err = client.ResolveSystemNameToIDs(ctx, []string{"System C"}, "test_user")
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound for unknown system, got: %v", err)
+	}
+}
+
+func TestSystemCacheInvalidatedByWrite(t *testing.T) {
+	server, gets := newSystemFrameworkServer(t)
+	defer server.Close()
+
+	client, err := NewClient(server.URL, "test_user", "test_key")
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	ctx := context.Background()
+
+	if _, err := client.GetJWTConfig(ctx, "System A", "test_user"); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if err := client.CreateOrUpdateJWTConfig(ctx, &JWTConfig{SystemName: "System A", JWTSecret: "rotated-secret", JWTType: 1}, "test_user"); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	jwt, err := client.GetJWTConfig(ctx, "System A", "test_user")
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if jwt.JWTSecret != "rotated-secret" {
+		t.Errorf("Expected refreshed secret after write, got '%s'", jwt.JWTSecret)
+	}
+	if got := atomic.LoadInt32(gets); got != 2 {
+		t.Errorf("Expected two system framework downloads, got %d", got)
+	}
+}
+
+func TestSystemCacheExpiryAndDisable(t *testing.T) {
+	tests := []struct {
+		name          string
+		ttl           time.Duration
+		sleep         time.Duration
+		expectedFetch int32
+	}{
+		{name: "fresh", ttl: time.Minute, expectedFetch: 1},
+		{name: "expired", ttl: 10 * time.Millisecond, sleep: 20 * time.Millisecond, expectedFetch: 2},
+		{name: "disabled", ttl: 0, expectedFetch: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, gets := newSystemFrameworkServer(t)
+			defer server.Close()
+
+			client, err := NewClient(server.URL, "test_user", "test_key")
+			if err != nil {
+				t.Fatalf("Failed to create client: %v", err)
+			}
+			client.SystemCacheTTL = tt.ttl
+
+			for i := 0; i < 2; i++ {
+				if _, err := client.ResolveSystemNameToIDs(context.Background(), []string{"System A"}, "test_user"); err != nil {
+					t.Fatalf("Expected no error, got: %v", err)
+				}
+				time.Sleep(tt.sleep)
+			}
+
+			if got := atomic.LoadInt32(gets); got != tt.expectedFetch {
+				t.Errorf("Expected %d downloads, got %d", tt.expectedFetch, got)
+			}
+		})
+	}
+}
+
+func TestSystemCacheConcurrentReaders(t *testing.T) {
+	server, gets := newSystemFrameworkServer(t)
+	defer server.Close()
+
+	client, err := NewClient(server.URL, "test_user", "test_key")
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := client.ResolveSystemNameToIDs(context.Background(), []string{"System B"}, "test_user"); err != nil {
+				t.Errorf("Expected no error, got: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got := atomic.LoadInt32(gets); got != 1 {
+		t.Errorf("Expected concurrent readers to share one download, got %d", got)
+	}
+}
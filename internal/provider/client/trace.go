@@ -0,0 +1,159 @@
+// Copyright (c) InsightFinder Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// redactedValue replaces secrets in traced requests and responses.
+const redactedValue = "***"
+
+// maxTraceBodyLength caps how much of a request or response body is traced.
+const maxTraceBodyLength = 4096
+
+// sensitiveKeys lists, in lower case, the JSON fields, form fields, query
+// parameters and headers whose values are never traced.
+var sensitiveKeys = map[string]bool{
+	"licensekey":           true,
+	"x-api-key":            true,
+	"authorization":        true,
+	"password":             true,
+	"appkey":               true,
+	"app_key":              true,
+	"systemleveljwtsecret": true,
+	"jwtsecret":            true,
+	"webhookheaderlist":    true,
+	"webhookheaders":       true,
+}
+
+func isSensitiveKey(key string) bool {
+	return sensitiveKeys[strings.ToLower(key)]
+}
+
+// redactHeaders returns a copy of the headers with secret values masked.
+func redactHeaders(header http.Header) map[string]string {
+	redacted := make(map[string]string, len(header))
+	for key, values := range header {
+		if isSensitiveKey(key) {
+			redacted[key] = redactedValue
+			continue
+		}
+		redacted[key] = strings.Join(values, ", ")
+	}
+	return redacted
+}
+
+// redactQuery returns the query string of path with secret parameters
+// masked. Parameter values holding JSON are redacted recursively.
+func redactQuery(path string) string {
+	i := strings.IndexByte(path, '?')
+	if i < 0 {
+		return ""
+	}
+	values, err := url.ParseQuery(path[i+1:])
+	if err != nil {
+		return redactedValue
+	}
+	return redactValues(values).Encode()
+}
+
+// redactValues masks secret form or query values. Values holding JSON, such
+// as the systemFrameworkSetting form field, are redacted recursively.
+func redactValues(values url.Values) url.Values {
+	redacted := make(url.Values, len(values))
+	for key, vals := range values {
+		for _, v := range vals {
+			if isSensitiveKey(key) {
+				redacted.Add(key, redactedValue)
+				continue
+			}
+			redacted.Add(key, redactJSONString(v))
+		}
+	}
+	return redacted
+}
+
+// redactBody returns a loggable, truncated version of a request or response
+// body. JSON and form bodies are parsed so individual secrets can be masked;
+// other bodies are traced as-is.
+func redactBody(contentType string, body []byte) string {
+	if len(body) == 0 {
+		return ""
+	}
+
+	var out string
+	switch {
+	case strings.HasPrefix(contentType, "application/x-www-form-urlencoded"):
+		values, err := url.ParseQuery(string(body))
+		if err != nil {
+			return redactedValue
+		}
+		out = redactValues(values).Encode()
+	default:
+		out = redactJSONString(string(body))
+	}
+
+	return truncate(out, maxTraceBodyLength)
+}
+
+// redactJSONString masks secrets in s if it is a JSON object or array, and
+// returns it unchanged otherwise.
+func redactJSONString(s string) string {
+	trimmed := strings.TrimSpace(s)
+	if !strings.HasPrefix(trimmed, "{") && !strings.HasPrefix(trimmed, "[") {
+		return s
+	}
+
+	var value interface{}
+	if err := json.Unmarshal([]byte(trimmed), &value); err != nil {
+		return s
+	}
+
+	redacted, err := json.Marshal(redactJSON(value))
+	if err != nil {
+		return redactedValue
+	}
+	return string(redacted)
+}
+
+// redactJSON walks a decoded JSON value and masks secret fields. The API
+// nests settings as JSON-encoded strings, so string values are redacted
+// recursively too.
+func redactJSON(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, item := range v {
+			if isSensitiveKey(key) {
+				v[key] = redactedValue
+				continue
+			}
+			v[key] = redactJSON(item)
+		}
+		return v
+	case []interface{}:
+		for i, item := range v {
+			v[i] = redactJSON(item)
+		}
+		return v
+	case string:
+		return redactJSONString(v)
+	}
+	return value
+}
+
+// mergeFields returns a new log field map holding base and extra.
+func mergeFields(base, extra map[string]any) map[string]any {
+	merged := make(map[string]any, len(base)+len(extra))
+	for k, v := range base {
+		merged[k] = v
+	}
+	for k, v := range extra {
+		merged[k] = v
+	}
+	return merged
+}
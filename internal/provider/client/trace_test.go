@@ -0,0 +1,163 @@
+// Copyright (c) InsightFinder Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+)
+
+func TestRedactBody(t *testing.T) {
+	tests := []struct {
+		name        string
+		contentType string
+		body        string
+		secrets     []string
+		keeps       []string
+	}{
+		{
+			name:        "form with license key and password",
+			contentType: "application/x-www-form-urlencoded",
+			body:        url.Values{"licenseKey": {"lk-123"}, "password": {"pw-456"}, "account": {"admin"}}.Encode(),
+			secrets:     []string{"lk-123", "pw-456"},
+			keeps:       []string{"account=admin"},
+		},
+		{
+			name:        "form with JSON field holding JWT secret",
+			contentType: "application/x-www-form-urlencoded",
+			body:        url.Values{"systemFrameworkSetting": {`{"systemLevelJWTSecret":"jwt-789","jwtType":1}`}}.Encode(),
+			secrets:     []string{"jwt-789"},
+			keeps:       []string{"jwtType"},
+		},
+		{
+			name:        "JSON with app key",
+			contentType: "application/json",
+			body:        `{"appKey":"ak-1","appId":"app","nested":[{"password":"pw-2"}]}`,
+			secrets:     []string{"ak-1", "pw-2"},
+			keeps:       []string{`"appId":"app"`},
+		},
+		{
+			name:        "JSON with webhook headers",
+			contentType: "application/json",
+			body:        `{"webhookHeaderList":[{"key":"Authorization","value":"Bearer tok-3"}],"webhookUrl":"https://hooks.example.com"}`,
+			secrets:     []string{"tok-3"},
+			keeps:       []string{"hooks.example.com"},
+		},
+		{
+			name:        "JSON response with settings encoded as string",
+			contentType: "application/json",
+			body:        `{"settingList":{"p1":"{\"DATA\":{\"webhookHeaderList\":[{\"value\":\"tok-4\"}],\"cValue\":3}}"}}`,
+			secrets:     []string{"tok-4"},
+			keeps:       []string{"cValue"},
+		},
+		{
+			name:        "plain text",
+			contentType: "text/plain",
+			body:        "Bad Gateway",
+			keeps:       []string{"Bad Gateway"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := redactBody(tt.contentType, []byte(tt.body))
+			for _, secret := range tt.secrets {
+				if strings.Contains(got, secret) {
+					t.Errorf("Expected '%s' to be redacted, got: %s", secret, got)
+				}
+			}
+			for _, keep := range tt.keeps {
+				if !strings.Contains(got, keep) {
+					t.Errorf("Expected '%s' to be kept, got: %s", keep, got)
+				}
+			}
+		})
+	}
+}
+
+func TestRedactBodyTruncates(t *testing.T) {
+	got := redactBody("text/plain", bytes.Repeat([]byte("a"), maxTraceBodyLength*2))
+	if len(got) > maxTraceBodyLength+len("...") {
+		t.Errorf("Expected body truncated to %d bytes, got %d", maxTraceBodyLength, len(got))
+	}
+}
+
+func TestRedactHeadersAndQuery(t *testing.T) {
+	headers := redactHeaders(http.Header{"X-Api-Key": {"lk-123"}, "X-User-Name": {"user"}})
+	if headers["X-Api-Key"] != redactedValue {
+		t.Errorf("Expected X-API-Key to be redacted, got '%s'", headers["X-Api-Key"])
+	}
+	if headers["X-User-Name"] != "user" {
+		t.Errorf("Expected X-User-Name to be kept, got '%s'", headers["X-User-Name"])
+	}
+
+	query := redactQuery("/api/test?projectName=p1&licenseKey=lk-123")
+	if strings.Contains(query, "lk-123") || !strings.Contains(query, "projectName=p1") {
+		t.Errorf("Unexpected redacted query: %s", query)
+	}
+}
+
+func TestDoRequestTrace(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"success":false,"message":"invalid password pw-secret"}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(server.URL, "test_user", "lk-secret")
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	var output bytes.Buffer
+	ctx := tflogtest.RootLogger(context.Background(), &output)
+
+	_, _, err = client.DoFormRequest(ctx, "POST", "/api/v1/service-integration?tzOffset=0", url.Values{"password": {"pw-secret"}})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	entries, err := tflogtest.MultilineJSONDecode(&output)
+	if err != nil {
+		t.Fatalf("Failed to decode log output: %v", err)
+	}
+
+	var sawRequest, sawResponse bool
+	for _, entry := range entries {
+		switch entry["@message"] {
+		case "Sending InsightFinder API request":
+			sawRequest = true
+			if entry["path"] != "/api/v1/service-integration" || entry["query"] != "tzOffset=0" {
+				t.Errorf("Unexpected request fields: %v", entry)
+			}
+		case "Received InsightFinder API response":
+			sawResponse = true
+			if entry["status_code"] != float64(http.StatusBadRequest) {
+				t.Errorf("Expected status_code 400, got %v", entry["status_code"])
+			}
+			if _, ok := entry["latency_ms"]; !ok {
+				t.Error("Expected latency_ms field")
+			}
+		}
+	}
+	if !sawRequest || !sawResponse {
+		t.Errorf("Expected request and response trace entries, got: %s", output.String())
+	}
+
+	raw := output.String()
+	if strings.Contains(raw, "lk-secret") {
+		t.Error("License key leaked into trace output")
+	}
+	if strings.Contains(raw, "password=pw-secret") {
+		t.Error("Form password leaked into trace output")
+	}
+}
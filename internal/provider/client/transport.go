@@ -0,0 +1,119 @@
+// Copyright (c) InsightFinder Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+)
+
+// TransportConfig holds the TLS and proxy settings for the HTTP transport.
+// File and PEM variants of the same setting are mutually exclusive.
+type TransportConfig struct {
+	// CACertFile and CACertPEM add a CA bundle to the system trust store,
+	// e.g. for on-prem deployments behind an internal CA.
+	CACertFile string
+	CACertPEM  string
+
+	// ClientCertFile/ClientKeyFile or ClientCertPEM/ClientKeyPEM enable
+	// mutual TLS.
+	ClientCertFile string
+	ClientKeyFile  string
+	ClientCertPEM  string
+	ClientKeyPEM   string
+
+	// InsecureSkipVerify disables server certificate verification. Only
+	// meant for lab environments.
+	InsecureSkipVerify bool
+
+	// ProxyURL routes all requests through an HTTP(S) proxy. When empty, the
+	// standard HTTP_PROXY, HTTPS_PROXY and NO_PROXY variables apply.
+	ProxyURL string
+}
+
+// NewTransport builds an HTTP transport from cfg, starting from the Go
+// default transport settings.
+func NewTransport(cfg TransportConfig) (*http.Transport, error) {
+	tlsConfig := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		InsecureSkipVerify: cfg.InsecureSkipVerify,
+	}
+
+	caPEM, err := readPEM("CA certificate", cfg.CACertFile, cfg.CACertPEM)
+	if err != nil {
+		return nil, err
+	}
+	if caPEM != nil {
+		pool, err := x509.SystemCertPool()
+		if err != nil || pool == nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(caPEM) {
+			return nil, fmt.Errorf("CA certificate does not contain any valid PEM certificates")
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	certPEM, err := readPEM("client certificate", cfg.ClientCertFile, cfg.ClientCertPEM)
+	if err != nil {
+		return nil, err
+	}
+	keyPEM, err := readPEM("client key", cfg.ClientKeyFile, cfg.ClientKeyPEM)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case certPEM != nil && keyPEM != nil:
+		cert, err := tls.X509KeyPair(certPEM, keyPEM)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	case certPEM != nil:
+		return nil, fmt.Errorf("client certificate is set but client key is missing")
+	case keyPEM != nil:
+		return nil, fmt.Errorf("client key is set but client certificate is missing")
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = tlsConfig
+
+	if cfg.ProxyURL != "" {
+		proxyURL, err := url.Parse(cfg.ProxyURL)
+		if err != nil {
+			return nil, fmt.Errorf("invalid proxy URL: %w", err)
+		}
+		if proxyURL.Scheme != "http" && proxyURL.Scheme != "https" {
+			return nil, fmt.Errorf("invalid proxy URL %q: scheme must be http or https", cfg.ProxyURL)
+		}
+		if proxyURL.Host == "" {
+			return nil, fmt.Errorf("invalid proxy URL %q: missing host", cfg.ProxyURL)
+		}
+		transport.Proxy = http.ProxyURL(proxyURL)
+	}
+
+	return transport, nil
+}
+
+// readPEM returns PEM data given either inline or as a file path, or nil
+// when neither is set.
+func readPEM(name, file, inline string) ([]byte, error) {
+	switch {
+	case file != "" && inline != "":
+		return nil, fmt.Errorf("%s can be given as a file or as PEM, not both", name)
+	case file != "":
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", name, err)
+		}
+		return data, nil
+	case inline != "":
+		return []byte(inline), nil
+	}
+	return nil, nil
+}
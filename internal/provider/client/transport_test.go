@@ -0,0 +1,243 @@
+// Copyright (c) InsightFinder Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// serverCAPEM returns the certificate of a TLS test server in PEM form.
+func serverCAPEM(server *httptest.Server) string {
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}))
+}
+
+// newClientCertificate generates a self-signed client certificate and key.
+func newClientCertificate(t *testing.T) (certPEM, keyPEM string, cert *x509.Certificate) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "terraform-test-client"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("Failed to create certificate: %v", err)
+	}
+	cert, err = x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatalf("Failed to parse certificate: %v", err)
+	}
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatalf("Failed to marshal key: %v", err)
+	}
+
+	certPEM = string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+	keyPEM = string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}))
+	return certPEM, keyPEM, cert
+}
+
+// newTransportTestClient returns a client using a transport built from cfg.
+func newTransportTestClient(t *testing.T, serverURL string, cfg TransportConfig) *Client {
+	t.Helper()
+
+	client, err := NewClient(serverURL, "test_user", "test_key")
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	transport, err := NewTransport(cfg)
+	if err != nil {
+		t.Fatalf("Failed to create transport: %v", err)
+	}
+	client.HTTPClient.Transport = transport
+	client.Retry.MaxAttempts = 1
+	return client
+}
+
+func TestTransportCustomCA(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	if err := os.WriteFile(caFile, []byte(serverCAPEM(server)), 0o600); err != nil {
+		t.Fatalf("Failed to write CA file: %v", err)
+	}
+
+	tests := []struct {
+		name        string
+		cfg         TransportConfig
+		expectError bool
+	}{
+		{name: "system roots only", cfg: TransportConfig{}, expectError: true},
+		{name: "CA PEM", cfg: TransportConfig{CACertPEM: serverCAPEM(server)}},
+		{name: "CA file", cfg: TransportConfig{CACertFile: caFile}},
+		{name: "insecure skip verify", cfg: TransportConfig{InsecureSkipVerify: true}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newTransportTestClient(t, server.URL, tt.cfg)
+
+			_, statusCode, err := client.DoRequest(context.Background(), "GET", "/api/test", nil)
+			if tt.expectError {
+				if err == nil {
+					t.Error("Expected certificate verification error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+			if statusCode != http.StatusOK {
+				t.Errorf("Expected status code 200, got %d", statusCode)
+			}
+		})
+	}
+}
+
+func TestTransportMutualTLS(t *testing.T) {
+	certPEM, keyPEM, cert := newClientCertificate(t)
+
+	clientCAs := x509.NewCertPool()
+	clientCAs.AddCert(cert)
+
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if len(r.TLS.PeerCertificates) == 0 || r.TLS.PeerCertificates[0].Subject.CommonName != "terraform-test-client" {
+			t.Error("Expected client certificate on request")
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	server.TLS = &tls.Config{
+		ClientAuth: tls.RequireAndVerifyClientCert,
+		ClientCAs:  clientCAs,
+	}
+	server.StartTLS()
+	defer server.Close()
+
+	dir := t.TempDir()
+	certFile := filepath.Join(dir, "client.pem")
+	keyFile := filepath.Join(dir, "client-key.pem")
+	if err := os.WriteFile(certFile, []byte(certPEM), 0o600); err != nil {
+		t.Fatalf("Failed to write certificate: %v", err)
+	}
+	if err := os.WriteFile(keyFile, []byte(keyPEM), 0o600); err != nil {
+		t.Fatalf("Failed to write key: %v", err)
+	}
+
+	tests := []struct {
+		name        string
+		cfg         TransportConfig
+		expectError bool
+	}{
+		{
+			name:        "without client certificate",
+			cfg:         TransportConfig{CACertPEM: serverCAPEM(server)},
+			expectError: true,
+		},
+		{
+			name: "client certificate PEM",
+			cfg:  TransportConfig{CACertPEM: serverCAPEM(server), ClientCertPEM: certPEM, ClientKeyPEM: keyPEM},
+		},
+		{
+			name: "client certificate files",
+			cfg:  TransportConfig{CACertPEM: serverCAPEM(server), ClientCertFile: certFile, ClientKeyFile: keyFile},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newTransportTestClient(t, server.URL, tt.cfg)
+
+			_, _, err := client.DoFormRequest(context.Background(), "POST", "/api/form", map[string][]string{})
+			if tt.expectError && err == nil {
+				t.Error("Expected handshake error without client certificate, got nil")
+			}
+			if !tt.expectError && err != nil {
+				t.Errorf("Expected no error, got: %v", err)
+			}
+		})
+	}
+}
+
+func TestTransportProxy(t *testing.T) {
+	var proxied int32
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// A forward proxy receives the absolute target URL
+		if !strings.HasPrefix(r.RequestURI, "http://insightfinder.internal/") {
+			t.Errorf("Expected absolute request URI, got '%s'", r.RequestURI)
+		}
+		atomic.AddInt32(&proxied, 1)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer proxy.Close()
+
+	client := newTransportTestClient(t, "http://insightfinder.internal", TransportConfig{ProxyURL: proxy.URL})
+
+	_, statusCode, err := client.DoRequest(context.Background(), "GET", "/api/test", nil)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if statusCode != http.StatusOK {
+		t.Errorf("Expected status code 200, got %d", statusCode)
+	}
+	if atomic.LoadInt32(&proxied) != 1 {
+		t.Error("Expected request to go through the proxy")
+	}
+}
+
+func TestNewTransportInvalidConfig(t *testing.T) {
+	certPEM, keyPEM, _ := newClientCertificate(t)
+
+	tests := []struct {
+		name string
+		cfg  TransportConfig
+	}{
+		{name: "CA file and PEM", cfg: TransportConfig{CACertFile: "ca.pem", CACertPEM: certPEM}},
+		{name: "missing CA file", cfg: TransportConfig{CACertFile: filepath.Join(t.TempDir(), "missing.pem")}},
+		{name: "invalid CA PEM", cfg: TransportConfig{CACertPEM: "not a certificate"}},
+		{name: "certificate without key", cfg: TransportConfig{ClientCertPEM: certPEM}},
+		{name: "key without certificate", cfg: TransportConfig{ClientKeyPEM: keyPEM}},
+		{name: "mismatched key pair", cfg: TransportConfig{ClientCertPEM: certPEM, ClientKeyPEM: "garbage"}},
+		{name: "proxy without scheme", cfg: TransportConfig{ProxyURL: "proxy.example.com:3128"}},
+		{name: "proxy with unsupported scheme", cfg: TransportConfig{ProxyURL: "ftp://proxy.example.com"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewTransport(tt.cfg); err == nil {
+				t.Error("Expected error, got nil")
+			}
+		})
+	}
+}
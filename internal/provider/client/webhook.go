@@ -0,0 +1,167 @@
+// Copyright (c) InsightFinder Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// WebhookConfig is a project's webhook notification configuration. It is
+// stored in the project's watch-tower settings.
+type WebhookConfig struct {
+	ProjectName       string
+	URL               string
+	Headers           map[string]string
+	EventTypes        []string
+	BlacklistKeywords []string
+	CriticalKeywords  []string
+	// AlertDampening is nil when the dampening is not managed, which
+	// leaves the project's current value in place.
+	AlertDampening *int
+}
+
+// Watch-tower setting keys holding the webhook configuration.
+const (
+	webhookURLKey                = "webhookUrl"
+	webhookHeaderListKey         = "webhookHeaderList"
+	webhookTypeSetStrKey         = "webhookTypeSetStr"
+	webhookBlackListSetStrKey    = "webhookBlackListSetStr"
+	webhookCriticalKeywordSetKey = "webhookCriticalKeywordSetStr"
+	webhookAlertDampeningKey     = "webhookAlertDampening"
+)
+
+// Settings returns the watch-tower settings for the webhook configuration.
+// Every webhook setting is included, so applying them replaces the whole
+// configuration; a zero WebhookConfig clears it. The alert dampening is
+// only included when it is set.
+func (w *WebhookConfig) Settings() map[string]interface{} {
+	names := make([]string, 0, len(w.Headers))
+	for name := range w.Headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	headers := make([]interface{}, 0, len(names))
+	for _, name := range names {
+		headers = append(headers, map[string]interface{}{"key": name, "value": w.Headers[name]})
+	}
+
+	settings := map[string]interface{}{
+		webhookURLKey:                w.URL,
+		webhookHeaderListKey:         headers,
+		webhookTypeSetStrKey:         encodeSetStr(w.EventTypes),
+		webhookBlackListSetStrKey:    encodeSetStr(w.BlacklistKeywords),
+		webhookCriticalKeywordSetKey: encodeSetStr(w.CriticalKeywords),
+	}
+	if w.AlertDampening != nil {
+		settings[webhookAlertDampeningKey] = *w.AlertDampening
+	}
+	return settings
+}
+
+// WebhookConfigFromSettings reads the webhook configuration of a project
+// from its watch-tower settings.
+func WebhookConfigFromSettings(projectName string, settings map[string]interface{}) *WebhookConfig {
+	config := &WebhookConfig{
+		ProjectName:       projectName,
+		Headers:           map[string]string{},
+		EventTypes:        decodeSetStr(settings[webhookTypeSetStrKey]),
+		BlacklistKeywords: decodeSetStr(settings[webhookBlackListSetStrKey]),
+		CriticalKeywords:  decodeSetStr(settings[webhookCriticalKeywordSetKey]),
+	}
+
+	if webhookURL, ok := settings[webhookURLKey].(string); ok {
+		config.URL = webhookURL
+	}
+	dampening, _ := settings[webhookAlertDampeningKey].(float64)
+	alertDampening := int(dampening)
+	config.AlertDampening = &alertDampening
+
+	headers, _ := settings[webhookHeaderListKey].([]interface{})
+	for _, header := range headers {
+		entry, ok := header.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		name, _ := entry["key"].(string)
+		value, _ := entry["value"].(string)
+		if name != "" {
+			config.Headers[name] = value
+		}
+	}
+
+	return config
+}
+
+// encodeSetStr encodes a set of strings the way the *SetStr settings store
+// them: a JSON array in a string, sorted so that equal sets encode equally.
+func encodeSetStr(values []string) string {
+	sorted := append([]string{}, values...)
+	sort.Strings(sorted)
+	if len(sorted) == 0 {
+		return "[]"
+	}
+	encoded, _ := json.Marshal(sorted)
+	return string(encoded)
+}
+
+// decodeSetStr decodes a *SetStr setting. Besides JSON arrays it accepts
+// the comma separated lists older projects hold.
+func decodeSetStr(value interface{}) []string {
+	str, _ := value.(string)
+	str = strings.TrimSpace(str)
+	if str == "" {
+		return nil
+	}
+
+	var values []string
+	if err := json.Unmarshal([]byte(str), &values); err != nil {
+		values = nil
+		for _, item := range strings.Split(str, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				values = append(values, item)
+			}
+		}
+	}
+	sort.Strings(values)
+	return values
+}
+
+// GetWebhookConfig retrieves the webhook configuration of a project. It
+// returns an error matching ErrNotFound when the project does not exist or
+// has no webhook URL.
+func (c *Client) GetWebhookConfig(ctx context.Context, projectName, username string) (*WebhookConfig, error) {
+	project, err := c.GetProject(ctx, projectName, username)
+	if err != nil {
+		return nil, err
+	}
+
+	config := WebhookConfigFromSettings(projectName, project.Settings)
+	if config.URL == "" {
+		return nil, fmt.Errorf("webhook for project '%s': %w", projectName, ErrNotFound)
+	}
+	return config, nil
+}
+
+// UpdateWebhookConfig replaces the webhook configuration of a project
+// through the watch-tower settings endpoint.
+func (c *Client) UpdateWebhookConfig(ctx context.Context, config *WebhookConfig) error {
+	err := c.UpdateProject(ctx, &ProjectConfig{
+		ProjectName: config.ProjectName,
+		Settings:    config.Settings(),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update webhook: %w", err)
+	}
+	return nil
+}
+
+// DeleteWebhookConfig clears the webhook configuration of a project.
+func (c *Client) DeleteWebhookConfig(ctx context.Context, projectName string) error {
+	return c.UpdateWebhookConfig(ctx, &WebhookConfig{ProjectName: projectName})
+}
@@ -5,6 +5,8 @@ package provider
 
 import (
 	"context"
+	"errors"
+	"fmt"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
@@ -23,7 +25,7 @@ func NewProjectDataSource() datasource.DataSource {
 }
 
 type projectDataSource struct {
-	client *client.Client
+	client client.InsightFinderAPI
 }
 
 type projectDataSourceModel struct {
@@ -67,7 +69,7 @@ func (d *projectDataSource) Configure(_ context.Context, req datasource.Configur
 		return
 	}
 
-	client, ok := req.ProviderData.(*client.Client)
+	client, ok := req.ProviderData.(client.InsightFinderAPI)
 	if !ok {
 		resp.Diagnostics.AddError("Unexpected Data Source Configure Type", "")
 		return
@@ -80,14 +82,16 @@ func (d *projectDataSource) Read(ctx context.Context, req datasource.ReadRequest
 	var data projectDataSourceModel
 	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
 
-	project, err := d.client.GetProject(data.ProjectName.ValueString(), d.client.Username)
-	if err != nil {
-		resp.Diagnostics.AddError("Error reading project", err.Error())
+	project, err := d.client.GetProject(ctx, data.ProjectName.ValueString(), d.client.CustomerName())
+	if errors.Is(err, client.ErrNotFound) {
+		resp.Diagnostics.AddError(
+			"Project not found",
+			fmt.Sprintf("Project '%s' does not exist or is not visible to user '%s'.", data.ProjectName.ValueString(), d.client.CustomerName()),
+		)
 		return
 	}
-
-	if project == nil {
-		resp.Diagnostics.AddError("Project not found", "")
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading project", apiErrorDetail(err))
 		return
 	}
 
@@ -5,6 +5,7 @@ package provider
 
 import (
 	"fmt"
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
@@ -24,9 +25,8 @@ func TestAccProjectDataSource(t *testing.T) {
 					// Check that the data source can read it
 					resource.TestCheckResourceAttr("data.insightfinder_project.test", "project_name", "datasource-test-project"),
 					resource.TestCheckResourceAttr("data.insightfinder_project.test", "project_display_name", "DataSource Test Project"),
-					resource.TestCheckResourceAttr("data.insightfinder_project.test", "system_name", "datasource-system"),
 					resource.TestCheckResourceAttrSet("data.insightfinder_project.test", "id"),
-					resource.TestCheckResourceAttrSet("data.insightfinder_project.test", "data_type"),
+					resource.TestCheckResourceAttrSet("data.insightfinder_project.test", "c_value"),
 				),
 			},
 		},
@@ -58,8 +58,8 @@ func TestAccProjectDataSource_WithSettings(t *testing.T) {
 				Config: testAccProjectDataSourceConfigWithSettings("settings-project", "settings-system"),
 				Check: resource.ComposeAggregateTestCheckFunc(
 					resource.TestCheckResourceAttr("data.insightfinder_project.test", "project_name", "settings-project"),
-					resource.TestCheckResourceAttrSet("data.insightfinder_project.test", "sampling_interval"),
-					resource.TestCheckResourceAttrSet("data.insightfinder_project.test", "project_time_zone"),
+					resource.TestCheckResourceAttr("data.insightfinder_project.test", "c_value", "3"),
+					resource.TestCheckResourceAttrSet("data.insightfinder_project.test", "p_value"),
 				),
 			},
 		},
@@ -73,7 +73,7 @@ func TestAccProjectDataSource_NonExistent(t *testing.T) {
 		Steps: []resource.TestStep{
 			{
 				Config:      testAccProjectDataSourceConfigNonExistent(),
-				ExpectError: nil, // Will return error from API
+				ExpectError: regexp.MustCompile("Project not found"),
 			},
 		},
 	})
@@ -87,13 +87,11 @@ resource "insightfinder_project" "test" {
   system_name          = %[3]q
 
   project_creation_config = {
-    data_type               = "Log"
-    instance_type           = "PrivateCloud"
-    project_cloud_type      = "PrivateCloud"
-    insight_agent_type      = "LogStreaming"
-    project_creation_type   = "Kafka"
-    sampling_interval       = 10
-    sampling_interval_in_seconds = 600
+    data_type             = "Log"
+    instance_type         = "PrivateCloud"
+    project_cloud_type    = "PrivateCloud"
+    insight_agent_type    = "LogStreaming"
+    project_creation_type = "Kafka"
   }
 }
 
@@ -113,13 +111,11 @@ resource "insightfinder_project" "test" {
   system_name          = %[3]q
 
   project_creation_config = {
-    data_type               = "Log"
-    instance_type           = "PrivateCloud"
-    project_cloud_type      = "PrivateCloud"
-    insight_agent_type      = "LogStreaming"
-    project_creation_type   = "Kafka"
-    sampling_interval       = 10
-    sampling_interval_in_seconds = 600
+    data_type             = "Log"
+    instance_type         = "PrivateCloud"
+    project_cloud_type    = "PrivateCloud"
+    insight_agent_type    = "LogStreaming"
+    project_creation_type = "Kafka"
   }
 }
 
@@ -138,16 +134,14 @@ resource "insightfinder_project" "test" {
   system_name  = %[2]q
 
   project_creation_config = {
-    data_type               = "Log"
-    instance_type           = "PrivateCloud"
-    project_cloud_type      = "PrivateCloud"
-    insight_agent_type      = "LogStreaming"
-    project_creation_type   = "Kafka"
-    sampling_interval       = 10
-    sampling_interval_in_seconds = 600
+    data_type             = "Log"
+    instance_type         = "PrivateCloud"
+    project_cloud_type    = "PrivateCloud"
+    insight_agent_type    = "LogStreaming"
+    project_creation_type = "Kafka"
   }
 
-  sampling_interval = 10
+  c_value           = 3
   project_time_zone = "America/New_York"
 }
 
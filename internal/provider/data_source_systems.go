@@ -29,7 +29,7 @@ func NewSystemsDataSource() datasource.DataSource {
 
 // systemsDataSource is the data source implementation.
 type systemsDataSource struct {
-	client *client.Client
+	client client.InsightFinderAPI
 }
 
 // systemsDataSourceModel maps the data source schema data.
@@ -84,11 +84,11 @@ func (d *systemsDataSource) Configure(_ context.Context, req datasource.Configur
 		return
 	}
 
-	client, ok := req.ProviderData.(*client.Client)
+	client, ok := req.ProviderData.(client.InsightFinderAPI)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected client.InsightFinderAPI, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
@@ -103,11 +103,11 @@ func (d *systemsDataSource) Read(ctx context.Context, req datasource.ReadRequest
 	tflog.Debug(ctx, "Reading systems list")
 
 	// Get system framework
-	systemFramework, err := d.client.GetSystemFramework(d.client.Username, false)
+	systemFramework, err := d.client.GetSystemFramework(ctx, d.client.CustomerName(), false)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Reading Systems",
-			"Could not read systems: "+err.Error(),
+			"Could not read systems: "+apiErrorDetail(err),
 		)
 		return
 	}
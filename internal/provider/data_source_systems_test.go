@@ -110,13 +110,11 @@ resource "insightfinder_project" "test" {
   system_name  = "systems-test-system"
 
   project_creation_config = {
-    data_type               = "Log"
-    instance_type           = "PrivateCloud"
-    project_cloud_type      = "PrivateCloud"
-    insight_agent_type      = "LogStreaming"
-    project_creation_type   = "Kafka"
-    sampling_interval       = 10
-    sampling_interval_in_seconds = 600
+    data_type             = "Log"
+    instance_type         = "PrivateCloud"
+    project_cloud_type    = "PrivateCloud"
+    insight_agent_type    = "LogStreaming"
+    project_creation_type = "Kafka"
   }
 }
 
@@ -0,0 +1,30 @@
+// Copyright (c) InsightFinder Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"errors"
+
+	"github.com/insightfinder/terraform-provider-insightfinder/internal/provider/client"
+)
+
+// apiErrorDetail formats a client error for a diagnostic, adding a hint on
+// how to resolve the failure when its category is known.
+func apiErrorDetail(err error) string {
+	detail := err.Error()
+
+	switch {
+	case errors.Is(err, client.ErrUnauthorized):
+		detail += "\n\nCheck the provider username and license_key (or the IF_USERNAME and IF_LICENSE_KEY " +
+			"environment variables), and that the user has access to this object."
+	case errors.Is(err, client.ErrRateLimited):
+		detail += "\n\nThe InsightFinder API is throttling requests. Lower max_requests_per_second or " +
+			"max_concurrent_requests in the provider configuration, or run Terraform with a lower -parallelism."
+	case errors.Is(err, client.ErrServer):
+		detail += "\n\nThe InsightFinder API reported a server-side failure. Retry the operation later; " +
+			"if it persists, contact InsightFinder support."
+	}
+
+	return detail
+}
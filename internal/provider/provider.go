@@ -5,9 +5,13 @@ package provider
 
 import (
 	"context"
+	"fmt"
 	"os"
+	"strconv"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
@@ -45,6 +49,30 @@ type insightfinderProviderModel struct {
 	BaseURL    types.String `tfsdk:"base_url"`
 	Username   types.String `tfsdk:"username"`
 	LicenseKey types.String `tfsdk:"license_key"`
+
+	MaxRequestsPerSecond  types.Float64 `tfsdk:"max_requests_per_second"`
+	MaxConcurrentRequests types.Int64   `tfsdk:"max_concurrent_requests"`
+	DisableSystemCache    types.Bool    `tfsdk:"disable_system_cache"`
+
+	LabelConvergenceTimeout types.String `tfsdk:"label_convergence_timeout"`
+
+	CACertFile         types.String `tfsdk:"ca_cert_file"`
+	CACertPEM          types.String `tfsdk:"ca_cert_pem"`
+	ClientCertFile     types.String `tfsdk:"client_cert_file"`
+	ClientKeyFile      types.String `tfsdk:"client_key_file"`
+	ClientCertPEM      types.String `tfsdk:"client_cert_pem"`
+	ClientKeyPEM       types.String `tfsdk:"client_key_pem"`
+	InsecureSkipVerify types.Bool   `tfsdk:"insecure_skip_verify"`
+	ProxyURL           types.String `tfsdk:"proxy_url"`
+
+	Retry *retryModel `tfsdk:"retry"`
+}
+
+// retryModel maps the provider retry block.
+type retryModel struct {
+	MaxAttempts types.Int64  `tfsdk:"max_attempts"`
+	MinBackoff  types.String `tfsdk:"min_backoff"`
+	MaxBackoff  types.String `tfsdk:"max_backoff"`
 }
 
 // Metadata returns the provider type name.
@@ -72,6 +100,86 @@ func (p *insightfinderProvider) Schema(_ context.Context, _ provider.SchemaReque
 				Optional:    true,
 				Sensitive:   true,
 			},
+			"max_requests_per_second": schema.Float64Attribute{
+				Description: "Maximum average number of API calls per second, shared by all resources and data sources of this provider. " +
+					"Retries count against the same budget. Fractional values such as 0.5 are allowed. Unlimited when unset.",
+				Optional: true,
+			},
+			"max_concurrent_requests": schema.Int64Attribute{
+				Description: "Maximum number of API calls in flight at once, shared by all resources and data sources of this provider. Unlimited when unset.",
+				Optional:    true,
+			},
+			"disable_system_cache": schema.BoolAttribute{
+				Description: fmt.Sprintf("Disable caching of the system list used to resolve system names to IDs. "+
+					"By default it is downloaded once and reused for %s, or until the provider changes a system.", client.DefaultSystemCacheTTL),
+				Optional: true,
+			},
+			"label_convergence_timeout": schema.StringAttribute{
+				Description: fmt.Sprintf("How long log label writes wait for the label lists to read back as written, as a Go duration string. "+
+					"Set to \"0s\" to skip the check, e.g. when the API rewrites stored entries. Default: %q.", client.DefaultLabelConvergenceTimeout.String()),
+				Optional: true,
+			},
+			"ca_cert_file": schema.StringAttribute{
+				Description: "Path to a PEM CA bundle trusted in addition to the system roots, e.g. for an on-prem deployment behind an internal CA. " +
+					"Conflicts with ca_cert_pem. May also be provided via IF_CA_CERT_FILE environment variable.",
+				Optional: true,
+			},
+			"ca_cert_pem": schema.StringAttribute{
+				Description: "PEM-encoded CA bundle trusted in addition to the system roots. Conflicts with ca_cert_file. " +
+					"May also be provided via IF_CA_CERT_PEM environment variable.",
+				Optional: true,
+			},
+			"client_cert_file": schema.StringAttribute{
+				Description: "Path to a PEM client certificate for mutual TLS. Requires client_key_file or client_key_pem. " +
+					"May also be provided via IF_CLIENT_CERT_FILE environment variable.",
+				Optional: true,
+			},
+			"client_key_file": schema.StringAttribute{
+				Description: "Path to the PEM private key of the client certificate. " +
+					"May also be provided via IF_CLIENT_KEY_FILE environment variable.",
+				Optional: true,
+			},
+			"client_cert_pem": schema.StringAttribute{
+				Description: "PEM-encoded client certificate for mutual TLS. Conflicts with client_cert_file. " +
+					"May also be provided via IF_CLIENT_CERT_PEM environment variable.",
+				Optional: true,
+			},
+			"client_key_pem": schema.StringAttribute{
+				Description: "PEM-encoded private key of the client certificate. Conflicts with client_key_file. " +
+					"May also be provided via IF_CLIENT_KEY_PEM environment variable.",
+				Optional:  true,
+				Sensitive: true,
+			},
+			"insecure_skip_verify": schema.BoolAttribute{
+				Description: "Skip verification of the server TLS certificate. Only use this in lab environments. " +
+					"May also be provided via IF_INSECURE_SKIP_VERIFY environment variable.",
+				Optional: true,
+			},
+			"proxy_url": schema.StringAttribute{
+				Description: "HTTP(S) proxy for all API calls, e.g. http://proxy.example.com:3128. When unset, the standard " +
+					"HTTPS_PROXY, HTTP_PROXY and NO_PROXY environment variables apply. May also be provided via IF_PROXY_URL environment variable.",
+				Optional: true,
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"retry": schema.SingleNestedBlock{
+				Description: "Retry policy for InsightFinder API calls. Throttled (429) responses are always retried; " +
+					"gateway errors (502, 503, 504) and connection failures are retried only for operations that are safe to repeat.",
+				Attributes: map[string]schema.Attribute{
+					"max_attempts": schema.Int64Attribute{
+						Description: fmt.Sprintf("Total number of attempts per API call, including the first one. Set to 1 to disable retries. Default: %d.", client.DefaultRetryMaxAttempts),
+						Optional:    true,
+					},
+					"min_backoff": schema.StringAttribute{
+						Description: fmt.Sprintf("Base delay before the first retry, as a Go duration string. The delay doubles on each retry, with jitter. Default: %q.", client.DefaultRetryMinBackoff.String()),
+						Optional:    true,
+					},
+					"max_backoff": schema.StringAttribute{
+						Description: fmt.Sprintf("Upper bound for the delay between retries, as a Go duration string. A Retry-After header from the server takes precedence, up to %s. Default: %q.", client.MaxRetryAfter, client.DefaultRetryMaxBackoff.String()),
+						Optional:    true,
+					},
+				},
+			},
 		},
 	}
 }
@@ -174,6 +282,26 @@ func (p *insightfinderProvider) Configure(ctx context.Context, req provider.Conf
 		)
 	}
 
+	if !config.MaxRequestsPerSecond.IsNull() && !config.MaxRequestsPerSecond.IsUnknown() && config.MaxRequestsPerSecond.ValueFloat64() <= 0 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("max_requests_per_second"),
+			"Invalid InsightFinder API Rate Limit",
+			fmt.Sprintf("max_requests_per_second must be greater than 0, got %g.", config.MaxRequestsPerSecond.ValueFloat64()),
+		)
+	}
+
+	if !config.MaxConcurrentRequests.IsNull() && !config.MaxConcurrentRequests.IsUnknown() && config.MaxConcurrentRequests.ValueInt64() < 1 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("max_concurrent_requests"),
+			"Invalid InsightFinder API Concurrency Limit",
+			fmt.Sprintf("max_concurrent_requests must be at least 1, got %d.", config.MaxConcurrentRequests.ValueInt64()),
+		)
+	}
+
+	retryPolicy := retryPolicyFromConfig(config.Retry, &resp.Diagnostics)
+	labelConvergenceTimeout := labelConvergenceTimeoutFromConfig(config.LabelConvergenceTimeout, &resp.Diagnostics)
+	transportConfig := transportConfigFromConfig(config, &resp.Diagnostics)
+
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -195,15 +323,140 @@ func (p *insightfinderProvider) Configure(ctx context.Context, req provider.Conf
 		)
 		return
 	}
+	transport, err := client.NewTransport(transportConfig)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Invalid InsightFinder TLS or Proxy Configuration",
+			"The provider cannot create the HTTP transport for the InsightFinder API client: "+err.Error(),
+		)
+		return
+	}
+	c.HTTPClient.Transport = transport
+
+	c.Retry = retryPolicy
+	c.SetRateLimit(config.MaxRequestsPerSecond.ValueFloat64())
+	c.SetMaxConcurrentRequests(int(config.MaxConcurrentRequests.ValueInt64()))
+	if config.DisableSystemCache.ValueBool() {
+		c.SystemCacheTTL = 0
+	}
+	c.LabelConvergenceTimeout = labelConvergenceTimeout
 
 	// Make the InsightFinder client available during DataSource and Resource
-	// type Configure methods.
-	resp.DataSourceData = c
-	resp.ResourceData = c
+	// type Configure methods. They only see the InsightFinderAPI interface so
+	// tests can substitute a fake.
+	var api client.InsightFinderAPI = c
+	resp.DataSourceData = api
+	resp.ResourceData = api
 
 	tflog.Info(ctx, "Configured InsightFinder client", map[string]any{"success": true})
 }
 
+// stringConfigOrEnv returns the configured value, or the environment
+// variable when the attribute is not set.
+func stringConfigOrEnv(value types.String, env string) string {
+	if !value.IsNull() && !value.IsUnknown() {
+		return value.ValueString()
+	}
+	return os.Getenv(env)
+}
+
+// transportConfigFromConfig collects the TLS and proxy settings from the
+// provider configuration and their IF_* environment variable fallbacks.
+func transportConfigFromConfig(config insightfinderProviderModel, diags *diag.Diagnostics) client.TransportConfig {
+	cfg := client.TransportConfig{
+		CACertFile:     stringConfigOrEnv(config.CACertFile, "IF_CA_CERT_FILE"),
+		CACertPEM:      stringConfigOrEnv(config.CACertPEM, "IF_CA_CERT_PEM"),
+		ClientCertFile: stringConfigOrEnv(config.ClientCertFile, "IF_CLIENT_CERT_FILE"),
+		ClientKeyFile:  stringConfigOrEnv(config.ClientKeyFile, "IF_CLIENT_KEY_FILE"),
+		ClientCertPEM:  stringConfigOrEnv(config.ClientCertPEM, "IF_CLIENT_CERT_PEM"),
+		ClientKeyPEM:   stringConfigOrEnv(config.ClientKeyPEM, "IF_CLIENT_KEY_PEM"),
+		ProxyURL:       stringConfigOrEnv(config.ProxyURL, "IF_PROXY_URL"),
+	}
+
+	if !config.InsecureSkipVerify.IsNull() && !config.InsecureSkipVerify.IsUnknown() {
+		cfg.InsecureSkipVerify = config.InsecureSkipVerify.ValueBool()
+	} else if v := os.Getenv("IF_INSECURE_SKIP_VERIFY"); v != "" {
+		skip, err := strconv.ParseBool(v)
+		if err != nil {
+			diags.AddAttributeError(
+				path.Root("insecure_skip_verify"),
+				"Invalid IF_INSECURE_SKIP_VERIFY Value",
+				fmt.Sprintf("IF_INSECURE_SKIP_VERIFY must be a boolean such as \"true\" or \"false\", got %q.", v),
+			)
+		}
+		cfg.InsecureSkipVerify = skip
+	}
+
+	return cfg
+}
+
+// retryPolicyFromConfig builds the client retry policy from the provider
+// retry block, falling back to the client defaults for unset attributes.
+func retryPolicyFromConfig(config *retryModel, diags *diag.Diagnostics) client.RetryPolicy {
+	policy := client.DefaultRetryPolicy()
+	if config == nil {
+		return policy
+	}
+
+	if !config.MaxAttempts.IsNull() && !config.MaxAttempts.IsUnknown() {
+		if config.MaxAttempts.ValueInt64() < 1 {
+			diags.AddAttributeError(
+				path.Root("retry").AtName("max_attempts"),
+				"Invalid Retry Max Attempts",
+				fmt.Sprintf("max_attempts must be at least 1, got %d.", config.MaxAttempts.ValueInt64()),
+			)
+		} else {
+			policy.MaxAttempts = int(config.MaxAttempts.ValueInt64())
+		}
+	}
+
+	parseBackoff := func(value types.String, name string, target *time.Duration) {
+		if value.IsNull() || value.IsUnknown() {
+			return
+		}
+		d, err := time.ParseDuration(value.ValueString())
+		if err != nil || d < 0 {
+			diags.AddAttributeError(
+				path.Root("retry").AtName(name),
+				"Invalid Retry Backoff",
+				fmt.Sprintf("%s must be a non-negative duration such as \"500ms\" or \"2s\", got %q.", name, value.ValueString()),
+			)
+			return
+		}
+		*target = d
+	}
+	parseBackoff(config.MinBackoff, "min_backoff", &policy.MinBackoff)
+	parseBackoff(config.MaxBackoff, "max_backoff", &policy.MaxBackoff)
+
+	if policy.MaxBackoff < policy.MinBackoff {
+		diags.AddAttributeError(
+			path.Root("retry").AtName("max_backoff"),
+			"Invalid Retry Backoff",
+			fmt.Sprintf("max_backoff (%s) must not be lower than min_backoff (%s).", policy.MaxBackoff, policy.MinBackoff),
+		)
+	}
+
+	return policy
+}
+
+// labelConvergenceTimeoutFromConfig returns the configured label convergence
+// timeout, or the client default when unset. Zero disables the check.
+func labelConvergenceTimeoutFromConfig(value types.String, diags *diag.Diagnostics) time.Duration {
+	if value.IsNull() || value.IsUnknown() {
+		return client.DefaultLabelConvergenceTimeout
+	}
+	d, err := time.ParseDuration(value.ValueString())
+	if err != nil || d < 0 {
+		diags.AddAttributeError(
+			path.Root("label_convergence_timeout"),
+			"Invalid Label Convergence Timeout",
+			fmt.Sprintf("label_convergence_timeout must be a non-negative duration such as \"30s\", or \"0s\" to skip the check, got %q.", value.ValueString()),
+		)
+		return client.DefaultLabelConvergenceTimeout
+	}
+	return d
+}
+
 // DataSources defines the data sources implemented in the provider.
 func (p *insightfinderProvider) DataSources(_ context.Context) []func() datasource.DataSource {
 	return []func() datasource.DataSource{
@@ -219,5 +472,6 @@ func (p *insightfinderProvider) Resources(_ context.Context) []func() resource.R
 		NewLogLabelsResource,
 		NewJWTConfigResource,
 		NewServiceNowResource,
+		NewProjectWebhookResource,
 	}
 }
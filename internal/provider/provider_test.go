@@ -5,14 +5,27 @@ package provider
 
 import (
 	"context"
+	"fmt"
+	"net/http/httptest"
 	"os"
 	"testing"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	"github.com/insightfinder/terraform-provider-insightfinder/internal/emulator"
+	"github.com/insightfinder/terraform-provider-insightfinder/internal/provider/client"
 )
 
 // testAccProtoV6ProviderFactories are used to instantiate a provider during
@@ -33,6 +46,67 @@ func testAccPreCheck(t *testing.T) {
 	}
 }
 
+// testAccEmulatorOptions seeds the API emulator with the systems and projects
+// the acceptance tests expect to already exist in the tenant.
+var testAccEmulatorOptions = emulator.Options{
+	Systems: []string{
+		// JWT config tests
+		"test-system-jwt", "jwt-system-1", "jwt-system-2",
+		"deletion-test-system", "special-char-system", "long-secret-system",
+		// ServiceNow tests
+		"system1", "system2", "system3", "oauth-system1", "proxy-system", "dampening-system",
+	},
+	Projects: []emulator.Project{
+		// Log labels tests
+		{Name: "log-labels-project", SystemName: "log-labels-system"},
+		{Name: "pattern-project", SystemName: "pattern-system"},
+		{Name: "training-project", SystemName: "training-system"},
+		{Name: "combined-project", SystemName: "combined-system"},
+		{Name: "multi-rule-project", SystemName: "multi-rule-system"},
+	},
+}
+
+// testAccAPIClient returns a client for the tenant the acceptance tests run
+// against, so tests can change objects outside Terraform.
+func testAccAPIClient(t *testing.T) *client.Client {
+	t.Helper()
+
+	c, err := client.NewClient(os.Getenv("IF_BASE_URL"), os.Getenv("IF_USERNAME"), os.Getenv("IF_LICENSE_KEY"))
+	if err != nil {
+		t.Fatalf("Failed to create API client: %v", err)
+	}
+	return c
+}
+
+// TestMain points the acceptance tests at an in-process API emulator when
+// IF_EMULATOR is set (see the testacc-emulator target in the Makefile), so
+// they can run without a live tenant.
+func TestMain(m *testing.M) {
+	if os.Getenv("IF_EMULATOR") == "" {
+		os.Exit(m.Run())
+	}
+
+	server := httptest.NewServer(emulator.New(testAccEmulatorOptions))
+
+	env := map[string]string{"IF_BASE_URL": server.URL}
+	if os.Getenv("IF_USERNAME") == "" {
+		env["IF_USERNAME"] = "emulator"
+	}
+	if os.Getenv("IF_LICENSE_KEY") == "" {
+		env["IF_LICENSE_KEY"] = "emulator"
+	}
+	for key, value := range env {
+		if err := os.Setenv(key, value); err != nil {
+			fmt.Fprintf(os.Stderr, "failed to set %s: %v\n", key, err)
+			os.Exit(1)
+		}
+	}
+
+	code := m.Run()
+	server.Close()
+	os.Exit(code)
+}
+
 func TestProvider(t *testing.T) {
 	// Test that the provider can be instantiated
 	p := New("test")()
@@ -154,10 +228,11 @@ func TestProviderResources(t *testing.T) {
 	resources := p.Resources(context.Background())
 
 	expectedResources := map[string]bool{
-		"insightfinder_project":    false,
-		"insightfinder_servicenow": false,
-		"insightfinder_jwt_config": false,
-		"insightfinder_log_labels": false,
+		"insightfinder_project":         false,
+		"insightfinder_servicenow":      false,
+		"insightfinder_jwt_config":      false,
+		"insightfinder_log_labels":      false,
+		"insightfinder_project_webhook": false,
 	}
 
 	if len(resources) != len(expectedResources) {
@@ -195,3 +270,170 @@ func TestProviderDataSources(t *testing.T) {
 		t.Logf("Found data source: %T", ds)
 	}
 }
+
+func TestRetryPolicyFromConfig(t *testing.T) {
+	tests := []struct {
+		name        string
+		config      *retryModel
+		expected    client.RetryPolicy
+		expectError bool
+	}{
+		{
+			name:     "no retry block",
+			config:   nil,
+			expected: client.DefaultRetryPolicy(),
+		},
+		{
+			name: "partial override",
+			config: &retryModel{
+				MaxAttempts: types.Int64Value(6),
+				MinBackoff:  types.StringNull(),
+				MaxBackoff:  types.StringValue("1m"),
+			},
+			expected: client.RetryPolicy{
+				MaxAttempts: 6,
+				MinBackoff:  client.DefaultRetryMinBackoff,
+				MaxBackoff:  time.Minute,
+			},
+		},
+		{
+			name: "zero attempts",
+			config: &retryModel{
+				MaxAttempts: types.Int64Value(0),
+				MinBackoff:  types.StringNull(),
+				MaxBackoff:  types.StringNull(),
+			},
+			expectError: true,
+		},
+		{
+			name: "invalid duration",
+			config: &retryModel{
+				MaxAttempts: types.Int64Null(),
+				MinBackoff:  types.StringValue("soon"),
+				MaxBackoff:  types.StringNull(),
+			},
+			expectError: true,
+		},
+		{
+			name: "max below min",
+			config: &retryModel{
+				MaxAttempts: types.Int64Null(),
+				MinBackoff:  types.StringValue("10s"),
+				MaxBackoff:  types.StringValue("1s"),
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var diags diag.Diagnostics
+			policy := retryPolicyFromConfig(tt.config, &diags)
+
+			if tt.expectError {
+				if !diags.HasError() {
+					t.Error("Expected error diagnostics, got none")
+				}
+				return
+			}
+			if diags.HasError() {
+				t.Fatalf("Expected no error, got: %v", diags.Errors())
+			}
+			if policy != tt.expected {
+				t.Errorf("Expected policy %+v, got %+v", tt.expected, policy)
+			}
+		})
+	}
+}
+
+func TestLabelConvergenceTimeoutFromConfig(t *testing.T) {
+	tests := []struct {
+		name        string
+		value       types.String
+		expected    time.Duration
+		expectError bool
+	}{
+		{name: "unset", value: types.StringNull(), expected: client.DefaultLabelConvergenceTimeout},
+		{name: "override", value: types.StringValue("2m"), expected: 2 * time.Minute},
+		{name: "disabled", value: types.StringValue("0s"), expected: 0},
+		{name: "negative", value: types.StringValue("-1s"), expectError: true},
+		{name: "invalid duration", value: types.StringValue("soon"), expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var diags diag.Diagnostics
+			timeout := labelConvergenceTimeoutFromConfig(tt.value, &diags)
+
+			if tt.expectError {
+				if !diags.HasError() {
+					t.Error("Expected error diagnostics, got none")
+				}
+				return
+			}
+			if diags.HasError() {
+				t.Fatalf("Expected no error, got: %v", diags.Errors())
+			}
+			if timeout != tt.expected {
+				t.Errorf("Expected timeout %s, got %s", tt.expected, timeout)
+			}
+		})
+	}
+}
+
+// newUnitTestResource returns the resource built by newResource, configured
+// with api in place of the live client.
+func newUnitTestResource(t *testing.T, newResource func() resource.Resource, api client.InsightFinderAPI) (resource.Resource, schema.Schema) {
+	t.Helper()
+	ctx := context.Background()
+
+	r := newResource()
+	configureResp := &resource.ConfigureResponse{}
+	r.(resource.ResourceWithConfigure).Configure(ctx, resource.ConfigureRequest{ProviderData: api}, configureResp)
+	if configureResp.Diagnostics.HasError() {
+		t.Fatalf("Configure() produced errors: %v", configureResp.Diagnostics.Errors())
+	}
+
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+	if schemaResp.Diagnostics.HasError() {
+		t.Fatalf("Schema() produced errors: %v", schemaResp.Diagnostics.Errors())
+	}
+
+	return r, schemaResp.Schema
+}
+
+// newUnitTestState returns a state for s holding model, or an empty state if
+// model is nil.
+func newUnitTestState(t *testing.T, s schema.Schema, model interface{}) tfsdk.State {
+	t.Helper()
+
+	state := tfsdk.State{
+		Schema: s,
+		Raw:    tftypes.NewValue(s.Type().TerraformType(context.Background()), nil),
+	}
+	if model != nil {
+		if diags := state.Set(context.Background(), model); diags.HasError() {
+			t.Fatalf("Failed to build state: %v", diags.Errors())
+		}
+	}
+	return state
+}
+
+// newUnitTestPlan returns a plan for s holding model.
+func newUnitTestPlan(t *testing.T, s schema.Schema, model interface{}) tfsdk.Plan {
+	t.Helper()
+
+	state := newUnitTestState(t, s, model)
+	return tfsdk.Plan{Schema: state.Schema, Raw: state.Raw}
+}
+
+// nullTimeouts is an unset timeouts block.
+var nullTimeouts = timeouts.Value{
+	Object: types.ObjectNull(map[string]attr.Type{
+		"create": types.StringType,
+		"read":   types.StringType,
+		"update": types.StringType,
+		"delete": types.StringType,
+	}),
+}
@@ -5,15 +5,20 @@ package provider
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 
@@ -34,15 +39,16 @@ func NewJWTConfigResource() resource.Resource {
 
 // jwtConfigResource is the resource implementation.
 type jwtConfigResource struct {
-	client *client.Client
+	client client.InsightFinderAPI
 }
 
 // jwtConfigResourceModel maps the resource schema data.
 type jwtConfigResourceModel struct {
-	ID         types.String `tfsdk:"id"`
-	SystemName types.String `tfsdk:"system_name"`
-	JWTSecret  types.String `tfsdk:"jwt_secret"`
-	JWTType    types.Int64  `tfsdk:"jwt_type"`
+	ID         types.String   `tfsdk:"id"`
+	SystemName types.String   `tfsdk:"system_name"`
+	JWTSecret  types.String   `tfsdk:"jwt_secret"`
+	JWTType    types.Int64    `tfsdk:"jwt_type"`
+	Timeouts   timeouts.Value `tfsdk:"timeouts"`
 }
 
 // Metadata returns the resource type name.
@@ -51,7 +57,7 @@ func (r *jwtConfigResource) Metadata(_ context.Context, req resource.MetadataReq
 }
 
 // Schema defines the schema for the resource.
-func (r *jwtConfigResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+func (r *jwtConfigResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Description: "Manages InsightFinder JWT configuration for a system.",
 		Attributes: map[string]schema.Attribute{
@@ -73,6 +79,9 @@ func (r *jwtConfigResource) Schema(_ context.Context, _ resource.SchemaRequest,
 				Description: "The JWT secret token (minimum 6 characters).",
 				Required:    true,
 				Sensitive:   true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(6),
+				},
 			},
 			"jwt_type": schema.Int64Attribute{
 				Description: "The JWT type (1 for system-level JWT).",
@@ -81,8 +90,19 @@ func (r *jwtConfigResource) Schema(_ context.Context, _ resource.SchemaRequest,
 				PlanModifiers: []planmodifier.Int64{
 					int64planmodifier.UseStateForUnknown(),
 				},
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
+		},
 	}
 }
 
@@ -92,11 +112,11 @@ func (r *jwtConfigResource) Configure(_ context.Context, req resource.ConfigureR
 		return
 	}
 
-	client, ok := req.ProviderData.(*client.Client)
+	client, ok := req.ProviderData.(client.InsightFinderAPI)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected client.InsightFinderAPI, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
@@ -113,6 +133,15 @@ func (r *jwtConfigResource) Create(ctx context.Context, req resource.CreateReque
 		return
 	}
 
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
 	tflog.Debug(ctx, "Creating JWT config", map[string]interface{}{
 		"system_name": plan.SystemName.ValueString(),
 	})
@@ -128,11 +157,11 @@ func (r *jwtConfigResource) Create(ctx context.Context, req resource.CreateReque
 	}
 
 	// Resolve the system name to a system ID using the shared client helper
-	systemID, err := r.resolveSystemID(plan.SystemName.ValueString())
+	systemID, err := r.resolveSystemID(ctx, plan.SystemName.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Resolving System Name",
-			fmt.Sprintf("Could not find system '%s': %s", plan.SystemName.ValueString(), err.Error()),
+			fmt.Sprintf("Could not find system '%s': %s", plan.SystemName.ValueString(), apiErrorDetail(err)),
 		)
 		return
 	}
@@ -151,11 +180,11 @@ func (r *jwtConfigResource) Create(ctx context.Context, req resource.CreateReque
 		JWTType:    int(jwtType),
 	}
 
-	err = r.client.CreateOrUpdateJWTConfig(jwtConfig, r.client.Username)
+	err = r.client.CreateOrUpdateJWTConfig(ctx, jwtConfig, r.client.CustomerName())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Creating JWT Config",
-			"Could not create JWT config: "+err.Error(),
+			"Could not create JWT config: "+apiErrorDetail(err),
 		)
 		return
 	}
@@ -177,22 +206,35 @@ func (r *jwtConfigResource) Read(ctx context.Context, req resource.ReadRequest,
 		return
 	}
 
+	readTimeout, diags := state.Timeouts.Read(ctx, defaultReadTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
 	tflog.Debug(ctx, "Reading JWT config", map[string]interface{}{
 		"system_name": state.SystemName.ValueString(),
 	})
 
 	// Get current JWT configuration
-	jwtConfig, err := r.client.GetJWTConfig(state.SystemName.ValueString(), r.client.Username)
+	jwtConfig, err := r.client.GetJWTConfig(ctx, state.SystemName.ValueString(), r.client.CustomerName())
+	if errors.Is(err, client.ErrNotFound) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Reading JWT Config",
-			"Could not read JWT config: "+err.Error(),
+			"Could not read JWT config: "+apiErrorDetail(err),
 		)
 		return
 	}
 
-	// If JWT config doesn't exist, remove from state
-	if jwtConfig == nil || jwtConfig.JWTSecret == "" {
+	// If JWT config was cleared, remove from state
+	if jwtConfig.JWTSecret == "" {
 		resp.State.RemoveResource(ctx)
 		return
 	}
@@ -214,6 +256,15 @@ func (r *jwtConfigResource) Update(ctx context.Context, req resource.UpdateReque
 		return
 	}
 
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
 	tflog.Debug(ctx, "Updating JWT config", map[string]interface{}{
 		"system_name": plan.SystemName.ValueString(),
 	})
@@ -229,11 +280,11 @@ func (r *jwtConfigResource) Update(ctx context.Context, req resource.UpdateReque
 	}
 
 	// Resolve the system name to a system ID using the shared client helper
-	systemID, err := r.resolveSystemID(plan.SystemName.ValueString())
+	systemID, err := r.resolveSystemID(ctx, plan.SystemName.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Resolving System Name",
-			fmt.Sprintf("Could not find system '%s': %s", plan.SystemName.ValueString(), err.Error()),
+			fmt.Sprintf("Could not find system '%s': %s", plan.SystemName.ValueString(), apiErrorDetail(err)),
 		)
 		return
 	}
@@ -252,11 +303,11 @@ func (r *jwtConfigResource) Update(ctx context.Context, req resource.UpdateReque
 		JWTType:    int(jwtType),
 	}
 
-	err = r.client.CreateOrUpdateJWTConfig(jwtConfig, r.client.Username)
+	err = r.client.CreateOrUpdateJWTConfig(ctx, jwtConfig, r.client.CustomerName())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Updating JWT Config",
-			"Could not update JWT config: "+err.Error(),
+			"Could not update JWT config: "+apiErrorDetail(err),
 		)
 		return
 	}
@@ -277,17 +328,33 @@ func (r *jwtConfigResource) Delete(ctx context.Context, req resource.DeleteReque
 		return
 	}
 
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, defaultDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
 	tflog.Debug(ctx, "Deleting JWT config", map[string]interface{}{
 		"system_name": state.SystemName.ValueString(),
 	})
 
 	// Resolve the system name to a system ID
-	systemID, err := r.resolveSystemID(state.SystemName.ValueString())
-	if err != nil {
+	systemID, err := r.resolveSystemID(ctx, state.SystemName.ValueString())
+	if errors.Is(err, client.ErrNotFound) {
 		// If system not found, it's already deleted
 		tflog.Debug(ctx, "System not found, considering JWT config as already deleted")
 		return
 	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Resolving System Name",
+			fmt.Sprintf("Could not find system '%s': %s", state.SystemName.ValueString(), apiErrorDetail(err)),
+		)
+		return
+	}
 
 	// Delete JWT config by setting empty secret
 	jwtConfig := &client.JWTConfig{
@@ -297,11 +364,11 @@ func (r *jwtConfigResource) Delete(ctx context.Context, req resource.DeleteReque
 		JWTType:    0,
 	}
 
-	err = r.client.DeleteJWTConfig(jwtConfig, r.client.Username)
+	err = r.client.DeleteJWTConfig(ctx, jwtConfig, r.client.CustomerName())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Deleting JWT Config",
-			"Could not delete JWT config: "+err.Error(),
+			"Could not delete JWT config: "+apiErrorDetail(err),
 		)
 		return
 	}
@@ -314,19 +381,19 @@ func (r *jwtConfigResource) ImportState(ctx context.Context, req resource.Import
 }
 
 // resolveSystemID finds the system ID for a given system name
-func (r *jwtConfigResource) resolveSystemID(systemName string) (string, error) {
+func (r *jwtConfigResource) resolveSystemID(ctx context.Context, systemName string) (string, error) {
 	trimmedName := strings.TrimSpace(systemName)
 	if trimmedName == "" {
 		return "", fmt.Errorf("system name cannot be empty")
 	}
 
-	resolvedIDs, err := r.client.ResolveSystemNameToIDs([]string{trimmedName}, r.client.Username)
+	resolvedIDs, err := r.client.ResolveSystemNameToIDs(ctx, []string{trimmedName}, r.client.CustomerName())
 	if err != nil {
 		return "", err
 	}
 
 	if len(resolvedIDs) == 0 {
-		return "", fmt.Errorf("system '%s' not found", trimmedName)
+		return "", fmt.Errorf("system '%s': %w", trimmedName, client.ErrNotFound)
 	}
 
 	id := strings.TrimSpace(resolvedIDs[0])
@@ -4,10 +4,16 @@
 package provider
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"testing"
 
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+
+	"github.com/insightfinder/terraform-provider-insightfinder/internal/provider/client/clienttest"
 )
 
 func TestAccJWTConfigResource(t *testing.T) {
@@ -144,3 +150,248 @@ resource "insightfinder_jwt_config" "system2" {
 }
 `
 }
+
+func TestJWTConfigResourceCreate(t *testing.T) {
+	tests := []struct {
+		name        string
+		model       jwtConfigResourceModel
+		apiErr      error
+		expectError bool
+		expectType  int64
+	}{
+		{
+			name: "default type",
+			model: jwtConfigResourceModel{
+				SystemName: types.StringValue("JWT System"),
+				JWTSecret:  types.StringValue("s3cret-token"),
+				JWTType:    types.Int64Unknown(),
+			},
+			expectType: 1,
+		},
+		{
+			name: "explicit type",
+			model: jwtConfigResourceModel{
+				SystemName: types.StringValue("jwt system"),
+				JWTSecret:  types.StringValue("s3cret-token"),
+				JWTType:    types.Int64Value(2),
+			},
+			expectType: 2,
+		},
+		{
+			name: "short secret",
+			model: jwtConfigResourceModel{
+				SystemName: types.StringValue("JWT System"),
+				JWTSecret:  types.StringValue("abc"),
+				JWTType:    types.Int64Unknown(),
+			},
+			expectError: true,
+		},
+		{
+			name: "unknown system",
+			model: jwtConfigResourceModel{
+				SystemName: types.StringValue("missing"),
+				JWTSecret:  types.StringValue("s3cret-token"),
+				JWTType:    types.Int64Unknown(),
+			},
+			expectError: true,
+		},
+		{
+			name: "API error",
+			model: jwtConfigResourceModel{
+				SystemName: types.StringValue("JWT System"),
+				JWTSecret:  types.StringValue("s3cret-token"),
+				JWTType:    types.Int64Unknown(),
+			},
+			apiErr:      errors.New("boom"),
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			fake := clienttest.New("test_user")
+			system := fake.AddSystem("abc123", "JWT System")
+			if tt.apiErr != nil {
+				fake.Errors["CreateOrUpdateJWTConfig"] = tt.apiErr
+			}
+
+			r, s := newUnitTestResource(t, NewJWTConfigResource, fake)
+			tt.model.ID = types.StringUnknown()
+			tt.model.Timeouts = nullTimeouts
+
+			resp := &fwresource.CreateResponse{State: newUnitTestState(t, s, nil)}
+			r.Create(ctx, fwresource.CreateRequest{Plan: newUnitTestPlan(t, s, tt.model)}, resp)
+
+			if tt.expectError {
+				if !resp.Diagnostics.HasError() {
+					t.Fatal("Expected error diagnostics, got none")
+				}
+				return
+			}
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("Expected no error, got: %v", resp.Diagnostics.Errors())
+			}
+
+			var state jwtConfigResourceModel
+			resp.State.Get(ctx, &state)
+			if state.ID.ValueString() != tt.model.SystemName.ValueString() {
+				t.Errorf("Expected ID %q, got %q", tt.model.SystemName.ValueString(), state.ID.ValueString())
+			}
+			if state.JWTType.ValueInt64() != tt.expectType {
+				t.Errorf("Expected JWT type %d in state, got %d", tt.expectType, state.JWTType.ValueInt64())
+			}
+			if system.JWTSecret != "s3cret-token" || int64(system.JWTType) != tt.expectType {
+				t.Errorf("Unexpected JWT setting on system: %+v", system)
+			}
+		})
+	}
+}
+
+func TestJWTConfigResourceRead(t *testing.T) {
+	tests := []struct {
+		name         string
+		secret       string
+		systemExists bool
+		apiErr       error
+		expectError  bool
+		expectRemove bool
+	}{
+		{
+			name:         "configured",
+			secret:       "remote-secret",
+			systemExists: true,
+		},
+		{
+			name:         "secret cleared",
+			systemExists: true,
+			expectRemove: true,
+		},
+		{
+			name:         "system deleted",
+			expectRemove: true,
+		},
+		{
+			name:         "API error",
+			systemExists: true,
+			apiErr:       errors.New("boom"),
+			expectError:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			fake := clienttest.New("test_user")
+			if tt.systemExists {
+				system := fake.AddSystem("abc123", "JWT System")
+				system.JWTSecret = tt.secret
+				system.JWTType = 1
+			}
+			if tt.apiErr != nil {
+				fake.Errors["GetJWTConfig"] = tt.apiErr
+			}
+
+			r, s := newUnitTestResource(t, NewJWTConfigResource, fake)
+			current := newUnitTestState(t, s, jwtConfigResourceModel{
+				ID:         types.StringValue("JWT System"),
+				SystemName: types.StringValue("JWT System"),
+				JWTSecret:  types.StringValue("old-secret"),
+				JWTType:    types.Int64Value(1),
+				Timeouts:   nullTimeouts,
+			})
+
+			resp := &fwresource.ReadResponse{State: current}
+			r.Read(ctx, fwresource.ReadRequest{State: current}, resp)
+
+			if tt.expectError {
+				if !resp.Diagnostics.HasError() {
+					t.Fatal("Expected error diagnostics, got none")
+				}
+				return
+			}
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("Expected no error, got: %v", resp.Diagnostics.Errors())
+			}
+			if tt.expectRemove {
+				if !resp.State.Raw.IsNull() {
+					t.Error("Expected resource to be removed from state")
+				}
+				return
+			}
+
+			var state jwtConfigResourceModel
+			resp.State.Get(ctx, &state)
+			if state.JWTSecret.ValueString() != tt.secret {
+				t.Errorf("Expected secret %q from the API, got %q", tt.secret, state.JWTSecret.ValueString())
+			}
+		})
+	}
+}
+
+func TestJWTConfigResourceDelete(t *testing.T) {
+	tests := []struct {
+		name         string
+		systemExists bool
+		apiErr       error
+		expectError  bool
+	}{
+		{
+			name:         "clears secret",
+			systemExists: true,
+		},
+		{
+			name: "system already gone",
+		},
+		{
+			name:         "API error",
+			systemExists: true,
+			apiErr:       errors.New("boom"),
+			expectError:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			fake := clienttest.New("test_user")
+			var system *clienttest.System
+			if tt.systemExists {
+				system = fake.AddSystem("abc123", "JWT System")
+				system.JWTSecret = "s3cret-token"
+				system.JWTType = 1
+			}
+			if tt.apiErr != nil {
+				fake.Errors["DeleteJWTConfig"] = tt.apiErr
+			}
+
+			r, s := newUnitTestResource(t, NewJWTConfigResource, fake)
+			current := newUnitTestState(t, s, jwtConfigResourceModel{
+				ID:         types.StringValue("JWT System"),
+				SystemName: types.StringValue("JWT System"),
+				JWTSecret:  types.StringValue("s3cret-token"),
+				JWTType:    types.Int64Value(1),
+				Timeouts:   nullTimeouts,
+			})
+
+			resp := &fwresource.DeleteResponse{State: current}
+			r.Delete(ctx, fwresource.DeleteRequest{State: current}, resp)
+
+			if tt.expectError {
+				if !resp.Diagnostics.HasError() {
+					t.Fatal("Expected error diagnostics, got none")
+				}
+				return
+			}
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("Expected no error, got: %v", resp.Diagnostics.Errors())
+			}
+			if system != nil && system.JWTSecret != "" {
+				t.Errorf("Expected JWT secret to be cleared, got %q", system.JWTSecret)
+			}
+			if !tt.systemExists && fake.Called("DeleteJWTConfig") {
+				t.Error("Expected no delete call for a missing system")
+			}
+		})
+	}
+}
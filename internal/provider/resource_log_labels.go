@@ -6,13 +6,22 @@ package provider
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"regexp"
+	"strings"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 
@@ -21,9 +30,11 @@ import (
 
 // Ensure the implementation satisfies the expected interfaces.
 var (
-	_ resource.Resource                = &logLabelsResource{}
-	_ resource.ResourceWithConfigure   = &logLabelsResource{}
-	_ resource.ResourceWithImportState = &logLabelsResource{}
+	_ resource.Resource                   = &logLabelsResource{}
+	_ resource.ResourceWithConfigure      = &logLabelsResource{}
+	_ resource.ResourceWithImportState    = &logLabelsResource{}
+	_ resource.ResourceWithValidateConfig = &logLabelsResource{}
+	_ resource.ResourceWithUpgradeState   = &logLabelsResource{}
 )
 
 // NewLogLabelsResource is a helper function to simplify the provider implementation.
@@ -31,33 +42,99 @@ func NewLogLabelsResource() resource.Resource {
 	return &logLabelsResource{}
 }
 
+// Modes of managing the label lists of a project.
+const (
+	// logLabelsModeAuthoritative replaces the label list of each configured
+	// label type.
+	logLabelsModeAuthoritative = "authoritative"
+	// logLabelsModeAdditive only adds and removes the configured entries.
+	logLabelsModeAdditive = "additive"
+)
+
 // logLabelsResource is the resource implementation.
 type logLabelsResource struct {
-	client *client.Client
+	client client.InsightFinderAPI
 }
 
 // logLabelsResourceModel maps the resource schema data.
 type logLabelsResourceModel struct {
+	ID            types.String        `tfsdk:"id"`
+	ProjectName   types.String        `tfsdk:"project_name"`
+	Mode          types.String        `tfsdk:"mode"`
+	LabelSettings []labelSettingModel `tfsdk:"label_settings"`
+	Timeouts      timeouts.Value      `tfsdk:"timeouts"`
+}
+
+// labelSettingModel represents a label setting of the resource. Values or
+// Rules hold the labels depending on the shape of the label type, and
+// LogLabelString holds them as JSON; state keeps all of them in sync.
+type labelSettingModel struct {
+	LabelType      types.String `tfsdk:"label_type"`
+	Values         types.List   `tfsdk:"values"`
+	Rules          types.List   `tfsdk:"rules"`
+	LogLabelString types.String `tfsdk:"log_label_string"`
+}
+
+// logLabelsResourceModelV0 maps version 0 of the resource schema, which
+// held the labels only as JSON strings.
+type logLabelsResourceModelV0 struct {
 	ID            types.String           `tfsdk:"id"`
 	ProjectName   types.String           `tfsdk:"project_name"`
 	LabelSettings []logLabelSettingModel `tfsdk:"label_settings"`
+	Timeouts      timeouts.Value         `tfsdk:"timeouts"`
 }
 
-// logLabelSettingModel represents a single log label setting
+// logLabelSettingModel represents a single log label setting held as a JSON
+// string, as the project resource's log_label_settings do
 type logLabelSettingModel struct {
 	LabelType      types.String `tfsdk:"label_type"`
 	LogLabelString types.String `tfsdk:"log_label_string"`
 }
 
+// labelRuleKeys maps the attributes of a rule to the keys of the rule
+// objects the API stores.
+var labelRuleKeys = map[string]string{
+	"type":              "type",
+	"keyword":           "keyword",
+	"regex":             "regex",
+	"field":             "field",
+	"pattern_name_key":  "patternNameKey",
+	"is_critical":       "isCritical",
+	"is_hot_event_only": "isHotEventOnly",
+}
+
+// labelRuleAttrTypes are the types of the attributes of a rule.
+var labelRuleAttrTypes = map[string]attr.Type{
+	"type":              types.StringType,
+	"keyword":           types.StringType,
+	"regex":             types.StringType,
+	"field":             types.StringType,
+	"pattern_name_key":  types.StringType,
+	"is_critical":       types.BoolType,
+	"is_hot_event_only": types.BoolType,
+}
+
+// labelRule describes a rule so that it converts like the project's object
+// settings.
+func labelRule(rule *types.Object) projectObjectSetting {
+	return projectObjectSetting{
+		Name:      "rules",
+		Field:     rule,
+		Keys:      labelRuleKeys,
+		AttrTypes: labelRuleAttrTypes,
+	}
+}
+
 // Metadata returns the resource type name.
 func (r *logLabelsResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_log_labels"
 }
 
 // Schema defines the schema for the resource.
-func (r *logLabelsResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+func (r *logLabelsResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Description: "Manages InsightFinder log label settings for a project.",
+		Version:     1,
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
 				Description: "Identifier for the log labels configuration (project_name).",
@@ -73,23 +150,96 @@ func (r *logLabelsResource) Schema(_ context.Context, _ resource.SchemaRequest,
 					stringplanmodifier.RequiresReplace(),
 				},
 			},
+			"mode": schema.StringAttribute{
+				Description: "How the label lists are managed: \"authoritative\" (the default) replaces the label list of each configured label type; " +
+					"\"additive\" only adds the configured entries and removes them again when they leave the configuration or the resource is destroyed, " +
+					"leaving entries managed elsewhere in place.",
+				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString(logLabelsModeAuthoritative),
+				Validators: []validator.String{
+					stringvalidator.OneOf(logLabelsModeAuthoritative, logLabelsModeAdditive),
+				},
+			},
 			"label_settings": schema.ListNestedAttribute{
 				Description: "List of log label settings.",
 				Required:    true,
 				NestedObject: schema.NestedAttributeObject{
 					Attributes: map[string]schema.Attribute{
 						"label_type": schema.StringAttribute{
-							Description: "Type of log label (e.g., logSeverity, logEventID, logSession, logComponent, logTransactionID, logCustomParameter).",
-							Required:    true,
+							Description:         "Type of log label: " + strings.Join(client.LabelTypeNames(), ", ") + ".",
+							MarkdownDescription: labelTypeMarkdownDescription(),
+							Required:            true,
+							Validators: []validator.String{
+								stringvalidator.OneOf(client.LabelTypeNames()...),
+							},
+						},
+						"values": schema.ListAttribute{
+							Description: "Labels of label types that take strings or regular expressions.",
+							ElementType: types.StringType,
+							Optional:    true,
+							Computed:    true,
+						},
+						"rules": schema.ListNestedAttribute{
+							Description: "Labels of label types that take rule objects.",
+							Optional:    true,
+							Computed:    true,
+							NestedObject: schema.NestedAttributeObject{
+								Attributes: map[string]schema.Attribute{
+									"type": schema.StringAttribute{
+										Description: "How the rule matches log entries, such as fieldName.",
+										Optional:    true,
+									},
+									"keyword": schema.StringAttribute{
+										Description: "Keyword the rule matches.",
+										Optional:    true,
+									},
+									"regex": schema.StringAttribute{
+										Description: "Regular expression the rule matches.",
+										Optional:    true,
+									},
+									"field": schema.StringAttribute{
+										Description: "Log field the rule applies to.",
+										Optional:    true,
+									},
+									"pattern_name_key": schema.StringAttribute{
+										Description: "Field whose value names the pattern.",
+										Optional:    true,
+									},
+									"is_critical": schema.BoolAttribute{
+										Description: "Whether matching entries are critical.",
+										Optional:    true,
+										Computed:    true,
+									},
+									"is_hot_event_only": schema.BoolAttribute{
+										Description: "Whether the rule applies to hot events only.",
+										Optional:    true,
+										Computed:    true,
+									},
+								},
+							},
 						},
 						"log_label_string": schema.StringAttribute{
-							Description: "JSON array string of log labels (e.g., '[\"ERROR\",\"WARN\"]').",
-							Required:    true,
+							Description:        "JSON array of the labels, as produced by jsonencode(). Depending on label_type the entries are rule objects, strings or regular expressions.",
+							DeprecationMessage: "Set values or rules instead.",
+							Optional:           true,
+							Computed:           true,
+							Validators: []validator.String{
+								logLabelString(),
+							},
 						},
 					},
 				},
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
+		},
 	}
 }
 
@@ -99,11 +249,11 @@ func (r *logLabelsResource) Configure(_ context.Context, req resource.ConfigureR
 		return
 	}
 
-	client, ok := req.ProviderData.(*client.Client)
+	client, ok := req.ProviderData.(client.InsightFinderAPI)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected client.InsightFinderAPI, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
@@ -120,36 +270,42 @@ func (r *logLabelsResource) Create(ctx context.Context, req resource.CreateReque
 		return
 	}
 
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
 	tflog.Debug(ctx, "Creating log labels", map[string]interface{}{
 		"project_name": plan.ProjectName.ValueString(),
 	})
 
 	// Validate and convert label settings
-	settings, err := r.validateAndConvertSettings(plan.LabelSettings)
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Invalid Log Label Settings",
-			err.Error(),
-		)
+	settings, diags := r.validateAndConvertSettings(ctx, plan.LabelSettings)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
 	// Create log labels
-	err = r.client.CreateOrUpdateLogLabels(
-		plan.ProjectName.ValueString(),
-		r.client.Username,
-		settings,
-	)
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error Creating Log Labels",
-			"Could not create log labels: "+err.Error(),
-		)
+	var err error
+	if plan.Mode.ValueString() == logLabelsModeAdditive {
+		err = r.client.MergeLogLabels(ctx, plan.ProjectName.ValueString(), r.client.CustomerName(), settings, nil)
+	} else {
+		err = r.client.CreateOrUpdateLogLabels(ctx, plan.ProjectName.ValueString(), r.client.CustomerName(), settings)
+	}
+	// Labels written but not yet read back are kept in state, so the
+	// resource is tainted rather than lost
+	if err != nil && !addLogLabelsError(&resp.Diagnostics, "Error Creating Log Labels", "create", plan.LabelSettings, err) {
 		return
 	}
 
 	// Set state
 	plan.ID = plan.ProjectName
+	plan.LabelSettings = appliedLabelSettings(plan.LabelSettings, settings)
 
 	diags = resp.State.Set(ctx, plan)
 	resp.Diagnostics.Append(diags...)
@@ -164,19 +320,33 @@ func (r *logLabelsResource) Read(ctx context.Context, req resource.ReadRequest,
 		return
 	}
 
+	readTimeout, diags := state.Timeouts.Read(ctx, defaultReadTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
 	tflog.Debug(ctx, "Reading log labels", map[string]interface{}{
 		"project_name": state.ProjectName.ValueString(),
 	})
 
 	// Get current log labels
 	currentLabels, err := r.client.GetLogLabels(
+		ctx,
 		state.ProjectName.ValueString(),
-		r.client.Username,
+		r.client.CustomerName(),
 	)
+	if errors.Is(err, client.ErrNotFound) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Reading Log Labels",
-			"Could not read log labels: "+err.Error(),
+			"Could not read log labels: "+apiErrorDetail(err),
 		)
 		return
 	}
@@ -189,24 +359,46 @@ func (r *logLabelsResource) Read(ctx context.Context, req resource.ReadRequest,
 
 	// Update state with current values
 	// Map API fields back to label types
-	updatedSettings := make([]logLabelSettingModel, 0)
+	updatedSettings := make([]labelSettingModel, 0)
+
+	// On import there are no settings in state yet; take every label type
+	// the API reports
+	if len(state.LabelSettings) == 0 {
+		for _, setting := range convertLogLabelsToState(currentLabels, nil) {
+			updatedSettings = append(updatedSettings, labelSettingState(setting.LabelType.ValueString(), setting.LogLabelString.ValueString()))
+		}
+	}
+
+	// Imported resources and state written before mode existed are
+	// authoritative
+	if state.Mode.IsNull() {
+		state.Mode = types.StringValue(logLabelsModeAuthoritative)
+	}
 
 	// For each setting in the plan, check if it exists in current state
 	for _, setting := range state.LabelSettings {
 		labelType := setting.LabelType.ValueString()
 		apiField := client.MapLabelTypeToAPIField(labelType)
 
-		if labels, ok := currentLabels[apiField]; ok && len(labels) > 0 {
-			// Convert labels array to JSON string
-			labelsJSON, err := json.Marshal(labels)
+		// In additive mode only the entries this resource added are
+		// tracked; those removed elsewhere are added back on the next apply
+		if state.Mode.ValueString() == logLabelsModeAdditive {
+			present, err := client.PresentLogLabels(setting.LogLabelString.ValueString(), currentLabels[apiField])
 			if err != nil {
-				continue
+				resp.Diagnostics.AddAttributeError(
+					path.Root("label_settings"),
+					"Error Reading Log Labels",
+					fmt.Sprintf("Could not compare the %s labels: %s", labelType, err),
+				)
+				return
 			}
+			updatedSettings = append(updatedSettings, labelSettingState(labelType, present))
+			continue
+		}
 
-			updatedSettings = append(updatedSettings, logLabelSettingModel{
-				LabelType:      types.StringValue(labelType),
-				LogLabelString: types.StringValue(string(labelsJSON)),
-			})
+		// GetLogLabels already returns each label list as a JSON string
+		if labels, ok := currentLabels[apiField]; ok && labels != "" && labels != "[]" {
+			updatedSettings = append(updatedSettings, labelSettingState(labelType, labels))
 		}
 	}
 
@@ -216,6 +408,15 @@ func (r *logLabelsResource) Read(ctx context.Context, req resource.ReadRequest,
 		return
 	}
 
+	// values and rules cannot hold labels of another shape, so a setting
+	// using them would show a change on every plan
+	for i, setting := range updatedSettings {
+		if detail, ok := labelShapeMismatch(setting.LabelType.ValueString(), setting.LogLabelString.ValueString()); ok {
+			resp.Diagnostics.AddAttributeWarning(path.Root("label_settings").AtListIndex(i), "Log Labels of Another Shape", detail)
+		}
+	}
+
+	state.ID = state.ProjectName
 	state.LabelSettings = updatedSettings
 
 	diags = resp.State.Set(ctx, &state)
@@ -231,34 +432,52 @@ func (r *logLabelsResource) Update(ctx context.Context, req resource.UpdateReque
 		return
 	}
 
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
 	tflog.Debug(ctx, "Updating log labels", map[string]interface{}{
 		"project_name": plan.ProjectName.ValueString(),
 	})
 
 	// Validate and convert label settings
-	settings, err := r.validateAndConvertSettings(plan.LabelSettings)
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Invalid Log Label Settings",
-			err.Error(),
-		)
+	settings, diags := r.validateAndConvertSettings(ctx, plan.LabelSettings)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
 	// Update log labels
-	err = r.client.CreateOrUpdateLogLabels(
-		plan.ProjectName.ValueString(),
-		r.client.Username,
-		settings,
-	)
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error Updating Log Labels",
-			"Could not update log labels: "+err.Error(),
-		)
+	var err error
+	if plan.Mode.ValueString() == logLabelsModeAdditive {
+		var state logLabelsResourceModel
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		// Entries added before switching to additive mode may be shared
+		// with other configurations, so only remove entries this
+		// resource added
+		var remove []*client.LogLabelSetting
+		if state.Mode.ValueString() == logLabelsModeAdditive {
+			remove = stateLabelSettings(state.LabelSettings)
+		}
+		err = r.client.MergeLogLabels(ctx, plan.ProjectName.ValueString(), r.client.CustomerName(), settings, remove)
+	} else {
+		err = r.client.CreateOrUpdateLogLabels(ctx, plan.ProjectName.ValueString(), r.client.CustomerName(), settings)
+	}
+	if err != nil && !addLogLabelsError(&resp.Diagnostics, "Error Updating Log Labels", "update", plan.LabelSettings, err) {
 		return
 	}
 
+	plan.LabelSettings = appliedLabelSettings(plan.LabelSettings, settings)
+
 	diags = resp.State.Set(ctx, plan)
 	resp.Diagnostics.Append(diags...)
 }
@@ -272,26 +491,34 @@ func (r *logLabelsResource) Delete(ctx context.Context, req resource.DeleteReque
 		return
 	}
 
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, defaultDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
 	tflog.Debug(ctx, "Deleting log labels", map[string]interface{}{
 		"project_name": state.ProjectName.ValueString(),
 	})
 
-	// Collect all label types to delete
-	labelTypes := make([]string, 0, len(state.LabelSettings))
-	for _, setting := range state.LabelSettings {
-		labelTypes = append(labelTypes, setting.LabelType.ValueString())
-	}
+	var err error
+	if state.Mode.ValueString() == logLabelsModeAdditive {
+		// Remove only the entries this resource added
+		err = r.client.MergeLogLabels(ctx, state.ProjectName.ValueString(), r.client.CustomerName(), nil, stateLabelSettings(state.LabelSettings))
+	} else {
+		// Collect all label types to delete
+		labelTypes := make([]string, 0, len(state.LabelSettings))
+		for _, setting := range state.LabelSettings {
+			labelTypes = append(labelTypes, setting.LabelType.ValueString())
+		}
 
-	err := r.client.DeleteLogLabels(
-		state.ProjectName.ValueString(),
-		r.client.Username,
-		labelTypes,
-	)
+		err = r.client.DeleteLogLabels(ctx, state.ProjectName.ValueString(), r.client.CustomerName(), labelTypes)
+	}
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error Deleting Log Labels",
-			"Could not delete log labels: "+err.Error(),
-		)
+		addLogLabelsError(&resp.Diagnostics, "Error Deleting Log Labels", "delete", state.LabelSettings, err)
 		return
 	}
 }
@@ -302,26 +529,374 @@ func (r *logLabelsResource) ImportState(ctx context.Context, req resource.Import
 	resource.ImportStatePassthroughID(ctx, path.Root("project_name"), req, resp)
 }
 
-// validateAndConvertSettings validates log label strings are valid JSON arrays
-func (r *logLabelsResource) validateAndConvertSettings(settings []logLabelSettingModel) ([]*client.LogLabelSetting, error) {
+// UpgradeState migrates state written by earlier versions of the schema.
+func (r *logLabelsResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+
+	prior := schemaResp.Schema
+	prior.Version = 0
+	prior.Attributes = make(map[string]schema.Attribute, len(schemaResp.Schema.Attributes))
+	for name, attribute := range schemaResp.Schema.Attributes {
+		if name != "mode" {
+			prior.Attributes[name] = attribute
+		}
+	}
+	prior.Attributes["label_settings"] = schema.ListNestedAttribute{
+		Required: true,
+		NestedObject: schema.NestedAttributeObject{
+			Attributes: map[string]schema.Attribute{
+				"label_type":       schema.StringAttribute{Required: true},
+				"log_label_string": schema.StringAttribute{Required: true},
+			},
+		},
+	}
+
+	return map[int64]resource.StateUpgrader{
+		// Version 0 held the labels only as JSON strings
+		0: {
+			PriorSchema: &prior,
+			StateUpgrader: func(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+				var priorState logLabelsResourceModelV0
+				resp.Diagnostics.Append(req.State.Get(ctx, &priorState)...)
+				if resp.Diagnostics.HasError() {
+					return
+				}
+
+				state := logLabelsResourceModel{
+					ID:            priorState.ID,
+					ProjectName:   priorState.ProjectName,
+					Mode:          types.StringValue(logLabelsModeAuthoritative),
+					LabelSettings: make([]labelSettingModel, 0, len(priorState.LabelSettings)),
+					Timeouts:      priorState.Timeouts,
+				}
+				for _, setting := range priorState.LabelSettings {
+					upgraded := labelSettingState(setting.LabelType.ValueString(), setting.LogLabelString.ValueString())
+					// Keep the string as written so configurations still
+					// using it plan no changes
+					upgraded.LogLabelString = setting.LogLabelString
+					state.LabelSettings = append(state.LabelSettings, upgraded)
+				}
+
+				resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+			},
+		},
+	}
+}
+
+// ValidateConfig checks that each label setting holds its labels in the one
+// attribute that suits its label type.
+func (r *logLabelsResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var labelSettings types.List
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("label_settings"), &labelSettings)...)
+	if resp.Diagnostics.HasError() || labelSettings.IsNull() || labelSettings.IsUnknown() {
+		return
+	}
+
+	var settings []labelSettingModel
+	resp.Diagnostics.Append(labelSettings.ElementsAs(ctx, &settings, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	for i, setting := range settings {
+		settingPath := path.Root("label_settings").AtListIndex(i)
+
+		set := 0
+		for _, value := range []attr.Value{setting.Values, setting.Rules, setting.LogLabelString} {
+			if !value.IsNull() {
+				set++
+			}
+		}
+		if set != 1 {
+			resp.Diagnostics.AddAttributeError(
+				settingPath,
+				"Invalid Label Setting",
+				"Exactly one of values, rules and log_label_string must be set.",
+			)
+			continue
+		}
+
+		t, ok := client.LookupLabelType(setting.LabelType.ValueString())
+		if !ok {
+			// The label_type validator reports unknown label types
+			continue
+		}
+
+		switch {
+		case !setting.Values.IsNull() && t.Shape == client.LabelValueObjects:
+			resp.Diagnostics.AddAttributeError(
+				settingPath.AtName("values"),
+				"Invalid Label Setting",
+				fmt.Sprintf("%s labels are rule objects; set rules instead.", t.Name),
+			)
+		case !setting.Rules.IsNull() && t.Shape != client.LabelValueObjects:
+			resp.Diagnostics.AddAttributeError(
+				settingPath.AtName("rules"),
+				"Invalid Label Setting",
+				fmt.Sprintf("%s labels are %s; set values instead.", t.Name, t.Shape),
+			)
+		case t.Shape == client.LabelValueRegex && !setting.Values.IsUnknown():
+			for j, value := range setting.Values.Elements() {
+				pattern, ok := value.(types.String)
+				if !ok || pattern.IsNull() || pattern.IsUnknown() {
+					continue
+				}
+				if _, err := regexp.Compile(pattern.ValueString()); err != nil {
+					resp.Diagnostics.AddAttributeError(
+						settingPath.AtName("values").AtListIndex(j),
+						"Invalid Log Labels",
+						fmt.Sprintf("%s labels must be %s: %s", t.Name, t.Shape, err),
+					)
+				}
+			}
+		}
+	}
+}
+
+// labelTypeMarkdownDescription documents the label_type attribute with one
+// line per entry of client.LabelTypes.
+func labelTypeMarkdownDescription() string {
+	var b strings.Builder
+	b.WriteString("Type of log label. Label types taking strings or regular expressions are set with `values`, those taking rule objects with `rules`:\n")
+	for _, t := range client.LabelTypes {
+		fmt.Fprintf(&b, "\n  - `%s` (%s) %s", t.Name, t.Shape, t.Description)
+	}
+	return b.String()
+}
+
+// encodeLabelSetting returns the labels of setting as the JSON array the API
+// stores, taken from values, rules or log_label_string, whichever is known.
+func encodeLabelSetting(ctx context.Context, setting labelSettingModel) (string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	switch {
+	case !setting.Values.IsNull() && !setting.Values.IsUnknown():
+		values := make([]string, 0, len(setting.Values.Elements()))
+		diags.Append(setting.Values.ElementsAs(ctx, &values, false)...)
+		if diags.HasError() {
+			return "", diags
+		}
+		encoded, err := json.Marshal(values)
+		if err != nil {
+			diags.AddError("Invalid Log Label Settings", err.Error())
+			return "", diags
+		}
+		return string(encoded), diags
+
+	case !setting.Rules.IsNull() && !setting.Rules.IsUnknown():
+		rules := make([]map[string]interface{}, 0, len(setting.Rules.Elements()))
+		for _, element := range setting.Rules.Elements() {
+			rule, ok := element.(types.Object)
+			if !ok {
+				continue
+			}
+			value, _ := objectSettingValue(labelRule(&rule))
+			if value == nil {
+				value = map[string]interface{}{}
+			}
+			rules = append(rules, value)
+		}
+		encoded, err := json.Marshal(rules)
+		if err != nil {
+			diags.AddError("Invalid Log Label Settings", err.Error())
+			return "", diags
+		}
+		return string(encoded), diags
+
+	case !setting.LogLabelString.IsNull() && !setting.LogLabelString.IsUnknown():
+		return setting.LogLabelString.ValueString(), diags
+	}
+
+	diags.AddError(
+		"Invalid Log Label Settings",
+		fmt.Sprintf("The %s label setting has no labels; set values, rules or log_label_string.", setting.LabelType.ValueString()),
+	)
+	return "", diags
+}
+
+// labelSettingState converts the labels of a label type, as the JSON array
+// the API stores, to a label setting with values or rules filled in as the
+// label type takes them. Labels that do not match the shape of the label
+// type are only kept in log_label_string.
+func labelSettingState(labelType string, labels string) labelSettingModel {
+	setting := labelSettingModel{
+		LabelType:      types.StringValue(labelType),
+		Values:         types.ListNull(types.StringType),
+		Rules:          types.ListNull(types.ObjectType{AttrTypes: labelRuleAttrTypes}),
+		LogLabelString: types.StringValue(normalizeJSON(labels)),
+	}
+
+	t, ok := client.LookupLabelType(labelType)
+	if !ok || t.CheckLabels(labels) != nil {
+		return setting
+	}
+
+	var entries []interface{}
+	if err := json.Unmarshal([]byte(labels), &entries); err != nil {
+		return setting
+	}
+
+	elements := make([]attr.Value, 0, len(entries))
+	if t.Shape == client.LabelValueObjects {
+		for _, entry := range entries {
+			elements = append(elements, settingObject(labelRule(nil), entry))
+		}
+		setting.Rules = types.ListValueMust(types.ObjectType{AttrTypes: labelRuleAttrTypes}, elements)
+		return setting
+	}
+
+	for _, entry := range entries {
+		elements = append(elements, types.StringValue(entry.(string)))
+	}
+	setting.Values = types.ListValueMust(types.StringType, elements)
+	return setting
+}
+
+// labelShapeMismatch returns the detail of a warning when labels, as the API
+// returned them, are not of the shape their label type takes.
+func labelShapeMismatch(labelType string, labels string) (string, bool) {
+	t, ok := client.LookupLabelType(labelType)
+	if !ok || t.CheckLabels(labels) == nil {
+		return "", false
+	}
+
+	var entries []interface{}
+	if err := json.Unmarshal([]byte(labels), &entries); err != nil {
+		return "", false
+	}
+	var objects, texts int
+	for _, entry := range entries {
+		switch entry.(type) {
+		case map[string]interface{}:
+			objects++
+		case string:
+			texts++
+		}
+	}
+	returned := "entries of mixed shapes"
+	switch {
+	case objects == len(entries):
+		returned = client.LabelValueObjects.String()
+	case texts == len(entries) && t.Shape == client.LabelValueRegex:
+		returned = "strings that are not all valid regular expressions"
+	case texts == len(entries):
+		returned = client.LabelValueStrings.String()
+	}
+
+	attribute := "values"
+	if t.Shape == client.LabelValueObjects {
+		attribute = "rules"
+	}
+	return fmt.Sprintf("The API returned %s for the %s labels, which are %s. They are kept only in log_label_string, "+
+		"so a configuration setting %s will show a change on every plan. "+
+		"Set log_label_string to the labels as the API holds them, or correct them in InsightFinder.",
+		returned, t.Name, t.Shape, attribute), true
+}
+
+// appliedLabelSettings returns the state of the planned label settings once
+// the API holds settings. log_label_string keeps its planned value when
+// known so that configurations setting it see no difference.
+func appliedLabelSettings(planned []labelSettingModel, settings []*client.LogLabelSetting) []labelSettingModel {
+	result := make([]labelSettingModel, 0, len(settings))
+	for i, setting := range settings {
+		applied := labelSettingState(setting.LabelType, setting.LogLabelString)
+		if i < len(planned) && !planned[i].LogLabelString.IsNull() && !planned[i].LogLabelString.IsUnknown() {
+			applied.LogLabelString = planned[i].LogLabelString
+		}
+		result = append(result, applied)
+	}
+	return result
+}
+
+// addLogLabelsError reports err, returned by a write of settings. Label
+// types that were written but did not read back as written are reported
+// each on its label setting, and true is returned.
+func addLogLabelsError(diags *diag.Diagnostics, summary, action string, settings []labelSettingModel, err error) bool {
+	var notConverged *client.LabelsNotConvergedError
+	if !errors.As(err, &notConverged) {
+		diags.AddError(summary, fmt.Sprintf("Could not %s log labels: %s", action, apiErrorDetail(err)))
+		return false
+	}
+
+	for _, labelType := range notConverged.LabelTypes {
+		detail := fmt.Sprintf("The %s labels of project %q were written, but reading them back did not show the result of the %s before the wait ended. "+
+			"Check the labels in InsightFinder and run terraform apply again.", labelType, notConverged.ProjectName, action)
+
+		settingPath := path.Root("label_settings")
+		for i, setting := range settings {
+			if setting.LabelType.ValueString() == labelType {
+				settingPath = settingPath.AtListIndex(i)
+				break
+			}
+		}
+		diags.AddAttributeError(settingPath, summary, detail)
+	}
+	return true
+}
+
+// stateLabelSettings returns the label lists of label settings read from
+// state, where log_label_string is kept in sync with values and rules.
+func stateLabelSettings(settings []labelSettingModel) []*client.LogLabelSetting {
+	result := make([]*client.LogLabelSetting, 0, len(settings))
+	for _, setting := range settings {
+		result = append(result, &client.LogLabelSetting{
+			LabelType:      setting.LabelType.ValueString(),
+			LogLabelString: setting.LogLabelString.ValueString(),
+		})
+	}
+	return result
+}
+
+// validateAndConvertSettings checks the label settings against client.LabelTypes
+// and converts them for the API
+func (r *logLabelsResource) validateAndConvertSettings(ctx context.Context, settings []labelSettingModel) ([]*client.LogLabelSetting, diag.Diagnostics) {
+	var diags diag.Diagnostics
 	result := make([]*client.LogLabelSetting, 0, len(settings))
 
 	for i, setting := range settings {
 		labelType := setting.LabelType.ValueString()
-		logLabelString := setting.LogLabelString.ValueString()
+		logLabelString, d := encodeLabelSetting(ctx, setting)
+		diags.Append(d...)
+		if diags.HasError() {
+			return nil, diags
+		}
 
-		// Validate that logLabelString is a valid JSON array
-		var labels []string
-		if err := json.Unmarshal([]byte(logLabelString), &labels); err != nil {
-			return nil, fmt.Errorf("label_settings[%d].log_label_string is not a valid JSON array: %s", i, err.Error())
+		// Validation checks known values at plan time; this catches values
+		// that were unknown then
+		t, ok := client.LookupLabelType(labelType)
+		if !ok {
+			diags.AddError(
+				"Invalid Log Label Settings",
+				fmt.Sprintf("label_settings[%d].label_type %q is not a known label type", i, labelType),
+			)
+			return nil, diags
+		}
+		// The deprecated log_label_string, used when neither values nor rules
+		// is known, keeps accepting entries of another shape, which its
+		// validator warns about
+		check := t.CheckLabels
+		fromValues := !setting.Values.IsNull() && !setting.Values.IsUnknown()
+		fromRules := !setting.Rules.IsNull() && !setting.Rules.IsUnknown()
+		if !fromValues && !fromRules {
+			check = func(labels string) error {
+				_, err := decodeJSONShape(labels, jsonArray)
+				return err
+			}
+		}
+		if err := check(logLabelString); err != nil {
+			diags.AddError(
+				"Invalid Log Label Settings",
+				fmt.Sprintf("label_settings[%d] %s", i, err),
+			)
+			return nil, diags
 		}
 
 		result = append(result, &client.LogLabelSetting{
 			LabelType:      labelType,
 			LogLabelString: logLabelString,
-			Labels:         labels,
 		})
 	}
 
-	return result, nil
+	return result, diags
 }
@@ -4,10 +4,25 @@
 package provider
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"reflect"
+	"strings"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+
+	"github.com/insightfinder/terraform-provider-insightfinder/internal/provider/client"
+	"github.com/insightfinder/terraform-provider-insightfinder/internal/provider/client/clienttest"
 )
 
 func TestAccLogLabelsResource_Whitelist(t *testing.T) {
@@ -17,13 +32,14 @@ func TestAccLogLabelsResource_Whitelist(t *testing.T) {
 		Steps: []resource.TestStep{
 			// Create and Read testing
 			{
-				Config: testAccLogLabelsResourceConfigWhitelist("test-log-project", "test-system"),
+				Config: testAccLogLabelsResourceConfigWhitelist("log-labels-project"),
 				Check: resource.ComposeAggregateTestCheckFunc(
-					resource.TestCheckResourceAttr("insightfinder_log_labels.test", "project_name", "test-log-project"),
-					resource.TestCheckResourceAttr("insightfinder_log_labels.test", "system_name", "test-system"),
-					resource.TestCheckResourceAttr("insightfinder_log_labels.test", "whitelist.#", "2"),
-					resource.TestCheckResourceAttr("insightfinder_log_labels.test", "whitelist.0.label_name", "important"),
-					resource.TestCheckResourceAttr("insightfinder_log_labels.test", "whitelist.0.label_rule", "ERROR"),
+					resource.TestCheckResourceAttr("insightfinder_log_labels.test", "project_name", "log-labels-project"),
+					resource.TestCheckResourceAttr("insightfinder_log_labels.test", "label_settings.#", "1"),
+					resource.TestCheckResourceAttr("insightfinder_log_labels.test", "label_settings.0.label_type", "whitelist"),
+					resource.TestCheckResourceAttr("insightfinder_log_labels.test", "label_settings.0.values.#", "2"),
+					resource.TestCheckResourceAttr("insightfinder_log_labels.test", "label_settings.0.values.1", "CRITICAL|FATAL"),
+					resource.TestCheckResourceAttr("insightfinder_log_labels.test", "label_settings.0.log_label_string", `["ERROR","CRITICAL|FATAL"]`),
 					resource.TestCheckResourceAttrSet("insightfinder_log_labels.test", "id"),
 				),
 			},
@@ -35,9 +51,10 @@ func TestAccLogLabelsResource_Whitelist(t *testing.T) {
 			},
 			// Update and Read testing
 			{
-				Config: testAccLogLabelsResourceConfigWhitelistUpdated("test-log-project", "test-system"),
+				Config: testAccLogLabelsResourceConfigWhitelistUpdated("log-labels-project"),
 				Check: resource.ComposeAggregateTestCheckFunc(
-					resource.TestCheckResourceAttr("insightfinder_log_labels.test", "whitelist.#", "3"),
+					resource.TestCheckResourceAttr("insightfinder_log_labels.test", "label_settings.0.values.#", "3"),
+					resource.TestCheckResourceAttr("insightfinder_log_labels.test", "label_settings.0.values.2", "WARN"),
 				),
 			},
 		},
@@ -50,12 +67,15 @@ func TestAccLogLabelsResource_PatternNaming(t *testing.T) {
 		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
 		Steps: []resource.TestStep{
 			{
-				Config: testAccLogLabelsResourceConfigPatternNaming("pattern-project", "pattern-system"),
+				Config: testAccLogLabelsResourceConfigPatternNaming("pattern-project"),
 				Check: resource.ComposeAggregateTestCheckFunc(
 					resource.TestCheckResourceAttr("insightfinder_log_labels.test", "project_name", "pattern-project"),
-					resource.TestCheckResourceAttr("insightfinder_log_labels.test", "pattern_naming.#", "2"),
-					resource.TestCheckResourceAttr("insightfinder_log_labels.test", "pattern_naming.0.label_name", "database_error"),
-					resource.TestCheckResourceAttr("insightfinder_log_labels.test", "pattern_naming.0.label_rule", "database.*connection.*failed"),
+					resource.TestCheckResourceAttr("insightfinder_log_labels.test", "label_settings.#", "1"),
+					resource.TestCheckResourceAttr("insightfinder_log_labels.test", "label_settings.0.label_type", "patternName"),
+					resource.TestCheckResourceAttr("insightfinder_log_labels.test", "label_settings.0.rules.#", "2"),
+					resource.TestCheckResourceAttr("insightfinder_log_labels.test", "label_settings.0.rules.0.type", "fieldName"),
+					resource.TestCheckResourceAttr("insightfinder_log_labels.test", "label_settings.0.rules.1.keyword", "network.*timeout|connection.*timed out"),
+					resource.TestCheckResourceAttr("insightfinder_log_labels.test", "label_settings.0.log_label_string", `[{"keyword":"database.*connection.*failed","type":"fieldName"},{"keyword":"network.*timeout|connection.*timed out","type":"fieldName"}]`),
 				),
 			},
 		},
@@ -68,12 +88,13 @@ func TestAccLogLabelsResource_TrainingWhitelist(t *testing.T) {
 		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
 		Steps: []resource.TestStep{
 			{
-				Config: testAccLogLabelsResourceConfigTrainingWhitelist("training-project", "training-system"),
+				Config: testAccLogLabelsResourceConfigTrainingWhitelist("training-project"),
 				Check: resource.ComposeAggregateTestCheckFunc(
 					resource.TestCheckResourceAttr("insightfinder_log_labels.test", "project_name", "training-project"),
-					resource.TestCheckResourceAttr("insightfinder_log_labels.test", "training_whitelist.#", "1"),
-					resource.TestCheckResourceAttr("insightfinder_log_labels.test", "training_whitelist.0.label_name", "normal_operation"),
-					resource.TestCheckResourceAttr("insightfinder_log_labels.test", "training_whitelist.0.label_rule", "INFO:.*started successfully"),
+					resource.TestCheckResourceAttr("insightfinder_log_labels.test", "label_settings.#", "1"),
+					resource.TestCheckResourceAttr("insightfinder_log_labels.test", "label_settings.0.label_type", "trainingWhitelist"),
+					resource.TestCheckResourceAttr("insightfinder_log_labels.test", "label_settings.0.log_label_string", `["INFO:.*started successfully"]`),
+					resource.TestCheckResourceAttr("insightfinder_log_labels.test", "label_settings.0.values.0", "INFO:.*started successfully"),
 				),
 			},
 		},
@@ -86,11 +107,12 @@ func TestAccLogLabelsResource_Combined(t *testing.T) {
 		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
 		Steps: []resource.TestStep{
 			{
-				Config: testAccLogLabelsResourceConfigCombined("combined-project", "combined-system"),
+				Config: testAccLogLabelsResourceConfigCombined("combined-project"),
 				Check: resource.ComposeAggregateTestCheckFunc(
-					resource.TestCheckResourceAttr("insightfinder_log_labels.test", "whitelist.#", "1"),
-					resource.TestCheckResourceAttr("insightfinder_log_labels.test", "pattern_naming.#", "1"),
-					resource.TestCheckResourceAttr("insightfinder_log_labels.test", "training_whitelist.#", "1"),
+					resource.TestCheckResourceAttr("insightfinder_log_labels.test", "label_settings.#", "3"),
+					resource.TestCheckResourceAttr("insightfinder_log_labels.test", "label_settings.0.label_type", "whitelist"),
+					resource.TestCheckResourceAttr("insightfinder_log_labels.test", "label_settings.1.label_type", "patternName"),
+					resource.TestCheckResourceAttr("insightfinder_log_labels.test", "label_settings.2.label_type", "trainingWhitelist"),
 				),
 			},
 		},
@@ -103,169 +125,865 @@ func TestAccLogLabelsResource_MultipleRules(t *testing.T) {
 		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
 		Steps: []resource.TestStep{
 			{
-				Config: testAccLogLabelsResourceConfigMultipleRules("multi-rule-project", "multi-rule-system"),
+				Config: testAccLogLabelsResourceConfigMultipleRules("multi-rule-project"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("insightfinder_log_labels.test", "label_settings.#", "2"),
+					resource.TestCheckResourceAttr("insightfinder_log_labels.test", "label_settings.0.log_label_string", `["ERROR","FATAL","Exception|Traceback","WARN","CRITICAL"]`),
+					resource.TestCheckResourceAttr("insightfinder_log_labels.test", "label_settings.1.log_label_string", `[{"keyword":"OutOfMemory|OOM|memory.*exceeded","type":"fieldName"},{"keyword":"disk.*full|no space left","type":"fieldName"},{"keyword":"connection refused|failed to connect","type":"fieldName"}]`),
+				),
+			},
+		},
+	})
+}
+
+func TestAccLogLabelsResource_Additive(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccLogLabelsResourceConfigAdditive("additive-project"),
 				Check: resource.ComposeAggregateTestCheckFunc(
-					resource.TestCheckResourceAttr("insightfinder_log_labels.test", "whitelist.#", "5"),
-					resource.TestCheckResourceAttr("insightfinder_log_labels.test", "pattern_naming.#", "3"),
+					resource.TestCheckResourceAttr("insightfinder_log_labels.platform", "mode", "additive"),
+					resource.TestCheckResourceAttr("insightfinder_log_labels.platform", "label_settings.0.values.#", "1"),
+					resource.TestCheckResourceAttr("insightfinder_log_labels.application", "label_settings.0.values.#", "1"),
+					resource.TestCheckResourceAttr("insightfinder_log_labels.application", "label_settings.0.values.0", "payment failed"),
 				),
 			},
 		},
 	})
 }
 
-func testAccLogLabelsResourceConfigWhitelist(projectName, systemName string) string {
+func testAccLogLabelsResourceConfigWhitelist(projectName string) string {
 	return fmt.Sprintf(`
 resource "insightfinder_log_labels" "test" {
   project_name = %[1]q
-  system_name  = %[2]q
 
-  whitelist = [
-    {
-      label_name = "important"
-      label_rule = "ERROR"
-    },
+  label_settings = [
     {
-      label_name = "critical"
-      label_rule = "CRITICAL|FATAL"
+      label_type = "whitelist"
+      values     = ["ERROR", "CRITICAL|FATAL"]
     }
   ]
 }
-`, projectName, systemName)
+`, projectName)
 }
 
-func testAccLogLabelsResourceConfigWhitelistUpdated(projectName, systemName string) string {
+func testAccLogLabelsResourceConfigWhitelistUpdated(projectName string) string {
 	return fmt.Sprintf(`
 resource "insightfinder_log_labels" "test" {
   project_name = %[1]q
-  system_name  = %[2]q
 
-  whitelist = [
+  label_settings = [
     {
-      label_name = "important"
-      label_rule = "ERROR"
-    },
-    {
-      label_name = "critical"
-      label_rule = "CRITICAL|FATAL"
-    },
-    {
-      label_name = "warning"
-      label_rule = "WARN"
+      label_type = "whitelist"
+      values     = ["ERROR", "CRITICAL|FATAL", "WARN"]
     }
   ]
 }
-`, projectName, systemName)
+`, projectName)
 }
 
-func testAccLogLabelsResourceConfigPatternNaming(projectName, systemName string) string {
+func testAccLogLabelsResourceConfigPatternNaming(projectName string) string {
 	return fmt.Sprintf(`
 resource "insightfinder_log_labels" "test" {
   project_name = %[1]q
-  system_name  = %[2]q
 
-  pattern_naming = [
+  label_settings = [
     {
-      label_name = "database_error"
-      label_rule = "database.*connection.*failed"
-    },
-    {
-      label_name = "network_timeout"
-      label_rule = "network.*timeout|connection.*timed out"
+      label_type = "patternName"
+      rules = [
+        { type = "fieldName", keyword = "database.*connection.*failed" },
+        { type = "fieldName", keyword = "network.*timeout|connection.*timed out" },
+      ]
     }
   ]
 }
-`, projectName, systemName)
+`, projectName)
 }
 
-func testAccLogLabelsResourceConfigTrainingWhitelist(projectName, systemName string) string {
+// The deprecated log_label_string keeps working
+func testAccLogLabelsResourceConfigTrainingWhitelist(projectName string) string {
 	return fmt.Sprintf(`
 resource "insightfinder_log_labels" "test" {
   project_name = %[1]q
-  system_name  = %[2]q
 
-  training_whitelist = [
+  label_settings = [
     {
-      label_name = "normal_operation"
-      label_rule = "INFO:.*started successfully"
+      label_type       = "trainingWhitelist"
+      log_label_string = jsonencode(["INFO:.*started successfully"])
     }
   ]
 }
-`, projectName, systemName)
+`, projectName)
 }
 
-func testAccLogLabelsResourceConfigCombined(projectName, systemName string) string {
+func testAccLogLabelsResourceConfigCombined(projectName string) string {
 	return fmt.Sprintf(`
 resource "insightfinder_log_labels" "test" {
   project_name = %[1]q
-  system_name  = %[2]q
 
-  whitelist = [
+  label_settings = [
     {
-      label_name = "errors"
-      label_rule = "ERROR|EXCEPTION"
-    }
-  ]
-
-  pattern_naming = [
+      label_type = "whitelist"
+      values     = ["ERROR|EXCEPTION"]
+    },
     {
-      label_name = "auth_failure"
-      label_rule = "authentication.*failed|login.*denied"
-    }
-  ]
-
-  training_whitelist = [
+      label_type = "patternName"
+      rules      = [{ type = "fieldName", keyword = "authentication.*failed|login.*denied" }]
+    },
     {
-      label_name = "routine"
-      label_rule = "INFO:.*routine check"
+      label_type = "trainingWhitelist"
+      values     = ["INFO:.*routine check"]
     }
   ]
 }
-`, projectName, systemName)
+`, projectName)
 }
 
-func testAccLogLabelsResourceConfigMultipleRules(projectName, systemName string) string {
+func testAccLogLabelsResourceConfigMultipleRules(projectName string) string {
 	return fmt.Sprintf(`
 resource "insightfinder_log_labels" "test" {
   project_name = %[1]q
-  system_name  = %[2]q
 
-  whitelist = [
+  label_settings = [
     {
-      label_name = "error"
-      label_rule = "ERROR"
+      label_type = "whitelist"
+      values     = ["ERROR", "FATAL", "Exception|Traceback", "WARN", "CRITICAL"]
     },
     {
-      label_name = "fatal"
-      label_rule = "FATAL"
-    },
-    {
-      label_name = "exception"
-      label_rule = "Exception|Traceback"
-    },
-    {
-      label_name = "warning"
-      label_rule = "WARN"
-    },
-    {
-      label_name = "critical"
-      label_rule = "CRITICAL"
+      label_type = "patternName"
+      rules = [
+        { type = "fieldName", keyword = "OutOfMemory|OOM|memory.*exceeded" },
+        { type = "fieldName", keyword = "disk.*full|no space left" },
+        { type = "fieldName", keyword = "connection refused|failed to connect" },
+      ]
     }
   ]
+}
+`, projectName)
+}
 
-  pattern_naming = [
-    {
-      label_name = "out_of_memory"
-      label_rule = "OutOfMemory|OOM|memory.*exceeded"
-    },
+func testAccLogLabelsResourceConfigAdditive(projectName string) string {
+	return fmt.Sprintf(`
+resource "insightfinder_log_labels" "platform" {
+  project_name = %[1]q
+  mode         = "additive"
+
+  label_settings = [
     {
-      label_name = "disk_full"
-      label_rule = "disk.*full|no space left"
-    },
+      label_type = "whitelist"
+      values     = ["OutOfMemory"]
+    }
+  ]
+}
+
+resource "insightfinder_log_labels" "application" {
+  project_name = %[1]q
+  mode         = "additive"
+
+  label_settings = [
     {
-      label_name = "connection_refused"
-      label_rule = "connection refused|failed to connect"
+      label_type = "whitelist"
+      values     = ["payment failed"]
     }
   ]
 }
-`, projectName, systemName)
+`, projectName)
+}
+
+// plannedValues returns a planned label setting holding values, with the
+// attributes left unset unknown as Terraform plans them.
+func plannedValues(labelType string, values ...string) labelSettingModel {
+	elements := make([]attr.Value, 0, len(values))
+	for _, value := range values {
+		elements = append(elements, types.StringValue(value))
+	}
+	return labelSettingModel{
+		LabelType:      types.StringValue(labelType),
+		Values:         types.ListValueMust(types.StringType, elements),
+		Rules:          types.ListUnknown(types.ObjectType{AttrTypes: labelRuleAttrTypes}),
+		LogLabelString: types.StringUnknown(),
+	}
+}
+
+// plannedRules returns a planned label setting holding fieldName rules
+// matching keywords.
+func plannedRules(labelType string, keywords ...string) labelSettingModel {
+	elements := make([]attr.Value, 0, len(keywords))
+	for _, keyword := range keywords {
+		elements = append(elements, types.ObjectValueMust(labelRuleAttrTypes, map[string]attr.Value{
+			"type":              types.StringValue("fieldName"),
+			"keyword":           types.StringValue(keyword),
+			"regex":             types.StringNull(),
+			"field":             types.StringNull(),
+			"pattern_name_key":  types.StringNull(),
+			"is_critical":       types.BoolUnknown(),
+			"is_hot_event_only": types.BoolUnknown(),
+		}))
+	}
+	return labelSettingModel{
+		LabelType:      types.StringValue(labelType),
+		Values:         types.ListUnknown(types.StringType),
+		Rules:          types.ListValueMust(types.ObjectType{AttrTypes: labelRuleAttrTypes}, elements),
+		LogLabelString: types.StringUnknown(),
+	}
+}
+
+// plannedLogLabelString returns a planned label setting holding the
+// deprecated log_label_string.
+func plannedLogLabelString(labelType, labels string) labelSettingModel {
+	return labelSettingModel{
+		LabelType:      types.StringValue(labelType),
+		Values:         types.ListUnknown(types.StringType),
+		Rules:          types.ListUnknown(types.ObjectType{AttrTypes: labelRuleAttrTypes}),
+		LogLabelString: types.StringValue(labels),
+	}
+}
+
+// configuredLabelSetting returns planned with the attributes left unset null,
+// as they are in configuration.
+func configuredLabelSetting(planned labelSettingModel) labelSettingModel {
+	if planned.Values.IsUnknown() {
+		planned.Values = types.ListNull(types.StringType)
+	}
+	if planned.Rules.IsUnknown() {
+		planned.Rules = types.ListNull(types.ObjectType{AttrTypes: labelRuleAttrTypes})
+	}
+	if planned.LogLabelString.IsUnknown() {
+		planned.LogLabelString = types.StringNull()
+	}
+	return planned
+}
+
+func TestLogLabelsResourceCreate(t *testing.T) {
+	tests := []struct {
+		name        string
+		settings    []labelSettingModel
+		apiErr      error
+		expectError bool
+		expectAPI   map[string]string
+		expected    []labelSettingModel
+	}{
+		{
+			name: "whitelist and blacklist",
+			settings: []labelSettingModel{
+				plannedValues("whitelist", "ERROR", "CRITICAL|FATAL"),
+				plannedValues("blacklist", "DEBUG"),
+			},
+			expectAPI: map[string]string{
+				"whitelist":               `["ERROR","CRITICAL|FATAL"]`,
+				"trainingBlacklistLabels": `["DEBUG"]`,
+			},
+			expected: []labelSettingModel{
+				labelSettingState("whitelist", `["ERROR","CRITICAL|FATAL"]`),
+				labelSettingState("blacklist", `["DEBUG"]`),
+			},
+		},
+		{
+			name:      "rules",
+			settings:  []labelSettingModel{plannedRules("patternName", "message")},
+			expectAPI: map[string]string{"patternNameLabels": `[{"keyword":"message","type":"fieldName"}]`},
+			expected:  []labelSettingModel{labelSettingState("patternName", `[{"keyword":"message","type":"fieldName"}]`)},
+		},
+		{
+			name:      "deprecated log_label_string",
+			settings:  []labelSettingModel{plannedLogLabelString("whitelist", `[ "ERROR" ]`)},
+			expectAPI: map[string]string{"whitelist": `[ "ERROR" ]`},
+			expected: []labelSettingModel{{
+				LabelType:      types.StringValue("whitelist"),
+				Values:         types.ListValueMust(types.StringType, []attr.Value{types.StringValue("ERROR")}),
+				Rules:          types.ListNull(types.ObjectType{AttrTypes: labelRuleAttrTypes}),
+				LogLabelString: types.StringValue(`[ "ERROR" ]`),
+			}},
+		},
+		{
+			name:      "deprecated rule objects for a string type",
+			settings:  []labelSettingModel{plannedLogLabelString("whitelist", `[{"type":"fieldName","keyword":"severity=error","isCritical":true}]`)},
+			expectAPI: map[string]string{"whitelist": `[{"type":"fieldName","keyword":"severity=error","isCritical":true}]`},
+		},
+		{
+			name:        "not a JSON array",
+			settings:    []labelSettingModel{plannedLogLabelString("whitelist", `"ERROR"`)},
+			expectError: true,
+		},
+		{
+			name:        "values for a rule type",
+			settings:    []labelSettingModel{plannedValues("patternName", "message")},
+			expectError: true,
+		},
+		{
+			name:        "unknown label type",
+			settings:    []labelSettingModel{plannedValues("logSession", "session")},
+			expectError: true,
+		},
+		{
+			name:      "regular expressions",
+			settings:  []labelSettingModel{plannedValues("patternMatchRegex", `timeout after \d+ms`)},
+			expectAPI: map[string]string{"patternMatchRegexLabels": `["timeout after \\d+ms"]`},
+		},
+		{
+			name:        "API error",
+			settings:    []labelSettingModel{plannedValues("whitelist", "ERROR")},
+			apiErr:      errors.New("boom"),
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			fake := clienttest.New("test_user")
+			fake.Projects["labels-project"] = &client.ProjectConfig{ProjectName: "labels-project"}
+			if tt.apiErr != nil {
+				fake.Errors["CreateOrUpdateLogLabels"] = tt.apiErr
+			}
+
+			r, s := newUnitTestResource(t, NewLogLabelsResource, fake)
+			plan := newUnitTestPlan(t, s, logLabelsResourceModel{
+				ID:            types.StringUnknown(),
+				ProjectName:   types.StringValue("labels-project"),
+				LabelSettings: tt.settings,
+				Timeouts:      nullTimeouts,
+			})
+
+			resp := &fwresource.CreateResponse{State: newUnitTestState(t, s, nil)}
+			r.Create(ctx, fwresource.CreateRequest{Plan: plan}, resp)
+
+			if tt.expectError {
+				if !resp.Diagnostics.HasError() {
+					t.Fatal("Expected error diagnostics, got none")
+				}
+				return
+			}
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("Expected no error, got: %v", resp.Diagnostics.Errors())
+			}
+
+			for field, expected := range tt.expectAPI {
+				if got := fake.LogLabels["labels-project"][field]; got != expected {
+					t.Errorf("Expected %s to be %s, got %s", field, expected, got)
+				}
+			}
+
+			var state logLabelsResourceModel
+			resp.State.Get(ctx, &state)
+			for i, expected := range tt.expected {
+				if !reflect.DeepEqual(state.LabelSettings[i], expected) {
+					t.Errorf("Expected setting %d to be %v, got %v", i, expected, state.LabelSettings[i])
+				}
+			}
+		})
+	}
+}
+
+func TestLogLabelsResourceRead(t *testing.T) {
+	tests := []struct {
+		name          string
+		apiLabels     map[string]string
+		projectGone   bool
+		state         []labelSettingModel
+		expectRemove  bool
+		expected      []labelSettingModel
+		expectWarning string
+	}{
+		{
+			name:      "normalizes API JSON",
+			apiLabels: map[string]string{"whitelist": `[ "ERROR", "FATAL" ]`},
+			state:     []labelSettingModel{labelSettingState("whitelist", `["ERROR"]`)},
+			expected:  []labelSettingModel{labelSettingState("whitelist", `["ERROR","FATAL"]`)},
+		},
+		{
+			name:      "rules",
+			apiLabels: map[string]string{"patternNameLabels": `[{"type":"fieldName","keyword":"message","isCritical":true}]`},
+			state:     []labelSettingModel{labelSettingState("patternName", `[]`)},
+			expected: []labelSettingModel{{
+				LabelType: types.StringValue("patternName"),
+				Values:    types.ListNull(types.StringType),
+				Rules: types.ListValueMust(types.ObjectType{AttrTypes: labelRuleAttrTypes}, []attr.Value{
+					types.ObjectValueMust(labelRuleAttrTypes, map[string]attr.Value{
+						"type":              types.StringValue("fieldName"),
+						"keyword":           types.StringValue("message"),
+						"regex":             types.StringNull(),
+						"field":             types.StringNull(),
+						"pattern_name_key":  types.StringNull(),
+						"is_critical":       types.BoolValue(true),
+						"is_hot_event_only": types.BoolNull(),
+					}),
+				}),
+				LogLabelString: types.StringValue(`[{"isCritical":true,"keyword":"message","type":"fieldName"}]`),
+			}},
+		},
+		{
+			name:      "labels not matching the label type",
+			apiLabels: map[string]string{"whitelist": `[{"keyword":"ERROR"}]`},
+			state:     []labelSettingModel{labelSettingState("whitelist", `["ERROR"]`)},
+			expected: []labelSettingModel{{
+				LabelType:      types.StringValue("whitelist"),
+				Values:         types.ListNull(types.StringType),
+				Rules:          types.ListNull(types.ObjectType{AttrTypes: labelRuleAttrTypes}),
+				LogLabelString: types.StringValue(`[{"keyword":"ERROR"}]`),
+			}},
+			expectWarning: "The API returned rule objects for the whitelist labels, which are strings.",
+		},
+		{
+			name:      "drops types removed remotely",
+			apiLabels: map[string]string{"whitelist": `["ERROR"]`},
+			state: []labelSettingModel{
+				labelSettingState("whitelist", `["ERROR"]`),
+				labelSettingState("blacklist", `["DEBUG"]`),
+			},
+			expected: []labelSettingModel{labelSettingState("whitelist", `["ERROR"]`)},
+		},
+		{
+			name:      "import takes all API labels",
+			apiLabels: map[string]string{"whitelist": `["ERROR"]`, "trainingBlacklistLabels": `["DEBUG"]`},
+			expected: []labelSettingModel{
+				labelSettingState("whitelist", `["ERROR"]`),
+				labelSettingState("blacklist", `["DEBUG"]`),
+			},
+		},
+		{
+			name:         "no labels left",
+			apiLabels:    map[string]string{},
+			state:        []labelSettingModel{labelSettingState("whitelist", `["ERROR"]`)},
+			expectRemove: true,
+		},
+		{
+			name:         "project deleted",
+			projectGone:  true,
+			state:        []labelSettingModel{labelSettingState("whitelist", `["ERROR"]`)},
+			expectRemove: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			fake := clienttest.New("test_user")
+			if !tt.projectGone {
+				fake.Projects["labels-project"] = &client.ProjectConfig{ProjectName: "labels-project"}
+				fake.LogLabels["labels-project"] = tt.apiLabels
+			}
+
+			r, s := newUnitTestResource(t, NewLogLabelsResource, fake)
+			current := newUnitTestState(t, s, logLabelsResourceModel{
+				ID:            types.StringValue("labels-project"),
+				ProjectName:   types.StringValue("labels-project"),
+				LabelSettings: tt.state,
+				Timeouts:      nullTimeouts,
+			})
+
+			resp := &fwresource.ReadResponse{State: current}
+			r.Read(ctx, fwresource.ReadRequest{State: current}, resp)
+
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("Expected no error, got: %v", resp.Diagnostics.Errors())
+			}
+			warnings := resp.Diagnostics.Warnings()
+			if tt.expectWarning == "" && len(warnings) != 0 {
+				t.Errorf("Expected no warning, got: %v", warnings)
+			}
+			if tt.expectWarning != "" && (len(warnings) != 1 || !strings.Contains(warnings[0].Detail(), tt.expectWarning)) {
+				t.Errorf("Expected a warning containing %q, got: %v", tt.expectWarning, warnings)
+			}
+			if tt.expectRemove {
+				if !resp.State.Raw.IsNull() {
+					t.Error("Expected resource to be removed from state")
+				}
+				return
+			}
+
+			var state logLabelsResourceModel
+			resp.State.Get(ctx, &state)
+			if len(state.LabelSettings) != len(tt.expected) {
+				t.Fatalf("Expected %d label settings, got %d: %v", len(tt.expected), len(state.LabelSettings), state.LabelSettings)
+			}
+			for i, expected := range tt.expected {
+				if !reflect.DeepEqual(state.LabelSettings[i], expected) {
+					t.Errorf("Expected setting %d to be %v, got %v", i, expected, state.LabelSettings[i])
+				}
+			}
+		})
+	}
+}
+
+func TestLogLabelsResourceValidateConfig(t *testing.T) {
+	tests := []struct {
+		name       string
+		setting    labelSettingModel
+		errorPaths []string
+	}{
+		{
+			name:    "values",
+			setting: configuredLabelSetting(plannedValues("whitelist", "ERROR")),
+		},
+		{
+			name:    "rules",
+			setting: configuredLabelSetting(plannedRules("patternName", "message")),
+		},
+		{
+			name:    "log_label_string",
+			setting: configuredLabelSetting(plannedLogLabelString("whitelist", `["ERROR"]`)),
+		},
+		{
+			name: "unknown values",
+			setting: labelSettingModel{
+				LabelType:      types.StringValue("patternMatchRegex"),
+				Values:         types.ListUnknown(types.StringType),
+				Rules:          types.ListNull(types.ObjectType{AttrTypes: labelRuleAttrTypes}),
+				LogLabelString: types.StringNull(),
+			},
+		},
+		{
+			name: "values and log_label_string",
+			setting: labelSettingModel{
+				LabelType:      types.StringValue("whitelist"),
+				Values:         types.ListValueMust(types.StringType, []attr.Value{types.StringValue("ERROR")}),
+				Rules:          types.ListNull(types.ObjectType{AttrTypes: labelRuleAttrTypes}),
+				LogLabelString: types.StringValue(`["ERROR"]`),
+			},
+			errorPaths: []string{"label_settings[0]"},
+		},
+		{
+			name: "no labels",
+			setting: labelSettingModel{
+				LabelType:      types.StringValue("whitelist"),
+				Values:         types.ListNull(types.StringType),
+				Rules:          types.ListNull(types.ObjectType{AttrTypes: labelRuleAttrTypes}),
+				LogLabelString: types.StringNull(),
+			},
+			errorPaths: []string{"label_settings[0]"},
+		},
+		{
+			name:       "values for a rule type",
+			setting:    configuredLabelSetting(plannedValues("patternName", "message")),
+			errorPaths: []string{"label_settings[0].values"},
+		},
+		{
+			name:       "rules for a string type",
+			setting:    configuredLabelSetting(plannedRules("whitelist", "ERROR")),
+			errorPaths: []string{"label_settings[0].rules"},
+		},
+		{
+			name:       "invalid regular expression",
+			setting:    configuredLabelSetting(plannedValues("patternIgnoreRegex", "timeout", "(unclosed")),
+			errorPaths: []string{"label_settings[0].values[1]"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			r, s := newUnitTestResource(t, NewLogLabelsResource, clienttest.New("test_user"))
+			config := newUnitTestPlan(t, s, logLabelsResourceModel{
+				ID:            types.StringNull(),
+				ProjectName:   types.StringValue("test-project"),
+				LabelSettings: []labelSettingModel{tt.setting},
+				Timeouts:      nullTimeouts,
+			})
+
+			resp := &fwresource.ValidateConfigResponse{}
+			r.(fwresource.ResourceWithValidateConfig).ValidateConfig(ctx, fwresource.ValidateConfigRequest{
+				Config: tfsdk.Config{Schema: config.Schema, Raw: config.Raw},
+			}, resp)
+
+			var errorPaths []string
+			for _, d := range resp.Diagnostics.Errors() {
+				errorPaths = append(errorPaths, d.(diag.DiagnosticWithPath).Path().String())
+			}
+			if fmt.Sprint(errorPaths) != fmt.Sprint(tt.errorPaths) {
+				t.Errorf("Expected errors at %v, got %v", tt.errorPaths, resp.Diagnostics.Errors())
+			}
+		})
+	}
+}
+
+func TestLogLabelsResourceValidateLabels(t *testing.T) {
+	tests := []struct {
+		name         string
+		labelType    string
+		labels       string
+		errorPaths   []string
+		warningPaths []string
+	}{
+		{
+			name:      "rule objects",
+			labelType: "patternName",
+			labels:    `[{"type":"fieldName","keyword":"message"}]`,
+		},
+		{
+			name:      "strings",
+			labelType: "whitelist",
+			labels:    `["ERROR"]`,
+		},
+		{
+			name:         "strings for a rule type",
+			labelType:    "patternName",
+			labels:       `["message"]`,
+			warningPaths: []string{"label_settings[0].log_label_string"},
+		},
+		{
+			name:         "rule objects for a string type",
+			labelType:    "whitelist",
+			labels:       `[{"type":"fieldName","keyword":"severity=error|critical","isCritical":true,"isHotEventOnly":false}]`,
+			warningPaths: []string{"label_settings[0].log_label_string"},
+		},
+		{
+			name:         "invalid regular expression",
+			labelType:    "patternIgnoreRegex",
+			labels:       `["(unclosed"]`,
+			warningPaths: []string{"label_settings[0].log_label_string"},
+		},
+		{
+			name:       "not an array",
+			labelType:  "whitelist",
+			labels:     `{"type":"fieldName"}`,
+			errorPaths: []string{"label_settings[0].log_label_string"},
+		},
+		{
+			name:       "unknown label type",
+			labelType:  "logSession",
+			labels:     `["session"]`,
+			errorPaths: []string{"label_settings[0].label_type"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			_, s := newUnitTestResource(t, NewLogLabelsResource, clienttest.New("test_user"))
+			plan := newUnitTestPlan(t, s, logLabelsResourceModel{
+				ID:            types.StringUnknown(),
+				ProjectName:   types.StringValue("test-project"),
+				LabelSettings: []labelSettingModel{configuredLabelSetting(plannedLogLabelString(tt.labelType, tt.labels))},
+				Timeouts:      nullTimeouts,
+			})
+			config := tfsdk.Config{Schema: plan.Schema, Raw: plan.Raw}
+
+			var errorPaths, warningPaths []string
+			for _, name := range []string{"label_type", "log_label_string"} {
+				attrPath := path.Root("label_settings").AtListIndex(0).AtName(name)
+				attribute, diags := s.AttributeAtPath(ctx, attrPath)
+				if diags.HasError() {
+					t.Fatalf("Missing attribute %s: %v", attrPath, diags)
+				}
+
+				var value types.String
+				config.GetAttribute(ctx, attrPath, &value)
+				resp := &validator.StringResponse{}
+				for _, v := range attribute.(interface{ StringValidators() []validator.String }).StringValidators() {
+					v.ValidateString(ctx, validator.StringRequest{Path: attrPath, ConfigValue: value, Config: config}, resp)
+				}
+				if resp.Diagnostics.HasError() {
+					errorPaths = append(errorPaths, attrPath.String())
+				}
+				if resp.Diagnostics.WarningsCount() > 0 {
+					warningPaths = append(warningPaths, attrPath.String())
+				}
+			}
+
+			if fmt.Sprint(errorPaths) != fmt.Sprint(tt.errorPaths) {
+				t.Errorf("Expected errors at %v, got %v", tt.errorPaths, errorPaths)
+			}
+			if fmt.Sprint(warningPaths) != fmt.Sprint(tt.warningPaths) {
+				t.Errorf("Expected warnings at %v, got %v", tt.warningPaths, warningPaths)
+			}
+		})
+	}
+}
+
+func TestLogLabelsResourceUpgradeState(t *testing.T) {
+	ctx := context.Background()
+	r, current := newUnitTestResource(t, NewLogLabelsResource, clienttest.New("test_user"))
+	upgrader := r.(fwresource.ResourceWithUpgradeState).UpgradeState(ctx)[0]
+
+	prior := tfsdk.State{
+		Schema: *upgrader.PriorSchema,
+		Raw:    tftypes.NewValue(upgrader.PriorSchema.Type().TerraformType(ctx), nil),
+	}
+	diags := prior.Set(ctx, logLabelsResourceModelV0{
+		ID:          types.StringValue("labels-project"),
+		ProjectName: types.StringValue("labels-project"),
+		LabelSettings: []logLabelSettingModel{
+			{LabelType: types.StringValue("whitelist"), LogLabelString: types.StringValue(`["ERROR", "FATAL"]`)},
+			{LabelType: types.StringValue("patternName"), LogLabelString: types.StringValue(`[{"keyword":"message","type":"fieldName"}]`)},
+			{LabelType: types.StringValue("blacklist"), LogLabelString: types.StringValue(`[{"keyword":"DEBUG"}]`)},
+		},
+		Timeouts: nullTimeouts,
+	})
+	if diags.HasError() {
+		t.Fatalf("Could not build prior state: %v", diags.Errors())
+	}
+
+	resp := &fwresource.UpgradeStateResponse{State: tfsdk.State{Schema: current}}
+	upgrader.StateUpgrader(ctx, fwresource.UpgradeStateRequest{State: &prior}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Expected no error, got: %v", resp.Diagnostics.Errors())
+	}
+
+	var state logLabelsResourceModel
+	if diags := resp.State.Get(ctx, &state); diags.HasError() {
+		t.Fatalf("Expected upgraded state to match the schema, got: %v", diags.Errors())
+	}
+	if state.ProjectName.ValueString() != "labels-project" {
+		t.Errorf("Expected project_name to be kept, got %s", state.ProjectName)
+	}
+
+	whitelist := labelSettingState("whitelist", `["ERROR","FATAL"]`)
+	// The prior string is kept as written
+	whitelist.LogLabelString = types.StringValue(`["ERROR", "FATAL"]`)
+	expected := []labelSettingModel{
+		whitelist,
+		labelSettingState("patternName", `[{"keyword":"message","type":"fieldName"}]`),
+		// Labels not matching the label type stay in log_label_string only
+		{
+			LabelType:      types.StringValue("blacklist"),
+			Values:         types.ListNull(types.StringType),
+			Rules:          types.ListNull(types.ObjectType{AttrTypes: labelRuleAttrTypes}),
+			LogLabelString: types.StringValue(`[{"keyword":"DEBUG"}]`),
+		},
+	}
+	if !reflect.DeepEqual(state.LabelSettings, expected) {
+		t.Errorf("Expected label settings %v, got %v", expected, state.LabelSettings)
+	}
+}
+
+func TestLogLabelsResourceAdditive(t *testing.T) {
+	ctx := context.Background()
+	fake := clienttest.New("test_user")
+	fake.Projects["labels-project"] = &client.ProjectConfig{ProjectName: "labels-project"}
+	fake.LogLabels["labels-project"] = map[string]string{"whitelist": `["A","B"]`}
+
+	r, s := newUnitTestResource(t, NewLogLabelsResource, fake)
+	model := func(mode string, settings ...labelSettingModel) logLabelsResourceModel {
+		return logLabelsResourceModel{
+			ID:            types.StringValue("labels-project"),
+			ProjectName:   types.StringValue("labels-project"),
+			Mode:          types.StringValue(mode),
+			LabelSettings: settings,
+			Timeouts:      nullTimeouts,
+		}
+	}
+	expectLabels := func(expected map[string]string) {
+		t.Helper()
+		if fmt.Sprint(fake.LogLabels["labels-project"]) != fmt.Sprint(expected) {
+			t.Errorf("Expected labels %v, got %v", expected, fake.LogLabels["labels-project"])
+		}
+	}
+
+	// Create merges into the entries managed elsewhere
+	createResp := &fwresource.CreateResponse{State: newUnitTestState(t, s, nil)}
+	r.Create(ctx, fwresource.CreateRequest{
+		Plan: newUnitTestPlan(t, s, model(logLabelsModeAdditive, plannedValues("whitelist", "C", "A"), plannedValues("blacklist", "DEBUG"))),
+	}, createResp)
+	if createResp.Diagnostics.HasError() {
+		t.Fatalf("Create: expected no error, got: %v", createResp.Diagnostics.Errors())
+	}
+	expectLabels(map[string]string{"whitelist": `["A","B","C"]`, "trainingBlacklistLabels": `["DEBUG"]`})
+
+	// Update removes only the entries that left the configuration
+	updateResp := &fwresource.UpdateResponse{State: createResp.State}
+	r.Update(ctx, fwresource.UpdateRequest{
+		State: createResp.State,
+		Plan:  newUnitTestPlan(t, s, model(logLabelsModeAdditive, plannedValues("whitelist", "D", "A"), plannedValues("blacklist", "DEBUG"))),
+	}, updateResp)
+	if updateResp.Diagnostics.HasError() {
+		t.Fatalf("Update: expected no error, got: %v", updateResp.Diagnostics.Errors())
+	}
+	expectLabels(map[string]string{"whitelist": `["A","B","D"]`, "trainingBlacklistLabels": `["DEBUG"]`})
+
+	// Read tracks only this resource's entries, dropping those removed
+	// elsewhere
+	fake.LogLabels["labels-project"]["whitelist"] = `["B","D","E"]`
+	readResp := &fwresource.ReadResponse{State: updateResp.State}
+	r.Read(ctx, fwresource.ReadRequest{State: updateResp.State}, readResp)
+	if readResp.Diagnostics.HasError() {
+		t.Fatalf("Read: expected no error, got: %v", readResp.Diagnostics.Errors())
+	}
+	var state logLabelsResourceModel
+	readResp.State.Get(ctx, &state)
+	expected := []labelSettingModel{labelSettingState("whitelist", `["D"]`), labelSettingState("blacklist", `["DEBUG"]`)}
+	if !reflect.DeepEqual(state.LabelSettings, expected) {
+		t.Errorf("Expected label settings %v, got %v", expected, state.LabelSettings)
+	}
+
+	// Delete leaves the entries managed elsewhere
+	r.Delete(ctx, fwresource.DeleteRequest{State: readResp.State}, &fwresource.DeleteResponse{})
+	expectLabels(map[string]string{"whitelist": `["B","E"]`})
+}
+
+func TestLogLabelsResourceSwitchToAdditive(t *testing.T) {
+	ctx := context.Background()
+	fake := clienttest.New("test_user")
+	fake.Projects["labels-project"] = &client.ProjectConfig{ProjectName: "labels-project"}
+	fake.LogLabels["labels-project"] = map[string]string{"whitelist": `["A","B"]`}
+
+	r, s := newUnitTestResource(t, NewLogLabelsResource, fake)
+	state := newUnitTestState(t, s, logLabelsResourceModel{
+		ID:            types.StringValue("labels-project"),
+		ProjectName:   types.StringValue("labels-project"),
+		Mode:          types.StringValue(logLabelsModeAuthoritative),
+		LabelSettings: []labelSettingModel{labelSettingState("whitelist", `["A","B"]`)},
+		Timeouts:      nullTimeouts,
+	})
+	plan := newUnitTestPlan(t, s, logLabelsResourceModel{
+		ID:            types.StringValue("labels-project"),
+		ProjectName:   types.StringValue("labels-project"),
+		Mode:          types.StringValue(logLabelsModeAdditive),
+		LabelSettings: []labelSettingModel{plannedValues("whitelist", "C")},
+		Timeouts:      nullTimeouts,
+	})
+
+	resp := &fwresource.UpdateResponse{State: state}
+	r.Update(ctx, fwresource.UpdateRequest{State: state, Plan: plan}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Expected no error, got: %v", resp.Diagnostics.Errors())
+	}
+
+	// Entries owned while authoritative may be shared and are kept
+	if got := fake.LogLabels["labels-project"]["whitelist"]; got != `["A","B","C"]` {
+		t.Errorf("Expected whitelist to keep its entries, got %s", got)
+	}
+}
+
+func TestLogLabelsResourceNotConverged(t *testing.T) {
+	ctx := context.Background()
+	fake := clienttest.New("test_user")
+	fake.Projects["labels-project"] = &client.ProjectConfig{ProjectName: "labels-project"}
+	fake.Errors["CreateOrUpdateLogLabels"] = &client.LabelsNotConvergedError{ProjectName: "labels-project", LabelTypes: []string{"blacklist"}}
+	fake.Errors["DeleteLogLabels"] = &client.LabelsNotConvergedError{ProjectName: "labels-project", LabelTypes: []string{"whitelist", "blacklist"}}
+
+	r, s := newUnitTestResource(t, NewLogLabelsResource, fake)
+	plan := newUnitTestPlan(t, s, logLabelsResourceModel{
+		ID:            types.StringUnknown(),
+		ProjectName:   types.StringValue("labels-project"),
+		LabelSettings: []labelSettingModel{plannedValues("whitelist", "ERROR"), plannedValues("blacklist", "DEBUG")},
+		Timeouts:      nullTimeouts,
+	})
+
+	errorPaths := func(diags diag.Diagnostics) string {
+		var paths []string
+		for _, d := range diags.Errors() {
+			paths = append(paths, d.(diag.DiagnosticWithPath).Path().String())
+		}
+		return fmt.Sprint(paths)
+	}
+
+	// Labels written but not read back stay in state so the resource is
+	// tainted rather than lost
+	createResp := &fwresource.CreateResponse{State: newUnitTestState(t, s, nil)}
+	r.Create(ctx, fwresource.CreateRequest{Plan: plan}, createResp)
+	if got := errorPaths(createResp.Diagnostics); got != "[label_settings[1]]" {
+		t.Errorf("Expected an error on the blacklist setting, got %v", createResp.Diagnostics.Errors())
+	}
+	if createResp.State.Raw.IsNull() {
+		t.Error("Expected the written labels to be kept in state")
+	}
+
+	deleteResp := &fwresource.DeleteResponse{State: createResp.State}
+	r.Delete(ctx, fwresource.DeleteRequest{State: createResp.State}, deleteResp)
+	if got := errorPaths(deleteResp.Diagnostics); got != "[label_settings[0] label_settings[1]]" {
+		t.Errorf("Expected an error on each setting, got %v", deleteResp.Diagnostics.Errors())
+	}
 }
@@ -7,15 +7,27 @@ package provider
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
-
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/float64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 
 	"github.com/insightfinder/terraform-provider-insightfinder/internal/provider/client"
@@ -23,9 +35,11 @@ import (
 
 // Ensure the implementation satisfies the expected interfaces.
 var (
-	_ resource.Resource                = &projectResource{}
-	_ resource.ResourceWithConfigure   = &projectResource{}
-	_ resource.ResourceWithImportState = &projectResource{}
+	_ resource.Resource                   = &projectResource{}
+	_ resource.ResourceWithConfigure      = &projectResource{}
+	_ resource.ResourceWithImportState    = &projectResource{}
+	_ resource.ResourceWithValidateConfig = &projectResource{}
+	_ resource.ResourceWithUpgradeState   = &projectResource{}
 )
 
 // NewProjectResource is a helper function to simplify the provider implementation.
@@ -35,7 +49,7 @@ func NewProjectResource() resource.Resource {
 
 // projectResource is the resource implementation.
 type projectResource struct {
-	client *client.Client
+	client client.InsightFinderAPI
 }
 
 // projectResourceModel maps the resource schema data.
@@ -45,6 +59,9 @@ type projectResourceModel struct {
 	ProjectDisplayName    types.String                `tfsdk:"project_display_name"`
 	SystemName            types.String                `tfsdk:"system_name"`
 	ProjectCreationConfig *projectCreationConfigModel `tfsdk:"project_creation_config"`
+	AdoptExisting         types.Bool                  `tfsdk:"adopt_existing"`
+	DeletionProtection    types.Bool                  `tfsdk:"deletion_protection"`
+	RetainOnDelete        types.Bool                  `tfsdk:"retain_on_delete"`
 	CValue                types.Int64                 `tfsdk:"c_value"`
 	PValue                types.Float64               `tfsdk:"p_value"`
 	ProjectTimeZone       types.String                `tfsdk:"project_time_zone"`
@@ -152,15 +169,17 @@ type projectResourceModel struct {
 	ZoneNameKey                  types.String `tfsdk:"zone_name_key"`
 
 	// Complex object fields (will use types.String for JSON encoding)
-	BaseValueSetting       types.String `tfsdk:"base_value_setting"`
-	CdfSetting             types.String `tfsdk:"cdf_setting"`
-	EmailSetting           types.String `tfsdk:"email_setting"`
-	InstanceGroupingUpdate types.String `tfsdk:"instance_grouping_update"`
-	LlmEvaluationSetting   types.String `tfsdk:"llm_evaluation_setting"`
-	LogToLogSettingList    types.String `tfsdk:"log_to_log_setting_list"`
-	WebhookHeaderList      types.String `tfsdk:"webhook_header_list"`
-	SharedUsernames        types.String `tfsdk:"shared_usernames"`
-	LogLabelSettings       types.List   `tfsdk:"log_label_settings"`
+	BaseValueSetting       types.String   `tfsdk:"base_value_setting"`
+	CdfSetting             types.String   `tfsdk:"cdf_setting"`
+	EmailSetting           types.Object   `tfsdk:"email_setting"`
+	InstanceGroupingUpdate types.String   `tfsdk:"instance_grouping_update"`
+	LlmEvaluationSetting   types.Object   `tfsdk:"llm_evaluation_setting"`
+	LogToLogSettingList    types.String   `tfsdk:"log_to_log_setting_list"`
+	WebhookHeaderList      types.String   `tfsdk:"webhook_header_list"`
+	SharedUsernames        types.String   `tfsdk:"shared_usernames"`
+	ExtraSettings          types.String   `tfsdk:"extra_settings"`
+	LogLabelSettings       types.List     `tfsdk:"log_label_settings"`
+	Timeouts               timeouts.Value `tfsdk:"timeouts"`
 }
 
 type projectCreationConfigModel struct {
@@ -177,9 +196,12 @@ func (r *projectResource) Metadata(_ context.Context, req resource.MetadataReque
 }
 
 // Schema defines the schema for the resource.
-func (r *projectResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+func (r *projectResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Description: "Manages an InsightFinder project.",
+		// Version 1 replaced the email_setting JSON string with a nested
+		// attribute, version 2 did the same for llm_evaluation_setting
+		Version: 2,
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
 				Description: "The unique identifier for the project (same as project_name).",
@@ -205,45 +227,84 @@ func (r *projectResource) Schema(_ context.Context, _ resource.SchemaRequest, re
 				Required:    true,
 			},
 			"c_value": schema.Int64Attribute{
-				Description: "The C value for anomaly detection sensitivity (typically 2-5).",
+				Description: "The C value for anomaly detection sensitivity (typically 2-5). Must be at least 1.",
 				Optional:    true,
 				Computed:    true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
 			},
 			"p_value": schema.Float64Attribute{
 				Description: "The P value for anomaly detection probability (0.0-1.0).",
 				Optional:    true,
 				Computed:    true,
+				Validators: []validator.Float64{
+					float64validator.Between(0, 1),
+				},
 			},
 			"project_time_zone": schema.StringAttribute{
-				Description: "The timezone for the project (default: UTC).",
+				Description: "The IANA time zone for the project, such as UTC or America/New_York (default: UTC).",
 				Optional:    true,
 				Computed:    true,
+				Validators: []validator.String{
+					timeZone(),
+				},
 			},
 			"sampling_interval": schema.Int64Attribute{
 				Description: "The sampling interval in seconds.",
 				Optional:    true,
 				Computed:    true,
 			},
+			"adopt_existing": schema.BoolAttribute{
+				Description: "Whether to take over a project that already exists with the same name instead of failing. The existing settings are read into state before the configured ones are applied. Defaults to false; prefer `terraform import` for existing projects.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+			},
+			"deletion_protection": schema.BoolAttribute{
+				Description: "Whether Terraform is prevented from destroying the project. While true, any plan that deletes or replaces the project fails at apply time; set it to false and apply before destroying.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+			},
+			"retain_on_delete": schema.BoolAttribute{
+				Description: "Whether destroying the resource only removes it from Terraform state, leaving the project and its data in InsightFinder. Defaults to false.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+			},
 			"project_creation_config": schema.SingleNestedAttribute{
 				Description: "Configuration for creating the project.",
 				Required:    true,
 				Attributes: map[string]schema.Attribute{
 					"data_type": schema.StringAttribute{
-						Description: "The type of data (e.g., Log, Metric, Trace).",
+						Description: "The type of data: " + strings.Join(client.DataTypes, ", ") + ".",
 						Required:    true,
+						Validators: []validator.String{
+							stringvalidator.OneOf(client.DataTypes...),
+						},
 					},
 					"instance_type": schema.StringAttribute{
-						Description: "The instance type (e.g., PrivateCloud, AWS, Azure).",
+						Description: "The instance type: " + strings.Join(client.InstanceTypes, ", ") + ".",
 						Required:    true,
+						Validators: []validator.String{
+							stringvalidator.OneOf(client.InstanceTypes...),
+						},
 					},
 					"project_cloud_type": schema.StringAttribute{
-						Description: "The cloud type for the project.",
+						Description: "The cloud type for the project, usually the same as instance_type: " + strings.Join(client.InstanceTypes, ", ") + ".",
 						Required:    true,
+						Validators: []validator.String{
+							stringvalidator.OneOf(client.InstanceTypes...),
+						},
 					},
 					"insight_agent_type": schema.StringAttribute{
-						Description: "The InsightFinder agent type.",
+						Description: "The InsightFinder agent type: " + strings.Join(client.InsightAgentTypes, ", ") + ".",
 						Optional:    true,
 						Computed:    true,
+						Validators: []validator.String{
+							stringvalidator.OneOf(client.InsightAgentTypes...),
+						},
 					},
 					"project_creation_type": schema.StringAttribute{
 						Description: "The project creation type.",
@@ -297,6 +358,9 @@ func (r *projectResource) Schema(_ context.Context, _ resource.SchemaRequest, re
 				Description: "Threshold for cold events",
 				Optional:    true,
 				Computed:    true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
 			},
 			"cold_number_limit": schema.Int64Attribute{
 				Description: "Limit for cold numbers",
@@ -347,16 +411,25 @@ func (r *projectResource) Schema(_ context.Context, _ resource.SchemaRequest, re
 				Description: "Threshold for anomaly score escalation",
 				Optional:    true,
 				Computed:    true,
+				Validators: []validator.String{
+					numericStringAtLeast(0),
+				},
 			},
 			"feature_outlier_sensitivity": schema.StringAttribute{
 				Description: "Sensitivity for feature outlier detection",
 				Optional:    true,
 				Computed:    true,
+				Validators: []validator.String{
+					numericStringBetween(0, 1),
+				},
 			},
 			"feature_outlier_threshold": schema.Float64Attribute{
 				Description: "Threshold for feature outlier detection",
 				Optional:    true,
 				Computed:    true,
+				Validators: []validator.Float64{
+					float64validator.AtLeast(0),
+				},
 			},
 			"hot_event_calm_down_period": schema.Int64Attribute{
 				Description: "Calm down period for hot events",
@@ -372,6 +445,9 @@ func (r *projectResource) Schema(_ context.Context, _ resource.SchemaRequest, re
 				Description: "Threshold for hot event detection",
 				Optional:    true,
 				Computed:    true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
 			},
 			"hot_number_limit": schema.Int64Attribute{
 				Description: "Limit for hot numbers",
@@ -382,6 +458,9 @@ func (r *projectResource) Schema(_ context.Context, _ resource.SchemaRequest, re
 				Description: "Threshold to ignore anomaly scores",
 				Optional:    true,
 				Computed:    true,
+				Validators: []validator.String{
+					numericStringAtLeast(0),
+				},
 			},
 			"ignore_instance_for_kb": schema.BoolAttribute{
 				Description: "Ignore instance for knowledge base",
@@ -552,11 +631,17 @@ func (r *projectResource) Schema(_ context.Context, _ resource.SchemaRequest, re
 				Description: "Threshold for prediction count",
 				Optional:    true,
 				Computed:    true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
 			},
 			"prediction_probability_threshold": schema.Float64Attribute{
 				Description: "Threshold for prediction probability",
 				Optional:    true,
 				Computed:    true,
+				Validators: []validator.Float64{
+					float64validator.Between(0, 1),
+				},
 			},
 			"prediction_rule_active_condition": schema.Int64Attribute{
 				Description: "Active condition for prediction rules",
@@ -567,21 +652,33 @@ func (r *projectResource) Schema(_ context.Context, _ resource.SchemaRequest, re
 				Description: "Active threshold for prediction rules",
 				Optional:    true,
 				Computed:    true,
+				Validators: []validator.Float64{
+					float64validator.Between(0, 1),
+				},
 			},
 			"prediction_rule_false_positive_threshold": schema.Int64Attribute{
 				Description: "False positive threshold for prediction rules",
 				Optional:    true,
 				Computed:    true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
 			},
 			"prediction_rule_inactive_threshold": schema.Float64Attribute{
 				Description: "Inactive threshold for prediction rules",
 				Optional:    true,
 				Computed:    true,
+				Validators: []validator.Float64{
+					float64validator.Between(0, 1),
+				},
 			},
 			"root_cause_count_threshold": schema.Int64Attribute{
 				Description: "Threshold for root cause count",
 				Optional:    true,
 				Computed:    true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
 			},
 			"root_cause_log_message_search_range": schema.Int64Attribute{
 				Description: "Search range for root cause log messages",
@@ -592,6 +689,9 @@ func (r *projectResource) Schema(_ context.Context, _ resource.SchemaRequest, re
 				Description: "Threshold for root cause probability",
 				Optional:    true,
 				Computed:    true,
+				Validators: []validator.Float64{
+					float64validator.Between(0, 1),
+				},
 			},
 			"root_cause_rank_setting": schema.Int64Attribute{
 				Description: "Rank setting for root cause",
@@ -617,6 +717,9 @@ func (r *projectResource) Schema(_ context.Context, _ resource.SchemaRequest, re
 				Description: "Alert thresholds for rare events",
 				Optional:    true,
 				Computed:    true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
 			},
 			"rare_number_limit": schema.Int64Attribute{
 				Description: "Limit for rare numbers",
@@ -632,6 +735,9 @@ func (r *projectResource) Schema(_ context.Context, _ resource.SchemaRequest, re
 				Description: "Sensitivity for similarity detection",
 				Optional:    true,
 				Computed:    true,
+				Validators: []validator.String{
+					numericStringBetween(0, 1),
+				},
 			},
 			"training_filter": schema.BoolAttribute{
 				Description: "Training filter flag",
@@ -682,41 +788,134 @@ func (r *projectResource) Schema(_ context.Context, _ resource.SchemaRequest, re
 				Description: "Base value setting configuration (JSON)",
 				Optional:    true,
 				Computed:    true,
+				Validators: []validator.String{
+					jsonString(jsonObject),
+				},
 			},
 			"cdf_setting": schema.StringAttribute{
 				Description: "CDF setting configuration (JSON)",
 				Optional:    true,
 				Computed:    true,
+				Validators: []validator.String{
+					jsonString(jsonArray),
+				},
 			},
-			"email_setting": schema.StringAttribute{
-				Description: "Email notification settings (JSON)",
+			"email_setting": schema.SingleNestedAttribute{
+				Description: "Email notification settings. Attributes left unset keep the value InsightFinder has.",
 				Optional:    true,
 				Computed:    true,
+				Attributes: map[string]schema.Attribute{
+					"only_send_with_rca": schema.BoolAttribute{
+						Description: "Only send incident emails once a root cause has been found.",
+						Optional:    true,
+						Computed:    true,
+					},
+					"enable_notification_aw": schema.BoolAttribute{
+						Description: "Send email notifications for anomalies at or above aw_severity_level.",
+						Optional:    true,
+						Computed:    true,
+					},
+					"enable_incident_prediction_email_alert": schema.BoolAttribute{
+						Description: "Send an email when an incident is predicted.",
+						Optional:    true,
+						Computed:    true,
+					},
+					"enable_incident_detection_email_alert": schema.BoolAttribute{
+						Description: "Send an email when an incident is detected.",
+						Optional:    true,
+						Computed:    true,
+					},
+					"enable_alerts_email": schema.BoolAttribute{
+						Description: "Send an email for alerts.",
+						Optional:    true,
+						Computed:    true,
+					},
+					"enable_root_cause_email_alert": schema.BoolAttribute{
+						Description: "Send an email when a root cause is found.",
+						Optional:    true,
+						Computed:    true,
+					},
+					"email_dampening_period": schema.Int64Attribute{
+						Description: "Minimum time between incident emails, in milliseconds.",
+						Optional:    true,
+						Computed:    true,
+						Validators: []validator.Int64{
+							int64validator.AtLeast(0),
+						},
+					},
+					"alerts_email_dampening_period": schema.Int64Attribute{
+						Description: "Minimum time between alert emails, in milliseconds.",
+						Optional:    true,
+						Computed:    true,
+						Validators: []validator.Int64{
+							int64validator.AtLeast(0),
+						},
+					},
+					"prediction_email_dampening_period": schema.Int64Attribute{
+						Description: "Minimum time between prediction emails, in milliseconds.",
+						Optional:    true,
+						Computed:    true,
+						Validators: []validator.Int64{
+							int64validator.AtLeast(0),
+						},
+					},
+					"aw_severity_level": schema.StringAttribute{
+						Description: "Lowest anomaly severity that triggers a notification: " + strings.Join(client.AwSeverityLevels, ", ") + ".",
+						Optional:    true,
+						Computed:    true,
+						Validators: []validator.String{
+							stringvalidator.OneOf(client.AwSeverityLevels...),
+						},
+					},
+				},
 			},
 			"instance_grouping_update": schema.StringAttribute{
 				Description: "Instance grouping update settings (JSON)",
 				Optional:    true,
 				Computed:    true,
+				Validators: []validator.String{
+					jsonString(jsonObject),
+				},
 			},
-			"llm_evaluation_setting": schema.StringAttribute{
-				Description: "LLM evaluation settings (JSON)",
+			"llm_evaluation_setting": schema.SingleNestedAttribute{
+				Description: "Evaluations run on the prompts and responses of an LLM project, one switch per evaluation. Attributes left unset keep the value InsightFinder has; evaluations are off on a new project.",
 				Optional:    true,
 				Computed:    true,
+				Attributes:  llmEvaluationSettingAttributes(),
 			},
 			"log_to_log_setting_list": schema.StringAttribute{
 				Description: "List of log to log settings (JSON)",
 				Optional:    true,
 				Computed:    true,
+				Validators: []validator.String{
+					jsonString(jsonArray),
+				},
 			},
 			"webhook_header_list": schema.StringAttribute{
-				Description: "List of webhook headers (JSON)",
+				Description: "List of webhook headers (JSON). Sensitive, as headers usually carry credentials; the insightfinder_project_webhook resource manages them as a typed map.",
 				Optional:    true,
 				Computed:    true,
+				Sensitive:   true,
+				Validators: []validator.String{
+					jsonString(jsonArrayOfObjects),
+				},
 			},
 			"shared_usernames": schema.StringAttribute{
 				Description: "List of shared usernames (JSON)",
 				Optional:    true,
 				Computed:    true,
+				Validators: []validator.String{
+					jsonString(jsonArrayOfStrings),
+				},
+			},
+			"extra_settings": schema.StringAttribute{
+				Description: "Additional watch-tower settings as a JSON object, merged into the settings sent to the API. " +
+					"Use it for settings without a dedicated attribute; keys managed by other attributes are rejected. " +
+					"Only the configured keys are refreshed from the API.",
+				Optional: true,
+				Validators: []validator.String{
+					jsonString(jsonObject),
+				},
 			},
 			"log_label_settings": schema.ListNestedAttribute{
 				Description: "List of log label settings for the project. Each setting is applied individually via API.",
@@ -725,17 +924,32 @@ func (r *projectResource) Schema(_ context.Context, _ resource.SchemaRequest, re
 				NestedObject: schema.NestedAttributeObject{
 					Attributes: map[string]schema.Attribute{
 						"label_type": schema.StringAttribute{
-							Description: "Type of log label (whitelist, blacklist, patternName, etc.)",
-							Required:    true,
+							Description:         "Type of log label: " + strings.Join(client.LabelTypeNames(), ", ") + ".",
+							MarkdownDescription: labelTypeMarkdownDescription(),
+							Required:            true,
+							Validators: []validator.String{
+								stringvalidator.OneOf(client.LabelTypeNames()...),
+							},
 						},
 						"log_label_string": schema.StringAttribute{
-							Description: "The log label value/pattern",
+							Description: "JSON array of the labels, as produced by jsonencode(). Depending on label_type the entries are rule objects, strings or regular expressions.",
 							Required:    true,
+							Validators: []validator.String{
+								logLabelString(),
+							},
 						},
 					},
 				},
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
+		},
 	}
 }
 
@@ -745,11 +959,11 @@ func (r *projectResource) Configure(_ context.Context, req resource.ConfigureReq
 		return
 	}
 
-	client, ok := req.ProviderData.(*client.Client)
+	client, ok := req.ProviderData.(client.InsightFinderAPI)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected client.InsightFinderAPI, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
@@ -757,360 +971,681 @@ func (r *projectResource) Configure(_ context.Context, req resource.ConfigureReq
 	r.client = client
 }
 
-// populateSettings converts the Terraform plan/state into a settings map for API calls
-func populateSettings(plan *projectResourceModel) map[string]interface{} {
-	// Helper function to parse JSON fields
-	parseJSONField := func(jsonStr string) interface{} {
-		if jsonStr == "" {
-			return nil
+// populateSettings converts the Terraform plan/state into a settings map for
+// API calls. Every known, non-null attribute is included, so explicitly
+// configured false, 0 and "" values reach the API; null and unknown
+// attributes are left out and keep their server-side values. JSON attributes
+// that do not hold the expected shape are reported as errors; validation
+// catches them at plan time unless they were unknown then.
+func populateSettings(plan *projectResourceModel) (map[string]interface{}, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	settings := make(map[string]interface{})
+
+	if v, ok := settingValue(&plan.ProjectName); ok {
+		settings["projectName"] = v
+	}
+	for key, field := range projectScalarSettings(plan) {
+		if v, ok := settingValue(field); ok {
+			settings[key] = v
 		}
-		var result interface{}
-		if err := json.Unmarshal([]byte(jsonStr), &result); err != nil {
-			return jsonStr
-		}
-		return result
 	}
 
-	// Build ProjectSettings struct from the plan
-	projectSettings := client.ProjectSettings{}
-
-	// Populate only non-null fields to match the struct
-	if !plan.ProjectName.IsNull() {
-		projectSettings.ProjectName = plan.ProjectName.ValueString()
-	}
-	if !plan.ProjectDisplayName.IsNull() {
-		projectSettings.ProjectDisplayName = plan.ProjectDisplayName.ValueString()
-	}
-	if !plan.CValue.IsNull() {
-		projectSettings.CValue = int(plan.CValue.ValueInt64())
-	}
-	if !plan.PValue.IsNull() {
-		projectSettings.PValue = plan.PValue.ValueFloat64()
-	}
-	if !plan.ProjectTimeZone.IsNull() {
-		projectSettings.ProjectTimeZone = plan.ProjectTimeZone.ValueString()
-	}
-	if !plan.SamplingInterval.IsNull() {
-		projectSettings.SamplingInterval = int(plan.SamplingInterval.ValueInt64())
-	}
-	if !plan.MinValidModelSpan.IsNull() {
-		projectSettings.MinValidModelSpan = int(plan.MinValidModelSpan.ValueInt64())
-	}
-	if !plan.MaxWebHookRequestSize.IsNull() {
-		projectSettings.MaxWebHookRequestSize = int(plan.MaxWebHookRequestSize.ValueInt64())
-	}
-	if !plan.WebhookUrl.IsNull() {
-		projectSettings.WebhookURL = plan.WebhookUrl.ValueString()
-	}
-	if !plan.WebhookTypeSetStr.IsNull() {
-		projectSettings.WebhookTypeSetStr = plan.WebhookTypeSetStr.ValueString()
-	}
-	if !plan.WebhookBlackListSetStr.IsNull() {
-		projectSettings.WebhookBlackListSetStr = plan.WebhookBlackListSetStr.ValueString()
-	}
-	if !plan.WebhookCriticalKeywordSetStr.IsNull() {
-		projectSettings.WebhookCriticalKeywordSetStr = plan.WebhookCriticalKeywordSetStr.ValueString()
-	}
-	if !plan.WebhookAlertDampening.IsNull() {
-		projectSettings.WebhookAlertDampening = int(plan.WebhookAlertDampening.ValueInt64())
-	}
-	if !plan.Proxy.IsNull() {
-		projectSettings.Proxy = plan.Proxy.ValueString()
-	}
-	if !plan.RetentionTime.IsNull() {
-		projectSettings.RetentionTime = int(plan.RetentionTime.ValueInt64())
-	}
-	if !plan.UBLRetentionTime.IsNull() {
-		projectSettings.UBLRetentionTime = int(plan.UBLRetentionTime.ValueInt64())
-	}
-	if !plan.TrainingFilter.IsNull() {
-		projectSettings.TrainingFilter = plan.TrainingFilter.ValueBool()
-	}
-	if !plan.MultiHopSearchLimit.IsNull() {
-		projectSettings.MultiHopSearchLimit = plan.MultiHopSearchLimit.ValueString()
-	}
-	if !plan.EnableNewAlertEmail.IsNull() {
-		projectSettings.EnableNewAlertEmail = plan.EnableNewAlertEmail.ValueBool()
-	}
-	if !plan.LargeProject.IsNull() {
-		projectSettings.LargeProject = plan.LargeProject.ValueBool()
-	}
-	if !plan.NewPatternRange.IsNull() {
-		projectSettings.NewPatternRange = int(plan.NewPatternRange.ValueInt64())
-	}
-	if !plan.EnableAnomalyScoreEscalation.IsNull() {
-		projectSettings.EnableAnomalyScoreEscalation = plan.EnableAnomalyScoreEscalation.ValueBool()
-	}
-	if !plan.EscalationAnomalyScoreThreshold.IsNull() {
-		projectSettings.EscalationAnomalyScoreThreshold = plan.EscalationAnomalyScoreThreshold.ValueString()
-	}
-	if !plan.IgnoreAnomalyScoreThreshold.IsNull() {
-		projectSettings.IgnoreAnomalyScoreThreshold = plan.IgnoreAnomalyScoreThreshold.ValueString()
+	// Complex JSON fields are sent as parsed JSON
+	for key, setting := range projectJSONSettings(plan) {
+		if setting.Field.IsNull() || setting.Field.IsUnknown() || setting.Field.ValueString() == "" {
+			continue
+		}
+		parsed, err := decodeJSONShape(setting.Field.ValueString(), setting.Shape)
+		if err != nil {
+			diags.AddAttributeError(
+				path.Root(setting.Name),
+				"Invalid JSON Value",
+				fmt.Sprintf("Attribute %s %s.", setting.Name, err),
+			)
+			continue
+		}
+		settings[key] = parsed
 	}
-	if !plan.EnableStreamDetection.IsNull() {
-		projectSettings.EnableStreamDetection = plan.EnableStreamDetection.ValueBool()
+	for key, setting := range projectObjectSettings(plan) {
+		if v, ok := objectSettingValue(setting); ok {
+			settings[key] = v
+		}
 	}
 
-	// Log-specific fields
-	if !plan.DailyModelSpan.IsNull() {
-		projectSettings.DailyModelSpan = int(plan.DailyModelSpan.ValueInt64())
-	}
-	if !plan.KeywordFeatureNumber.IsNull() {
-		projectSettings.KeywordFeatureNumber = int(plan.KeywordFeatureNumber.ValueInt64())
-	}
-	if !plan.MaxLogModelSize.IsNull() {
-		projectSettings.MaxLogModelSize = int(plan.MaxLogModelSize.ValueInt64())
-	}
-	if !plan.ModelKeywordSetting.IsNull() {
-		projectSettings.ModelKeywordSetting = int(plan.ModelKeywordSetting.ValueInt64())
-	}
-	if !plan.NlpFlag.IsNull() {
-		projectSettings.NlpFlag = plan.NlpFlag.ValueBool()
-	}
-	if !plan.ProjectModelFlag.IsNull() {
-		projectSettings.ProjectModelFlag = plan.ProjectModelFlag.ValueBool()
-	}
-	if !plan.MaximumThreads.IsNull() {
-		projectSettings.MaximumThreads = int(plan.MaximumThreads.ValueInt64())
-	}
-	if !plan.LogDetectionMinCount.IsNull() {
-		projectSettings.LogDetectionMinCount = int(plan.LogDetectionMinCount.ValueInt64())
-	}
-	if !plan.LogDetectionSize.IsNull() {
-		projectSettings.LogDetectionSize = int(plan.LogDetectionSize.ValueInt64())
-	}
-	if !plan.MaximumDetectionWaitTime.IsNull() {
-		projectSettings.MaximumDetectionWaitTime = int(plan.MaximumDetectionWaitTime.ValueInt64())
-	}
-	if !plan.KeywordSetting.IsNull() {
-		projectSettings.KeywordSetting = int(plan.KeywordSetting.ValueInt64())
-	}
-	if !plan.LogPatternLimitLevel.IsNull() {
-		projectSettings.LogPatternLimitLevel = int(plan.LogPatternLimitLevel.ValueInt64())
-	}
-	if !plan.NormalEventCausalFlag.IsNull() {
-		projectSettings.NormalEventCausalFlag = plan.NormalEventCausalFlag.ValueBool()
-	}
-	if !plan.SimilaritySensitivity.IsNull() {
-		projectSettings.SimilaritySensitivity = plan.SimilaritySensitivity.ValueString()
-	}
-	if !plan.CollectAllRareEventsFlag.IsNull() {
-		projectSettings.CollectAllRareEventsFlag = plan.CollectAllRareEventsFlag.ValueBool()
-	}
-	if !plan.RareEventAlertThresholds.IsNull() {
-		projectSettings.RareEventAlertThresholds = int(plan.RareEventAlertThresholds.ValueInt64())
-	}
-	if !plan.LogAnomalyEventBaseScore.IsNull() {
-		projectSettings.LogAnomalyEventBaseScore = plan.LogAnomalyEventBaseScore.ValueString()
-	}
-	if !plan.RareNumberLimit.IsNull() {
-		projectSettings.RareNumberLimit = int(plan.RareNumberLimit.ValueInt64())
-	}
-	if !plan.WhitelistNumberLimit.IsNull() {
-		projectSettings.WhitelistNumberLimit = int(plan.WhitelistNumberLimit.ValueInt64())
-	}
-	if !plan.NewPatternNumberLimit.IsNull() {
-		projectSettings.NewPatternNumberLimit = int(plan.NewPatternNumberLimit.ValueInt64())
-	}
-	if !plan.HotNumberLimit.IsNull() {
-		projectSettings.HotNumberLimit = int(plan.HotNumberLimit.ValueInt64())
-	}
-	if !plan.ColdNumberLimit.IsNull() {
-		projectSettings.ColdNumberLimit = int(plan.ColdNumberLimit.ValueInt64())
-	}
-	if !plan.RareAnomalyType.IsNull() {
-		projectSettings.RareAnomalyType = int(plan.RareAnomalyType.ValueInt64())
-	}
-	if !plan.HotEventThreshold.IsNull() {
-		projectSettings.HotEventThreshold = int(plan.HotEventThreshold.ValueInt64())
-	}
-	if !plan.ColdEventThreshold.IsNull() {
-		projectSettings.ColdEventThreshold = int(plan.ColdEventThreshold.ValueInt64())
-	}
-	if !plan.DisableLogCompressEvent.IsNull() {
-		projectSettings.DisableLogCompressEvent = plan.DisableLogCompressEvent.ValueBool()
-	}
-	if !plan.EnableHotEvent.IsNull() {
-		projectSettings.EnableHotEvent = plan.EnableHotEvent.ValueBool()
-	}
-	if !plan.HotEventCalmDownPeriod.IsNull() {
-		projectSettings.HotEventCalmDownPeriod = int(plan.HotEventCalmDownPeriod.ValueInt64())
-	}
-	if !plan.InstanceDownEnable.IsNull() {
-		projectSettings.InstanceDownEnable = plan.InstanceDownEnable.ValueBool()
-	}
-	if !plan.AnomalySamplingInterval.IsNull() {
-		projectSettings.AnomalySamplingInterval = int(plan.AnomalySamplingInterval.ValueInt64())
-	}
-	if !plan.HotEventDetectionMode.IsNull() {
-		projectSettings.HotEventDetectionMode = int(plan.HotEventDetectionMode.ValueInt64())
+	// Extra settings never override a dedicated attribute; ValidateConfig
+	// rejects such keys before apply
+	extra, err := parseExtraSettings(plan.ExtraSettings)
+	if err != nil {
+		diags.AddAttributeError(path.Root("extra_settings"), "Invalid Extra Settings", err.Error())
 	}
-	if !plan.AnomalyDetectionMode.IsNull() {
-		projectSettings.AnomalyDetectionMode = int(plan.AnomalyDetectionMode.ValueInt64())
+	for key, value := range extra {
+		if _, exists := settings[key]; !exists {
+			settings[key] = value
+		}
 	}
-	if !plan.PrettyJsonConvertorFlag.IsNull() {
-		projectSettings.PrettyJSONConvertorFlag = plan.PrettyJsonConvertorFlag.ValueBool()
+
+	return settings, diags
+}
+
+// flattenProjectSettings sets every setting attribute of m from the
+// watch-tower settings returned by GetProject. Settings the API does not
+// report become null. Numbers, booleans and JSON are normalized so that
+// equivalent values do not show up as a diff.
+func flattenProjectSettings(m *projectResourceModel, settings map[string]interface{}) {
+	for key, field := range projectScalarSettings(m) {
+		value := settings[key]
+		switch f := field.(type) {
+		case *types.String:
+			*f = settingString(value)
+		case *types.Int64:
+			*f = settingInt64(value)
+		case *types.Float64:
+			*f = settingFloat64(value)
+		case *types.Bool:
+			*f = settingBool(value)
+		}
 	}
-	if !plan.ZoneNameKey.IsNull() {
-		projectSettings.ZoneNameKey = plan.ZoneNameKey.ValueString()
+	for key, setting := range projectJSONSettings(m) {
+		*setting.Field = settingJSON(settings[key])
 	}
-	if !plan.MultiLineFlag.IsNull() {
-		projectSettings.MultiLineFlag = plan.MultiLineFlag.ValueBool()
+	for key, setting := range projectObjectSettings(m) {
+		*setting.Field = settingObject(setting, settings[key])
 	}
-	if !plan.FeatureOutlierSensitivity.IsNull() {
-		projectSettings.FeatureOutlierSensitivity = plan.FeatureOutlierSensitivity.ValueString()
+	m.ExtraSettings = refreshExtraSettings(m.ExtraSettings, settings)
+}
+
+// preserveConfiguredSettings copies every setting attribute configured in
+// config into m. Create and Update use it after reading the project back so
+// that the new state agrees with the plan even where the API reformats a
+// value; the next Read reports any real difference.
+func preserveConfiguredSettings(m, config *projectResourceModel) {
+	configured := projectScalarSettings(config)
+	for key, field := range projectScalarSettings(m) {
+		switch f := field.(type) {
+		case *types.String:
+			if c := configured[key].(*types.String); !c.IsNull() && !c.IsUnknown() {
+				*f = *c
+			}
+		case *types.Int64:
+			if c := configured[key].(*types.Int64); !c.IsNull() && !c.IsUnknown() {
+				*f = *c
+			}
+		case *types.Float64:
+			if c := configured[key].(*types.Float64); !c.IsNull() && !c.IsUnknown() {
+				*f = *c
+			}
+		case *types.Bool:
+			if c := configured[key].(*types.Bool); !c.IsNull() && !c.IsUnknown() {
+				*f = *c
+			}
+		}
 	}
-	if !plan.DisableModelKeywordStatsCollection.IsNull() {
-		projectSettings.DisableModelKeywordStatsCollection = plan.DisableModelKeywordStatsCollection.ValueBool()
+
+	configuredJSON := projectJSONSettings(config)
+	for key, setting := range projectJSONSettings(m) {
+		if c := configuredJSON[key].Field; !c.IsNull() && !c.IsUnknown() {
+			*setting.Field = *c
+		}
 	}
-	if !plan.InstanceConvertFlag.IsNull() {
-		projectSettings.InstanceConvertFlag = plan.InstanceConvertFlag.ValueBool()
+	configuredObjects := projectObjectSettings(config)
+	for key, setting := range projectObjectSettings(m) {
+		preserveConfiguredObject(setting, *configuredObjects[key].Field)
 	}
-	if !plan.NewAlertFlag.IsNull() {
-		projectSettings.NewAlertFlag = plan.NewAlertFlag.ValueBool()
+	if !config.ExtraSettings.IsNull() && !config.ExtraSettings.IsUnknown() {
+		m.ExtraSettings = config.ExtraSettings
 	}
-	if !plan.IsGroupingByInstance.IsNull() {
-		projectSettings.IsGroupingByInstance = plan.IsGroupingByInstance.ValueBool()
+}
+
+// projectScalarSettings maps watch-tower setting keys to the fields of m
+// holding the scalar attributes that manage them. Each value is a
+// *types.String, *types.Int64, *types.Float64 or *types.Bool.
+func projectScalarSettings(m *projectResourceModel) map[string]interface{} {
+	return map[string]interface{}{
+		"projectDisplayName":              &m.ProjectDisplayName,
+		"cValue":                          &m.CValue,
+		"pValue":                          &m.PValue,
+		"projectTimeZone":                 &m.ProjectTimeZone,
+		"samplingInterval":                &m.SamplingInterval,
+		"minValidModelSpan":               &m.MinValidModelSpan,
+		"maxWebHookRequestSize":           &m.MaxWebHookRequestSize,
+		"webhookUrl":                      &m.WebhookUrl,
+		"webhookTypeSetStr":               &m.WebhookTypeSetStr,
+		"webhookBlackListSetStr":          &m.WebhookBlackListSetStr,
+		"webhookCriticalKeywordSetStr":    &m.WebhookCriticalKeywordSetStr,
+		"webhookAlertDampening":           &m.WebhookAlertDampening,
+		"proxy":                           &m.Proxy,
+		"retentionTime":                   &m.RetentionTime,
+		"UBLRetentionTime":                &m.UBLRetentionTime,
+		"trainingFilter":                  &m.TrainingFilter,
+		"multiHopSearchLimit":             &m.MultiHopSearchLimit,
+		"enableNewAlertEmail":             &m.EnableNewAlertEmail,
+		"largeProject":                    &m.LargeProject,
+		"newPatternRange":                 &m.NewPatternRange,
+		"enableAnomalyScoreEscalation":    &m.EnableAnomalyScoreEscalation,
+		"escalationAnomalyScoreThreshold": &m.EscalationAnomalyScoreThreshold,
+		"ignoreAnomalyScoreThreshold":     &m.IgnoreAnomalyScoreThreshold,
+		"enableStreamDetection":           &m.EnableStreamDetection,
+
+		// Log-specific fields
+		"dailyModelSpan":                     &m.DailyModelSpan,
+		"keywordFeatureNumber":               &m.KeywordFeatureNumber,
+		"maxLogModelSize":                    &m.MaxLogModelSize,
+		"modelKeywordSetting":                &m.ModelKeywordSetting,
+		"nlpFlag":                            &m.NlpFlag,
+		"projectModelFlag":                   &m.ProjectModelFlag,
+		"maximumThreads":                     &m.MaximumThreads,
+		"logDetectionMinCount":               &m.LogDetectionMinCount,
+		"logDetectionSize":                   &m.LogDetectionSize,
+		"maximumDetectionWaitTime":           &m.MaximumDetectionWaitTime,
+		"keywordSetting":                     &m.KeywordSetting,
+		"logPatternLimitLevel":               &m.LogPatternLimitLevel,
+		"normalEventCausalFlag":              &m.NormalEventCausalFlag,
+		"similaritySensitivity":              &m.SimilaritySensitivity,
+		"collectAllRareEventsFlag":           &m.CollectAllRareEventsFlag,
+		"rareEventAlertThresholds":           &m.RareEventAlertThresholds,
+		"logAnomalyEventBaseScore":           &m.LogAnomalyEventBaseScore,
+		"rareNumberLimit":                    &m.RareNumberLimit,
+		"whitelistNumberLimit":               &m.WhitelistNumberLimit,
+		"newPatternNumberLimit":              &m.NewPatternNumberLimit,
+		"hotNumberLimit":                     &m.HotNumberLimit,
+		"coldNumberLimit":                    &m.ColdNumberLimit,
+		"rareAnomalyType":                    &m.RareAnomalyType,
+		"hotEventThreshold":                  &m.HotEventThreshold,
+		"coldEventThreshold":                 &m.ColdEventThreshold,
+		"disableLogCompressEvent":            &m.DisableLogCompressEvent,
+		"enableHotEvent":                     &m.EnableHotEvent,
+		"hotEventCalmDownPeriod":             &m.HotEventCalmDownPeriod,
+		"instanceDownEnable":                 &m.InstanceDownEnable,
+		"anomalySamplingInterval":            &m.AnomalySamplingInterval,
+		"hotEventDetectionMode":              &m.HotEventDetectionMode,
+		"anomalyDetectionMode":               &m.AnomalyDetectionMode,
+		"prettyJsonConvertorFlag":            &m.PrettyJsonConvertorFlag,
+		"zoneNameKey":                        &m.ZoneNameKey,
+		"multiLineFlag":                      &m.MultiLineFlag,
+		"featureOutlierSensitivity":          &m.FeatureOutlierSensitivity,
+		"disableModelKeywordStatsCollection": &m.DisableModelKeywordStatsCollection,
+		"instanceConvertFlag":                &m.InstanceConvertFlag,
+		"newAlertFlag":                       &m.NewAlertFlag,
+		"isGroupingByInstance":               &m.IsGroupingByInstance,
+		"featureOutlierThreshold":            &m.FeatureOutlierThreshold,
+		"isTracePrompt":                      &m.IsTracePrompt,
+		"isEdgeBrain":                        &m.IsEdgeBrain,
+
+		// Incident prediction and RCA fields
+		"incidentPredictionWindow":             &m.IncidentPredictionWindow,
+		"minIncidentPredictionWindow":          &m.MinIncidentPredictionWindow,
+		"incidentRelationSearchWindow":         &m.IncidentRelationSearchWindow,
+		"incidentPredictionEventLimit":         &m.IncidentPredictionEventLimit,
+		"rootCauseCountThreshold":              &m.RootCauseCountThreshold,
+		"rootCauseProbabilityThreshold":        &m.RootCauseProbabilityThreshold,
+		"rootCauseLogMessageSearchRange":       &m.RootCauseLogMessageSearchRange,
+		"causalPredictionSetting":              &m.CausalPredictionSetting,
+		"causalMinDelay":                       &m.CausalMinDelay,
+		"rootCauseRankSetting":                 &m.RootCauseRankSetting,
+		"maximumRootCauseResultSize":           &m.MaximumRootCauseResultSize,
+		"multiHopSearchLevel":                  &m.MultiHopSearchLevel,
+		"avgPerIncidentDowntimeCost":           &m.AvgPerIncidentDowntimeCost,
+		"predictionRuleActiveCondition":        &m.PredictionRuleActiveCondition,
+		"predictionRuleFalsePositiveThreshold": &m.PredictionRuleFalsePositiveThreshold,
+		"predictionRuleActiveThreshold":        &m.PredictionRuleActiveThreshold,
+		"predictionRuleInactiveThreshold":      &m.PredictionRuleInactiveThreshold,
+		"predictionProbabilityThreshold":       &m.PredictionProbabilityThreshold,
+		"alertHourlyCost":                      &m.AlertHourlyCost,
+		"alertAverageTime":                     &m.AlertAverageTime,
+		"ignoreInstanceForKB":                  &m.IgnoreInstanceForKB,
+		"showInstanceDown":                     &m.ShowInstanceDown,
+		"predictionCountThreshold":             &m.PredictionCountThreshold,
 	}
-	if !plan.FeatureOutlierThreshold.IsNull() {
-		projectSettings.FeatureOutlierThreshold = plan.FeatureOutlierThreshold.ValueFloat64()
+}
+
+// projectJSONSetting describes a watch-tower setting managed through a
+// JSON-encoded string attribute.
+type projectJSONSetting struct {
+	// Name is the name of the attribute.
+	Name string
+	// Field holds the attribute.
+	Field *types.String
+	// Shape is the JSON value the attribute must hold.
+	Shape jsonShape
+}
+
+// projectJSONSettings maps watch-tower setting keys to the JSON-encoded
+// attributes of m that manage them.
+func projectJSONSettings(m *projectResourceModel) map[string]projectJSONSetting {
+	return map[string]projectJSONSetting{
+		"baseValueSetting":       {Name: "base_value_setting", Field: &m.BaseValueSetting, Shape: jsonObject},
+		"cdfSetting":             {Name: "cdf_setting", Field: &m.CdfSetting, Shape: jsonArray},
+		"instanceGroupingUpdate": {Name: "instance_grouping_update", Field: &m.InstanceGroupingUpdate, Shape: jsonObject},
+		"logToLogSettingList":    {Name: "log_to_log_setting_list", Field: &m.LogToLogSettingList, Shape: jsonArray},
+		"webhookHeaderList":      {Name: "webhook_header_list", Field: &m.WebhookHeaderList, Shape: jsonArrayOfObjects},
+		"sharedUsernames":        {Name: "shared_usernames", Field: &m.SharedUsernames, Shape: jsonArrayOfStrings},
 	}
-	if !plan.IsTracePrompt.IsNull() {
-		projectSettings.IsTracePrompt = plan.IsTracePrompt.ValueBool()
+}
+
+// projectObjectSetting describes a watch-tower setting holding a JSON object
+// that is managed through a nested attribute.
+type projectObjectSetting struct {
+	// Name is the name of the nested attribute.
+	Name string
+	// Field holds the nested attribute.
+	Field *types.Object
+	// Keys maps the names of the attributes in the object to the keys of
+	// the setting.
+	Keys map[string]string
+	// AttrTypes are the types of the attributes in the object.
+	AttrTypes map[string]attr.Type
+}
+
+// projectObjectSettings maps watch-tower setting keys to the nested
+// attributes of m that manage them.
+func projectObjectSettings(m *projectResourceModel) map[string]projectObjectSetting {
+	return map[string]projectObjectSetting{
+		"emailSetting": {
+			Name:      "email_setting",
+			Field:     &m.EmailSetting,
+			Keys:      emailSettingKeys,
+			AttrTypes: emailSettingAttrTypes,
+		},
+		"llmEvaluationSetting": {
+			Name:      "llm_evaluation_setting",
+			Field:     &m.LlmEvaluationSetting,
+			Keys:      llmEvaluationSettingKeys(),
+			AttrTypes: llmEvaluationSettingAttrTypes(),
+		},
 	}
-	if !plan.IsEdgeBrain.IsNull() {
-		projectSettings.IsEdgeBrain = plan.IsEdgeBrain.ValueBool()
+}
+
+// emailSettingKeys maps the email_setting attributes to the keys of the
+// emailSetting setting.
+var emailSettingKeys = map[string]string{
+	"only_send_with_rca":                     "onlySendWithRCA",
+	"enable_notification_aw":                 "enableNotificationAW",
+	"enable_incident_prediction_email_alert": "enableIncidentPredictionEmailAlert",
+	"enable_incident_detection_email_alert":  "enableIncidentDetectionEmailAlert",
+	"enable_alerts_email":                    "enableAlertsEmail",
+	"enable_root_cause_email_alert":          "enableRootCauseEmailAlert",
+	"email_dampening_period":                 "emailDampeningPeriod",
+	"alerts_email_dampening_period":          "alertsEmailDampeningPeriod",
+	"prediction_email_dampening_period":      "predictionEmailDampeningPeriod",
+	"aw_severity_level":                      "awSeverityLevel",
+}
+
+// emailSettingAttrTypes are the types of the email_setting attributes.
+var emailSettingAttrTypes = map[string]attr.Type{
+	"only_send_with_rca":                     types.BoolType,
+	"enable_notification_aw":                 types.BoolType,
+	"enable_incident_prediction_email_alert": types.BoolType,
+	"enable_incident_detection_email_alert":  types.BoolType,
+	"enable_alerts_email":                    types.BoolType,
+	"enable_root_cause_email_alert":          types.BoolType,
+	"email_dampening_period":                 types.Int64Type,
+	"alerts_email_dampening_period":          types.Int64Type,
+	"prediction_email_dampening_period":      types.Int64Type,
+	"aw_severity_level":                      types.StringType,
+}
+
+// llmEvaluations lists the llm_evaluation_setting attributes with the
+// llmEvaluationSetting keys they manage.
+var llmEvaluations = []struct {
+	Name        string
+	Key         string
+	Description string
+}{
+	{"hallucination", "isHallucinationEvaluation", "Detect hallucinated content in responses."},
+	{"answer_relevance", "isAnswerRelevantEvaluation", "Check that responses are relevant to the prompt."},
+	{"logic_consistency", "isLogicConsistencyEvaluation", "Detect logically inconsistent responses."},
+	{"factual_inaccuracy", "isFactualInaccuracyEvaluation", "Detect factually inaccurate responses."},
+	{"malicious_prompt", "isMaliciousPromptEvaluation", "Detect malicious prompts such as jailbreak and injection attempts."},
+	{"toxicity", "isToxicityEvaluation", "Detect toxic language."},
+	{"pii_phi_leakage", "isPiiPhiLeakageEvaluation", "Detect leaked personally identifiable (PII) and protected health (PHI) information."},
+	{"topic_guardrails", "isTopicGuardrailsEvaluation", "Detect conversations outside the allowed topics."},
+	{"tone_detection", "isToneDetectionEvaluation", "Detect an inappropriate tone."},
+	{"anomalous_outliers", "isAnomalousOutliersEvaluation", "Detect anomalous outlier prompts and responses."},
+	{"show_safety_template", "showSafetyTemplate", "Show the safety template in the InsightFinder UI."},
+	{"gender_bias", "isGenderBiasEvaluation", "Detect gender bias."},
+	{"racial_bias", "isRacialBiasEvaluation", "Detect racial bias."},
+	{"socioeconomic_bias", "isSocioeconomicBiasEvaluation", "Detect socioeconomic bias."},
+	{"cultural_bias", "isCulturalBiasEvaluation", "Detect cultural bias."},
+	{"religious_bias", "isReligiousBiasEvaluation", "Detect religious bias."},
+	{"political_bias", "isPoliticalBiasEvaluation", "Detect political bias."},
+	{"disability_bias", "isDisabilityBiasEvaluation", "Detect disability bias."},
+	{"age_bias", "isAgeBiasEvaluation", "Detect age bias."},
+}
+
+// llmEvaluationSettingAttributes returns the llm_evaluation_setting schema
+// attributes.
+func llmEvaluationSettingAttributes() map[string]schema.Attribute {
+	attributes := make(map[string]schema.Attribute, len(llmEvaluations))
+	for _, evaluation := range llmEvaluations {
+		attributes[evaluation.Name] = schema.BoolAttribute{
+			Description: evaluation.Description,
+			Optional:    true,
+			Computed:    true,
+		}
 	}
+	return attributes
+}
 
-	// Incident prediction and RCA fields
-	if !plan.IncidentPredictionWindow.IsNull() {
-		projectSettings.IncidentPredictionWindow = int(plan.IncidentPredictionWindow.ValueInt64())
+// llmEvaluationSettingKeys maps the llm_evaluation_setting attributes to the
+// keys of the llmEvaluationSetting setting.
+func llmEvaluationSettingKeys() map[string]string {
+	keys := make(map[string]string, len(llmEvaluations))
+	for _, evaluation := range llmEvaluations {
+		keys[evaluation.Name] = evaluation.Key
 	}
-	if !plan.MinIncidentPredictionWindow.IsNull() {
-		projectSettings.MinIncidentPredictionWindow = int(plan.MinIncidentPredictionWindow.ValueInt64())
+	return keys
+}
+
+// llmEvaluationSettingAttrTypes returns the types of the
+// llm_evaluation_setting attributes.
+func llmEvaluationSettingAttrTypes() map[string]attr.Type {
+	attrTypes := make(map[string]attr.Type, len(llmEvaluations))
+	for _, evaluation := range llmEvaluations {
+		attrTypes[evaluation.Name] = types.BoolType
 	}
-	if !plan.IncidentRelationSearchWindow.IsNull() {
-		projectSettings.IncidentRelationSearchWindow = int(plan.IncidentRelationSearchWindow.ValueInt64())
+	return attrTypes
+}
+
+// reservedExtraSettingKeys are setting keys extra_settings may not contain
+// besides those of the scalar and JSON attributes: the project name, which
+// identifies the project, and the log label operations driven by
+// log_label_settings.
+var reservedExtraSettingKeys = []string{
+	"projectName",
+	"logLabelSettingCreate",
+	"logLabelSettingRemove",
+}
+
+// parseExtraSettings decodes the extra_settings JSON object. Null, unknown
+// and empty values decode to nil.
+func parseExtraSettings(value types.String) (map[string]interface{}, error) {
+	if value.IsNull() || value.IsUnknown() || value.ValueString() == "" {
+		return nil, nil
 	}
-	if !plan.IncidentPredictionEventLimit.IsNull() {
-		projectSettings.IncidentPredictionEventLimit = int(plan.IncidentPredictionEventLimit.ValueInt64())
+
+	var extra map[string]interface{}
+	if err := json.Unmarshal([]byte(value.ValueString()), &extra); err != nil {
+		return nil, fmt.Errorf("extra_settings must be a JSON object: %w", err)
 	}
-	if !plan.RootCauseCountThreshold.IsNull() {
-		projectSettings.RootCauseCountThreshold = int(plan.RootCauseCountThreshold.ValueInt64())
+	if extra == nil {
+		return nil, fmt.Errorf("extra_settings must be a JSON object, got null")
 	}
-	if !plan.RootCauseProbabilityThreshold.IsNull() {
-		projectSettings.RootCauseProbabilityThreshold = plan.RootCauseProbabilityThreshold.ValueFloat64()
+	return extra, nil
+}
+
+// extraSettingConflicts returns the keys of extra that are managed by a
+// dedicated attribute, sorted.
+func extraSettingConflicts(extra map[string]interface{}) []string {
+	var empty projectResourceModel
+	managed := make(map[string]bool)
+	for key := range projectScalarSettings(&empty) {
+		managed[key] = true
 	}
-	if !plan.RootCauseLogMessageSearchRange.IsNull() {
-		projectSettings.RootCauseLogMessageSearchRange = int(plan.RootCauseLogMessageSearchRange.ValueInt64())
+	for key := range projectJSONSettings(&empty) {
+		managed[key] = true
 	}
-	if !plan.CausalPredictionSetting.IsNull() {
-		projectSettings.CausalPredictionSetting = int(plan.CausalPredictionSetting.ValueInt64())
+	for key := range projectObjectSettings(&empty) {
+		managed[key] = true
 	}
-	if !plan.CausalMinDelay.IsNull() {
-		projectSettings.CausalMinDelay = plan.CausalMinDelay.ValueString()
+	for _, key := range reservedExtraSettingKeys {
+		managed[key] = true
 	}
-	if !plan.RootCauseRankSetting.IsNull() {
-		projectSettings.RootCauseRankSetting = int(plan.RootCauseRankSetting.ValueInt64())
+
+	var conflicts []string
+	for key := range extra {
+		if managed[key] {
+			conflicts = append(conflicts, key)
+		}
 	}
-	if !plan.MaximumRootCauseResultSize.IsNull() {
-		projectSettings.MaximumRootCauseResultSize = int(plan.MaximumRootCauseResultSize.ValueInt64())
+	sort.Strings(conflicts)
+	return conflicts
+}
+
+// refreshExtraSettings returns extra_settings with the configured keys set
+// to the values reported by the API. Keys the API no longer reports are
+// dropped so the next plan puts them back.
+func refreshExtraSettings(current types.String, settings map[string]interface{}) types.String {
+	extra, err := parseExtraSettings(current)
+	if err != nil || extra == nil {
+		return current
 	}
-	if !plan.MultiHopSearchLevel.IsNull() {
-		projectSettings.MultiHopSearchLevel = int(plan.MultiHopSearchLevel.ValueInt64())
+
+	refreshed := make(map[string]interface{}, len(extra))
+	for key := range extra {
+		if value, ok := settings[key]; ok {
+			refreshed[key] = value
+		}
 	}
-	if !plan.AvgPerIncidentDowntimeCost.IsNull() {
-		projectSettings.AvgPerIncidentDowntimeCost = plan.AvgPerIncidentDowntimeCost.ValueFloat64()
+
+	// Compact, key-sorted output matches Terraform's jsonencode()
+	encoded, err := json.Marshal(refreshed)
+	if err != nil {
+		return current
 	}
-	if !plan.PredictionRuleActiveCondition.IsNull() {
-		projectSettings.PredictionRuleActiveCondition = int(plan.PredictionRuleActiveCondition.ValueInt64())
+	return types.StringValue(string(encoded))
+}
+
+// ValidateConfig rejects extra_settings that are not a JSON object or that
+// set keys managed by dedicated attributes.
+func (r *projectResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var extraSettings types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("extra_settings"), &extraSettings)...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
-	if !plan.PredictionRuleFalsePositiveThreshold.IsNull() {
-		projectSettings.PredictionRuleFalsePositiveThreshold = int(plan.PredictionRuleFalsePositiveThreshold.ValueInt64())
+
+	// The attribute's validator reports values that are not JSON objects
+	extra, err := parseExtraSettings(extraSettings)
+	if err != nil {
+		return
 	}
-	if !plan.PredictionRuleActiveThreshold.IsNull() {
-		projectSettings.PredictionRuleActiveThreshold = plan.PredictionRuleActiveThreshold.ValueFloat64()
+
+	for _, key := range extraSettingConflicts(extra) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("extra_settings"),
+			"Conflicting Extra Setting",
+			fmt.Sprintf("The setting %q is managed by a dedicated attribute of this resource. "+
+				"Remove it from extra_settings and set that attribute instead.", key),
+		)
 	}
-	if !plan.PredictionRuleInactiveThreshold.IsNull() {
-		projectSettings.PredictionRuleInactiveThreshold = plan.PredictionRuleInactiveThreshold.ValueFloat64()
+}
+
+// settingValue returns the Go value of a known, non-null scalar attribute
+// held in one of the fields returned by projectScalarSettings.
+func settingValue(field interface{}) (interface{}, bool) {
+	switch f := field.(type) {
+	case *types.String:
+		if !f.IsNull() && !f.IsUnknown() {
+			return f.ValueString(), true
+		}
+	case *types.Int64:
+		if !f.IsNull() && !f.IsUnknown() {
+			return f.ValueInt64(), true
+		}
+	case *types.Float64:
+		if !f.IsNull() && !f.IsUnknown() {
+			return f.ValueFloat64(), true
+		}
+	case *types.Bool:
+		if !f.IsNull() && !f.IsUnknown() {
+			return f.ValueBool(), true
+		}
 	}
-	if !plan.PredictionProbabilityThreshold.IsNull() {
-		projectSettings.PredictionProbabilityThreshold = plan.PredictionProbabilityThreshold.ValueFloat64()
+	return nil, false
+}
+
+// objectSettingValue returns the known attributes of a nested attribute
+// keyed by their setting keys, and whether there are any. Attributes left
+// unset are not included; the client merges the value into the project's
+// current object, so they keep their current values.
+func objectSettingValue(setting projectObjectSetting) (map[string]interface{}, bool) {
+	if setting.Field.IsNull() || setting.Field.IsUnknown() {
+		return nil, false
+	}
+
+	value := make(map[string]interface{})
+	for name, attribute := range setting.Field.Attributes() {
+		var v interface{}
+		var ok bool
+		switch a := attribute.(type) {
+		case types.String:
+			v, ok = settingValue(&a)
+		case types.Int64:
+			v, ok = settingValue(&a)
+		case types.Float64:
+			v, ok = settingValue(&a)
+		case types.Bool:
+			v, ok = settingValue(&a)
+		}
+		if ok {
+			value[setting.Keys[name]] = v
+		}
 	}
-	if !plan.AlertHourlyCost.IsNull() {
-		projectSettings.AlertHourlyCost = plan.AlertHourlyCost.ValueFloat64()
+	return value, len(value) > 0
+}
+
+// partialObjectSettings returns the keys of settings holding nested
+// attributes, which objectSettingValue leaves partial, sorted.
+func partialObjectSettings(settings map[string]interface{}) []string {
+	var keys []string
+	for key := range projectObjectSettings(&projectResourceModel{}) {
+		if _, ok := settings[key]; ok {
+			keys = append(keys, key)
+		}
 	}
-	if !plan.AlertAverageTime.IsNull() {
-		projectSettings.AlertAverageTime = int(plan.AlertAverageTime.ValueInt64())
+	sort.Strings(keys)
+	return keys
+}
+
+// settingObject converts a JSON object setting to the nested attribute
+// described by setting. Keys missing from the object become null attributes;
+// a setting that is not an object becomes a null object.
+func settingObject(setting projectObjectSetting, value interface{}) types.Object {
+	object, ok := value.(map[string]interface{})
+	if !ok {
+		return types.ObjectNull(setting.AttrTypes)
+	}
+
+	attributes := make(map[string]attr.Value, len(setting.Keys))
+	for name, key := range setting.Keys {
+		switch setting.AttrTypes[name] {
+		case types.BoolType:
+			attributes[name] = settingBool(object[key])
+		case types.Int64Type:
+			attributes[name] = settingInt64(object[key])
+		case types.Float64Type:
+			attributes[name] = settingFloat64(object[key])
+		default:
+			attributes[name] = settingString(object[key])
+		}
 	}
-	if !plan.IgnoreInstanceForKB.IsNull() {
-		projectSettings.IgnoreInstanceForKB = plan.IgnoreInstanceForKB.ValueBool()
+	return types.ObjectValueMust(setting.AttrTypes, attributes)
+}
+
+// preserveConfiguredObject sets the attributes configured in config over
+// those of the nested attribute described by setting.
+func preserveConfiguredObject(setting projectObjectSetting, config types.Object) {
+	if config.IsNull() || config.IsUnknown() {
+		return
 	}
-	if !plan.ShowInstanceDown.IsNull() {
-		projectSettings.ShowInstanceDown = plan.ShowInstanceDown.ValueBool()
+
+	current := setting.Field.Attributes()
+	if setting.Field.IsNull() || setting.Field.IsUnknown() {
+		current = settingObject(setting, map[string]interface{}{}).Attributes()
 	}
-	if !plan.PredictionCountThreshold.IsNull() {
-		projectSettings.PredictionCountThreshold = int(plan.PredictionCountThreshold.ValueInt64())
+	attributes := make(map[string]attr.Value, len(setting.AttrTypes))
+	for name := range setting.AttrTypes {
+		attributes[name] = current[name]
+		if c, ok := config.Attributes()[name]; ok && !c.IsNull() && !c.IsUnknown() {
+			attributes[name] = c
+		}
 	}
+	*setting.Field = types.ObjectValueMust(setting.AttrTypes, attributes)
+}
 
-	// Complex JSON fields - parse and assign to struct
-	if !plan.BaseValueSetting.IsNull() {
-		if parsed := parseJSONField(plan.BaseValueSetting.ValueString()); parsed != nil {
-			// Marshal to JSON and unmarshal to the struct field
-			if jsonBytes, err := json.Marshal(parsed); err == nil {
-				_ = json.Unmarshal(jsonBytes, &projectSettings.BaseValueSetting)
-			}
+// settingString converts an API setting to a string attribute. Numbers and
+// booleans are formatted, since some string settings come back unquoted.
+func settingString(value interface{}) types.String {
+	switch v := value.(type) {
+	case string:
+		return types.StringValue(v)
+	case float64:
+		return types.StringValue(strconv.FormatFloat(v, 'f', -1, 64))
+	case bool:
+		return types.StringValue(strconv.FormatBool(v))
+	}
+	return types.StringNull()
+}
+
+// settingInt64 converts an API setting to an integer attribute, accepting
+// JSON numbers and numeric strings.
+func settingInt64(value interface{}) types.Int64 {
+	switch v := value.(type) {
+	case float64:
+		return types.Int64Value(int64(v))
+	case int:
+		return types.Int64Value(int64(v))
+	case int64:
+		return types.Int64Value(v)
+	case string:
+		if i, err := strconv.ParseInt(strings.TrimSpace(v), 10, 64); err == nil {
+			return types.Int64Value(i)
 		}
-	}
-	if !plan.CdfSetting.IsNull() {
-		projectSettings.CdfSetting = parseJSONField(plan.CdfSetting.ValueString()).([]interface{})
-	}
-	if !plan.EmailSetting.IsNull() {
-		if parsed := parseJSONField(plan.EmailSetting.ValueString()); parsed != nil {
-			if jsonBytes, err := json.Marshal(parsed); err == nil {
-				_ = json.Unmarshal(jsonBytes, &projectSettings.EmailSetting)
-			}
+		if f, err := strconv.ParseFloat(strings.TrimSpace(v), 64); err == nil {
+			return types.Int64Value(int64(f))
 		}
 	}
-	if !plan.InstanceGroupingUpdate.IsNull() {
-		if parsed := parseJSONField(plan.InstanceGroupingUpdate.ValueString()); parsed != nil {
-			if jsonBytes, err := json.Marshal(parsed); err == nil {
-				_ = json.Unmarshal(jsonBytes, &projectSettings.InstanceGroupingUpdate)
-			}
+	return types.Int64Null()
+}
+
+// settingFloat64 converts an API setting to a float attribute, accepting
+// JSON numbers and numeric strings.
+func settingFloat64(value interface{}) types.Float64 {
+	switch v := value.(type) {
+	case float64:
+		return types.Float64Value(v)
+	case int:
+		return types.Float64Value(float64(v))
+	case int64:
+		return types.Float64Value(float64(v))
+	case string:
+		if f, err := strconv.ParseFloat(strings.TrimSpace(v), 64); err == nil {
+			return types.Float64Value(f)
 		}
 	}
-	if !plan.LlmEvaluationSetting.IsNull() {
-		if parsed := parseJSONField(plan.LlmEvaluationSetting.ValueString()); parsed != nil {
-			if jsonBytes, err := json.Marshal(parsed); err == nil {
-				_ = json.Unmarshal(jsonBytes, &projectSettings.LlmEvaluationSetting)
-			}
+	return types.Float64Null()
+}
+
+// settingBool converts an API setting to a boolean attribute, accepting
+// "true" and "false" strings.
+func settingBool(value interface{}) types.Bool {
+	switch v := value.(type) {
+	case bool:
+		return types.BoolValue(v)
+	case string:
+		if b, err := strconv.ParseBool(strings.TrimSpace(v)); err == nil {
+			return types.BoolValue(b)
 		}
 	}
-	if !plan.LogToLogSettingList.IsNull() {
-		projectSettings.LogToLogSettingList = parseJSONField(plan.LogToLogSettingList.ValueString()).([]interface{})
-	}
-	if !plan.WebhookHeaderList.IsNull() {
-		projectSettings.WebhookHeaderList = parseJSONField(plan.WebhookHeaderList.ValueString()).([]interface{})
-	}
-	if !plan.SharedUsernames.IsNull() {
-		projectSettings.SharedUsernames = parseJSONField(plan.SharedUsernames.ValueString()).([]interface{})
-	}
+	return types.BoolNull()
+}
 
-	// Convert struct to map[string]interface{} using JSON marshal/unmarshal
-	// This automatically excludes omitempty fields that are zero values
-	jsonBytes, err := json.Marshal(projectSettings)
-	if err != nil {
-		// Fallback to empty map if marshaling fails
-		return make(map[string]interface{})
+// settingJSON converts an API setting to a JSON string attribute in the
+// compact, key-sorted form jsonencode() produces. Settings the API returns
+// as JSON text are normalized the same way.
+func settingJSON(value interface{}) types.String {
+	switch v := value.(type) {
+	case nil:
+		return types.StringNull()
+	case string:
+		return types.StringValue(normalizeJSON(v))
 	}
 
-	var settings map[string]interface{}
-	if err := json.Unmarshal(jsonBytes, &settings); err != nil {
-		// Fallback to empty map if unmarshaling fails
-		return make(map[string]interface{})
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return types.StringNull()
 	}
-
-	return settings
+	return types.StringValue(string(encoded))
 }
 
 // Create creates the resource and sets the initial Terraform state.
@@ -1122,6 +1657,23 @@ func (r *projectResource) Create(ctx context.Context, req resource.CreateRequest
 		return
 	}
 
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	// Build the settings first, so that a value that cannot be sent fails
+	// the apply before the project exists
+	settings, diags := populateSettings(&plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	tflog.Info(ctx, "Creating project", map[string]any{"project_name": plan.ProjectName.ValueString()})
 
 	// Create the project via API
@@ -1138,11 +1690,32 @@ func (r *projectResource) Create(ctx context.Context, req resource.CreateRequest
 		PValue:              plan.PValue.ValueFloat64(),
 	}
 
-	err := r.client.CreateProject(projectConfig)
-	if err != nil {
+	// An adopted project existed before this apply, so failures past this
+	// point must not taint it: Terraform would destroy it on the next apply
+	adopted := false
+	err := r.client.CreateProject(ctx, projectConfig)
+	if errors.Is(err, client.ErrAlreadyExists) {
+		if !plan.AdoptExisting.ValueBool() {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("project_name"),
+				"Project already exists",
+				fmt.Sprintf("A project named %q already exists. To manage it with Terraform, import it instead:\n\n", plan.ProjectName.ValueString())+
+					fmt.Sprintf("  terraform import <resource address> %s\n\n", plan.ProjectName.ValueString())+
+					"or set adopt_existing = true to take it over and apply this configuration to it. "+
+					"If the name collision is unintended, choose a different project_name.",
+			)
+			return
+		}
+
+		tflog.Info(ctx, "Adopting existing project", map[string]any{"project_name": plan.ProjectName.ValueString()})
+		if !r.adoptProject(ctx, plan, resp) {
+			return
+		}
+		adopted = true
+	} else if err != nil {
 		resp.Diagnostics.AddError(
 			"Error creating project",
-			"Could not create project, unexpected error: "+err.Error(),
+			"Could not create project, unexpected error: "+apiErrorDetail(err),
 		)
 		return
 	}
@@ -1150,23 +1723,19 @@ func (r *projectResource) Create(ctx context.Context, req resource.CreateRequest
 	// Set the ID
 	plan.ID = plan.ProjectName
 
-	// Apply additional settings if any are provided
-	settings := populateSettings(&plan)
+	// Apply additional settings if any are provided. A failure is reported
+	// once the created project has been saved to state, so that Terraform
+	// tracks it instead of leaving it orphaned.
+	var settingsErr error
 	// Only update if we have settings beyond just the project name
 	if len(settings) > 0 {
 		tflog.Debug(ctx, "Applying additional project settings", map[string]any{"settings_count": len(settings)})
 		updateConfig := &client.ProjectConfig{
-			ProjectName: plan.ProjectName.ValueString(),
-			Settings:    settings,
-		}
-		err = r.client.UpdateProject(updateConfig)
-		if err != nil {
-			// Log the error but don't fail - project is created
-			tflog.Warn(ctx, "Could not apply all settings on creation", map[string]any{
-				"error": err.Error(),
-				"note":  "Settings can be applied on next terraform apply",
-			})
+			ProjectName:     plan.ProjectName.ValueString(),
+			Settings:        settings,
+			PartialSettings: partialObjectSettings(settings),
 		}
+		settingsErr = r.client.UpdateProject(ctx, updateConfig)
 	}
 
 	// Read back the project configuration after creation to populate computed fields
@@ -1179,515 +1748,61 @@ func (r *projectResource) Create(ctx context.Context, req resource.CreateRequest
 	}
 
 	tflog.Debug(ctx, "Reading project configuration after creation")
-	project, err := r.client.GetProject(plan.ProjectName.ValueString(), r.client.Username)
+	project, err := r.client.GetProject(ctx, plan.ProjectName.ValueString(), r.client.CustomerName())
 	if err != nil {
 		tflog.Warn(ctx, "Could not read project after creation", map[string]any{
 			"error": err.Error(),
 			"note":  "State may not reflect all API values",
 		})
 		// If we can't read from API, use the config as state
+		config.ID = config.ProjectName
+		copyLifecycleSettings(&config, &plan)
 		resp.State.Set(ctx, config)
+		if settingsErr != nil && adopted {
+			addAdoptedSettingsNotAppliedWarning(&resp.Diagnostics, plan.ProjectName.ValueString(), settingsErr, rejectedSettings(settings, nil))
+		} else if settingsErr != nil {
+			addSettingsNotAppliedError(&resp.Diagnostics, plan.ProjectName.ValueString(), settingsErr, rejectedSettings(settings, nil))
+		}
 		return
 	}
 
 	if project != nil {
 		// Start with config values
+		lifecycle := plan
 		plan = config
 		plan.ID = plan.ProjectName
+		copyLifecycleSettings(&plan, &lifecycle)
 
-		// Populate all fields from the API response (same logic as Read/Update)
-		settings := project.Settings
-		if settings == nil {
-			settings = make(map[string]interface{})
-		}
-
-		// Helper functions
-		getInt64 := func(key string) types.Int64 {
-			if val, ok := settings[key]; ok && val != nil {
-				switch v := val.(type) {
-				case float64:
-					return types.Int64Value(int64(v))
-				case int64:
-					return types.Int64Value(v)
-				case int:
-					return types.Int64Value(int64(v))
-				}
-			}
-			return types.Int64Null()
-		}
-
-		getFloat64 := func(key string) types.Float64 {
-			if val, ok := settings[key]; ok && val != nil {
-				switch v := val.(type) {
-				case float64:
-					return types.Float64Value(v)
-				case int64:
-					return types.Float64Value(float64(v))
-				case int:
-					return types.Float64Value(float64(v))
-				}
-			}
-			return types.Float64Null()
-		}
-
-		getString := func(key string) types.String {
-			if val, ok := settings[key]; ok && val != nil {
-				if str, ok := val.(string); ok {
-					return types.StringValue(str)
-				}
-			}
-			return types.StringNull()
-		}
+		// Populate all fields from the API response
+		flattenProjectSettings(&plan, project.Settings)
+	}
 
-		getBool := func(key string) types.Bool {
-			if val, ok := settings[key]; ok && val != nil {
-				if b, ok := val.(bool); ok {
-					return types.BoolValue(b)
-				}
-			}
-			return types.BoolNull()
+	if settingsErr != nil {
+		var applied map[string]interface{}
+		if project != nil {
+			applied = project.Settings
 		}
-
-		getJSONString := func(key string) types.String {
-			if val, ok := settings[key]; ok && val != nil {
-				// If it's already a string, return it
-				if str, ok := val.(string); ok {
-					return types.StringValue(str)
-				}
-				// Otherwise, marshal it to JSON
-				if jsonBytes, err := json.Marshal(val); err == nil {
-					return types.StringValue(string(jsonBytes))
-				}
-			}
-			return types.StringNull()
+		rejected := rejectedSettings(settings, applied)
+
+		if adopted {
+			// Keep the planned values so the apply completes without
+			// tainting the project; the next refresh reads the rejected
+			// settings back from the API and the plan retries them
+			addAdoptedSettingsNotAppliedWarning(&resp.Diagnostics, plan.ProjectName.ValueString(), settingsErr, rejected)
+		} else {
+			// Save the project as the API reports it; returning an error
+			// with state set makes Terraform mark it tainted
+			addSettingsNotAppliedError(&resp.Diagnostics, plan.ProjectName.ValueString(), settingsErr, rejected)
+			plan.SystemName = config.SystemName
+			plan.ProjectCreationConfig = config.ProjectCreationConfig
+			resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+			return
 		}
-
-		// Populate all fields from API response
-		plan.ProjectDisplayName = getString("projectDisplayName")
-		plan.CValue = getInt64("cValue")
-		plan.PValue = getFloat64("pValue")
-		plan.ProjectTimeZone = getString("projectTimeZone")
-		plan.SamplingInterval = getInt64("samplingInterval")
-
-		// Basic Configuration
-		plan.UBLRetentionTime = getInt64("UBLRetentionTime")
-		plan.AlertAverageTime = getInt64("alertAverageTime")
-		plan.AlertHourlyCost = getFloat64("alertHourlyCost")
-		plan.AnomalyDetectionMode = getInt64("anomalyDetectionMode")
-		plan.AnomalySamplingInterval = getInt64("anomalySamplingInterval")
-		plan.AvgPerIncidentDowntimeCost = getFloat64("avgPerIncidentDowntimeCost")
-		plan.CausalPredictionSetting = getInt64("causalPredictionSetting")
-		plan.CausalMinDelay = getString("causalMinDelay")
-		plan.ColdEventThreshold = getInt64("coldEventThreshold")
-		plan.ColdNumberLimit = getInt64("coldNumberLimit")
-		plan.CollectAllRareEventsFlag = getBool("collectAllRareEventsFlag")
-		plan.DailyModelSpan = getInt64("dailyModelSpan")
-		plan.DisableLogCompressEvent = getBool("disableLogCompressEvent")
-		plan.DisableModelKeywordStatsCollection = getBool("disableModelKeywordStatsCollection")
-
-		// Anomaly and Detection Settings
-		plan.EnableAnomalyScoreEscalation = getBool("enableAnomalyScoreEscalation")
-		plan.EnableHotEvent = getBool("enableHotEvent")
-		plan.EnableNewAlertEmail = getBool("enableNewAlertEmail")
-		plan.EnableStreamDetection = getBool("enableStreamDetection")
-		plan.EscalationAnomalyScoreThreshold = getString("escalationAnomalyScoreThreshold")
-		plan.FeatureOutlierSensitivity = getString("featureOutlierSensitivity")
-		plan.FeatureOutlierThreshold = getFloat64("featureOutlierThreshold")
-		plan.HotEventCalmDownPeriod = getInt64("hotEventCalmDownPeriod")
-		plan.HotEventDetectionMode = getInt64("hotEventDetectionMode")
-		plan.HotEventThreshold = getInt64("hotEventThreshold")
-		plan.HotNumberLimit = getInt64("hotNumberLimit")
-		plan.IgnoreAnomalyScoreThreshold = getString("ignoreAnomalyScoreThreshold")
-		plan.IgnoreInstanceForKB = getBool("ignoreInstanceForKB")
-
-		// Incident Settings
-		plan.IncidentPredictionEventLimit = getInt64("incidentPredictionEventLimit")
-		plan.IncidentPredictionWindow = getInt64("incidentPredictionWindow")
-		plan.IncidentRelationSearchWindow = getInt64("incidentRelationSearchWindow")
-
-		// Instance Settings
-		plan.InstanceConvertFlag = getBool("instanceConvertFlag")
-		plan.InstanceDownEnable = getBool("instanceDownEnable")
-		plan.IsEdgeBrain = getBool("isEdgeBrain")
-		plan.IsGroupingByInstance = getBool("isGroupingByInstance")
-		plan.IsTracePrompt = getBool("isTracePrompt")
-		plan.ShowInstanceDown = getBool("showInstanceDown")
-
-		// Log Settings
-		plan.KeywordFeatureNumber = getInt64("keywordFeatureNumber")
-		plan.KeywordSetting = getInt64("keywordSetting")
-		plan.LargeProject = getBool("largeProject")
-		plan.LogAnomalyEventBaseScore = getString("logAnomalyEventBaseScore")
-		plan.LogDetectionMinCount = getInt64("logDetectionMinCount")
-		plan.LogDetectionSize = getInt64("logDetectionSize")
-		plan.LogPatternLimitLevel = getInt64("logPatternLimitLevel")
-		plan.MaxLogModelSize = getInt64("maxLogModelSize")
-		plan.MaximumDetectionWaitTime = getInt64("maximumDetectionWaitTime")
-		plan.MaximumThreads = getInt64("maximumThreads")
-		plan.ModelKeywordSetting = getInt64("modelKeywordSetting")
-		plan.MultiLineFlag = getBool("multiLineFlag")
-		plan.NlpFlag = getBool("nlpFlag")
-		plan.PrettyJsonConvertorFlag = getBool("prettyJsonConvertorFlag")
-
-		// Model Settings
-		plan.MaximumRootCauseResultSize = getInt64("maximumRootCauseResultSize")
-		plan.MinIncidentPredictionWindow = getInt64("minIncidentPredictionWindow")
-		plan.MinValidModelSpan = getInt64("minValidModelSpan")
-		plan.MultiHopSearchLevel = getInt64("multiHopSearchLevel")
-		plan.MultiHopSearchLimit = getString("multiHopSearchLimit")
-
-		// Pattern and Event Settings
-		plan.NewAlertFlag = getBool("newAlertFlag")
-		plan.NewPatternNumberLimit = getInt64("newPatternNumberLimit")
-		plan.NewPatternRange = getInt64("newPatternRange")
-		plan.NormalEventCausalFlag = getBool("normalEventCausalFlag")
-
-		// Prediction Settings
-		plan.PredictionCountThreshold = getInt64("predictionCountThreshold")
-		plan.PredictionProbabilityThreshold = getFloat64("predictionProbabilityThreshold")
-		plan.PredictionRuleActiveCondition = getInt64("predictionRuleActiveCondition")
-		plan.PredictionRuleActiveThreshold = getFloat64("predictionRuleActiveThreshold")
-		plan.PredictionRuleFalsePositiveThreshold = getInt64("predictionRuleFalsePositiveThreshold")
-		plan.PredictionRuleInactiveThreshold = getFloat64("predictionRuleInactiveThreshold")
-		plan.ProjectModelFlag = getBool("projectModelFlag")
-		plan.Proxy = getString("proxy")
-
-		// Rare Event Settings
-		plan.RareAnomalyType = getInt64("rareAnomalyType")
-		plan.RareEventAlertThresholds = getInt64("rareEventAlertThresholds")
-		plan.RareNumberLimit = getInt64("rareNumberLimit")
-		plan.RetentionTime = getInt64("retentionTime")
-
-		// Root Cause Settings
-		plan.RootCauseCountThreshold = getInt64("rootCauseCountThreshold")
-		plan.RootCauseLogMessageSearchRange = getInt64("rootCauseLogMessageSearchRange")
-		plan.RootCauseProbabilityThreshold = getFloat64("rootCauseProbabilityThreshold")
-		plan.RootCauseRankSetting = getInt64("rootCauseRankSetting")
-
-		// Similarity and Training
-		plan.SimilaritySensitivity = getString("similaritySensitivity")
-		plan.TrainingFilter = getBool("trainingFilter")
-
-		// Webhook Settings
-		plan.MaxWebHookRequestSize = getInt64("maxWebHookRequestSize")
-		plan.WebhookAlertDampening = getInt64("webhookAlertDampening")
-		plan.WebhookBlackListSetStr = getString("webhookBlackListSetStr")
-		plan.WebhookCriticalKeywordSetStr = getString("webhookCriticalKeywordSetStr")
-		plan.WebhookTypeSetStr = getString("webhookTypeSetStr")
-		plan.WebhookUrl = getString("webhookUrl")
-		plan.WhitelistNumberLimit = getInt64("whitelistNumberLimit")
-		plan.ZoneNameKey = getString("zoneNameKey")
-
-		// Metric Project Fields
-
-		// JSON String Fields
-		plan.BaseValueSetting = getJSONString("baseValueSetting")
-		plan.CdfSetting = getJSONString("cdfSetting")
-		plan.EmailSetting = getJSONString("emailSetting")
-		plan.InstanceGroupingUpdate = getJSONString("instanceGroupingUpdate")
-		plan.LlmEvaluationSetting = getJSONString("llmEvaluationSetting")
-		plan.LogToLogSettingList = getJSONString("logToLogSettingList")
-		plan.WebhookHeaderList = getJSONString("webhookHeaderList")
-		plan.SharedUsernames = getJSONString("sharedUsernames")
-	}
-
-	// Always preserve config values over API values for fields explicitly set by user
-	// This ensures user-specified values take precedence
-	if !config.ProjectDisplayName.IsNull() {
-		plan.ProjectDisplayName = config.ProjectDisplayName
-	}
-	if !config.CValue.IsNull() {
-		plan.CValue = config.CValue
-	}
-	if !config.PValue.IsNull() {
-		plan.PValue = config.PValue
-	}
-	if !config.ProjectTimeZone.IsNull() {
-		plan.ProjectTimeZone = config.ProjectTimeZone
-	}
-	if !config.SamplingInterval.IsNull() {
-		plan.SamplingInterval = config.SamplingInterval
-	}
-	if !config.UBLRetentionTime.IsNull() {
-		plan.UBLRetentionTime = config.UBLRetentionTime
-	}
-	if !config.AlertAverageTime.IsNull() {
-		plan.AlertAverageTime = config.AlertAverageTime
-	}
-	if !config.AlertHourlyCost.IsNull() {
-		plan.AlertHourlyCost = config.AlertHourlyCost
-	}
-	if !config.AnomalyDetectionMode.IsNull() {
-		plan.AnomalyDetectionMode = config.AnomalyDetectionMode
-	}
-	if !config.AnomalySamplingInterval.IsNull() {
-		plan.AnomalySamplingInterval = config.AnomalySamplingInterval
-	}
-	if !config.AvgPerIncidentDowntimeCost.IsNull() {
-		plan.AvgPerIncidentDowntimeCost = config.AvgPerIncidentDowntimeCost
-	}
-	if !config.CausalPredictionSetting.IsNull() {
-		plan.CausalPredictionSetting = config.CausalPredictionSetting
-	}
-	if !config.CausalMinDelay.IsNull() {
-		plan.CausalMinDelay = config.CausalMinDelay
-	}
-	if !config.ColdEventThreshold.IsNull() {
-		plan.ColdEventThreshold = config.ColdEventThreshold
-	}
-	if !config.ColdNumberLimit.IsNull() {
-		plan.ColdNumberLimit = config.ColdNumberLimit
-	}
-	if !config.CollectAllRareEventsFlag.IsNull() {
-		plan.CollectAllRareEventsFlag = config.CollectAllRareEventsFlag
-	}
-	if !config.DailyModelSpan.IsNull() {
-		plan.DailyModelSpan = config.DailyModelSpan
-	}
-	if !config.DisableLogCompressEvent.IsNull() {
-		plan.DisableLogCompressEvent = config.DisableLogCompressEvent
-	}
-	if !config.DisableModelKeywordStatsCollection.IsNull() {
-		plan.DisableModelKeywordStatsCollection = config.DisableModelKeywordStatsCollection
-	}
-	if !config.EnableAnomalyScoreEscalation.IsNull() {
-		plan.EnableAnomalyScoreEscalation = config.EnableAnomalyScoreEscalation
-	}
-	if !config.EnableHotEvent.IsNull() {
-		plan.EnableHotEvent = config.EnableHotEvent
-	}
-	if !config.EnableNewAlertEmail.IsNull() {
-		plan.EnableNewAlertEmail = config.EnableNewAlertEmail
-	}
-	if !config.EnableStreamDetection.IsNull() {
-		plan.EnableStreamDetection = config.EnableStreamDetection
-	}
-	if !config.EscalationAnomalyScoreThreshold.IsNull() {
-		plan.EscalationAnomalyScoreThreshold = config.EscalationAnomalyScoreThreshold
-	}
-	if !config.FeatureOutlierSensitivity.IsNull() {
-		plan.FeatureOutlierSensitivity = config.FeatureOutlierSensitivity
-	}
-	if !config.FeatureOutlierThreshold.IsNull() {
-		plan.FeatureOutlierThreshold = config.FeatureOutlierThreshold
-	}
-	if !config.HotEventCalmDownPeriod.IsNull() {
-		plan.HotEventCalmDownPeriod = config.HotEventCalmDownPeriod
-	}
-	if !config.HotEventDetectionMode.IsNull() {
-		plan.HotEventDetectionMode = config.HotEventDetectionMode
-	}
-	if !config.HotEventThreshold.IsNull() {
-		plan.HotEventThreshold = config.HotEventThreshold
-	}
-	if !config.HotNumberLimit.IsNull() {
-		plan.HotNumberLimit = config.HotNumberLimit
-	}
-	if !config.IgnoreAnomalyScoreThreshold.IsNull() {
-		plan.IgnoreAnomalyScoreThreshold = config.IgnoreAnomalyScoreThreshold
-	}
-	if !config.IgnoreInstanceForKB.IsNull() {
-		plan.IgnoreInstanceForKB = config.IgnoreInstanceForKB
-	}
-	if !config.IncidentPredictionEventLimit.IsNull() {
-		plan.IncidentPredictionEventLimit = config.IncidentPredictionEventLimit
-	}
-	if !config.IncidentPredictionWindow.IsNull() {
-		plan.IncidentPredictionWindow = config.IncidentPredictionWindow
-	}
-	if !config.IncidentRelationSearchWindow.IsNull() {
-		plan.IncidentRelationSearchWindow = config.IncidentRelationSearchWindow
-	}
-	if !config.InstanceConvertFlag.IsNull() {
-		plan.InstanceConvertFlag = config.InstanceConvertFlag
-	}
-	if !config.InstanceDownEnable.IsNull() {
-		plan.InstanceDownEnable = config.InstanceDownEnable
-	}
-	if !config.IsEdgeBrain.IsNull() {
-		plan.IsEdgeBrain = config.IsEdgeBrain
-	}
-	if !config.IsGroupingByInstance.IsNull() {
-		plan.IsGroupingByInstance = config.IsGroupingByInstance
-	}
-	if !config.IsTracePrompt.IsNull() {
-		plan.IsTracePrompt = config.IsTracePrompt
-	}
-	if !config.ShowInstanceDown.IsNull() {
-		plan.ShowInstanceDown = config.ShowInstanceDown
-	}
-	if !config.KeywordFeatureNumber.IsNull() {
-		plan.KeywordFeatureNumber = config.KeywordFeatureNumber
-	}
-	if !config.KeywordSetting.IsNull() {
-		plan.KeywordSetting = config.KeywordSetting
-	}
-	if !config.LargeProject.IsNull() {
-		plan.LargeProject = config.LargeProject
-	}
-	if !config.LogAnomalyEventBaseScore.IsNull() {
-		plan.LogAnomalyEventBaseScore = config.LogAnomalyEventBaseScore
-	}
-	if !config.LogDetectionMinCount.IsNull() {
-		plan.LogDetectionMinCount = config.LogDetectionMinCount
-	}
-	if !config.LogDetectionSize.IsNull() {
-		plan.LogDetectionSize = config.LogDetectionSize
-	}
-	if !config.LogPatternLimitLevel.IsNull() {
-		plan.LogPatternLimitLevel = config.LogPatternLimitLevel
-	}
-	if !config.MaxLogModelSize.IsNull() {
-		plan.MaxLogModelSize = config.MaxLogModelSize
-	}
-	if !config.MaximumDetectionWaitTime.IsNull() {
-		plan.MaximumDetectionWaitTime = config.MaximumDetectionWaitTime
-	}
-	if !config.MaximumThreads.IsNull() {
-		plan.MaximumThreads = config.MaximumThreads
-	}
-	if !config.ModelKeywordSetting.IsNull() {
-		plan.ModelKeywordSetting = config.ModelKeywordSetting
-	}
-	if !config.MultiLineFlag.IsNull() {
-		plan.MultiLineFlag = config.MultiLineFlag
-	}
-	if !config.NlpFlag.IsNull() {
-		plan.NlpFlag = config.NlpFlag
-	}
-	if !config.PrettyJsonConvertorFlag.IsNull() {
-		plan.PrettyJsonConvertorFlag = config.PrettyJsonConvertorFlag
-	}
-	if !config.MaximumRootCauseResultSize.IsNull() {
-		plan.MaximumRootCauseResultSize = config.MaximumRootCauseResultSize
-	}
-	if !config.MinIncidentPredictionWindow.IsNull() {
-		plan.MinIncidentPredictionWindow = config.MinIncidentPredictionWindow
-	}
-	if !config.MinValidModelSpan.IsNull() {
-		plan.MinValidModelSpan = config.MinValidModelSpan
-	}
-	if !config.MultiHopSearchLevel.IsNull() {
-		plan.MultiHopSearchLevel = config.MultiHopSearchLevel
-	}
-	if !config.MultiHopSearchLimit.IsNull() {
-		plan.MultiHopSearchLimit = config.MultiHopSearchLimit
-	}
-	if !config.NewAlertFlag.IsNull() {
-		plan.NewAlertFlag = config.NewAlertFlag
-	}
-	if !config.NewPatternNumberLimit.IsNull() {
-		plan.NewPatternNumberLimit = config.NewPatternNumberLimit
-	}
-	if !config.NewPatternRange.IsNull() {
-		plan.NewPatternRange = config.NewPatternRange
-	}
-	if !config.NormalEventCausalFlag.IsNull() {
-		plan.NormalEventCausalFlag = config.NormalEventCausalFlag
-	}
-	if !config.PredictionCountThreshold.IsNull() {
-		plan.PredictionCountThreshold = config.PredictionCountThreshold
-	}
-	if !config.PredictionProbabilityThreshold.IsNull() {
-		plan.PredictionProbabilityThreshold = config.PredictionProbabilityThreshold
-	}
-	if !config.PredictionRuleActiveCondition.IsNull() {
-		plan.PredictionRuleActiveCondition = config.PredictionRuleActiveCondition
-	}
-	if !config.PredictionRuleActiveThreshold.IsNull() {
-		plan.PredictionRuleActiveThreshold = config.PredictionRuleActiveThreshold
-	}
-	if !config.PredictionRuleFalsePositiveThreshold.IsNull() {
-		plan.PredictionRuleFalsePositiveThreshold = config.PredictionRuleFalsePositiveThreshold
-	}
-	if !config.PredictionRuleInactiveThreshold.IsNull() {
-		plan.PredictionRuleInactiveThreshold = config.PredictionRuleInactiveThreshold
-	}
-	if !config.ProjectModelFlag.IsNull() {
-		plan.ProjectModelFlag = config.ProjectModelFlag
-	}
-	if !config.Proxy.IsNull() {
-		plan.Proxy = config.Proxy
-	}
-	if !config.RareAnomalyType.IsNull() {
-		plan.RareAnomalyType = config.RareAnomalyType
-	}
-	if !config.RareEventAlertThresholds.IsNull() {
-		plan.RareEventAlertThresholds = config.RareEventAlertThresholds
-	}
-	if !config.RareNumberLimit.IsNull() {
-		plan.RareNumberLimit = config.RareNumberLimit
-	}
-	if !config.RetentionTime.IsNull() {
-		plan.RetentionTime = config.RetentionTime
-	}
-	if !config.RootCauseCountThreshold.IsNull() {
-		plan.RootCauseCountThreshold = config.RootCauseCountThreshold
-	}
-	if !config.RootCauseLogMessageSearchRange.IsNull() {
-		plan.RootCauseLogMessageSearchRange = config.RootCauseLogMessageSearchRange
-	}
-	if !config.RootCauseProbabilityThreshold.IsNull() {
-		plan.RootCauseProbabilityThreshold = config.RootCauseProbabilityThreshold
-	}
-	if !config.RootCauseRankSetting.IsNull() {
-		plan.RootCauseRankSetting = config.RootCauseRankSetting
-	}
-	if !config.SimilaritySensitivity.IsNull() {
-		plan.SimilaritySensitivity = config.SimilaritySensitivity
-	}
-	if !config.TrainingFilter.IsNull() {
-		plan.TrainingFilter = config.TrainingFilter
-	}
-	if !config.MaxWebHookRequestSize.IsNull() {
-		plan.MaxWebHookRequestSize = config.MaxWebHookRequestSize
-	}
-	if !config.WebhookAlertDampening.IsNull() {
-		plan.WebhookAlertDampening = config.WebhookAlertDampening
-	}
-	if !config.WebhookBlackListSetStr.IsNull() {
-		plan.WebhookBlackListSetStr = config.WebhookBlackListSetStr
-	}
-	if !config.WebhookCriticalKeywordSetStr.IsNull() {
-		plan.WebhookCriticalKeywordSetStr = config.WebhookCriticalKeywordSetStr
-	}
-	if !config.WebhookTypeSetStr.IsNull() {
-		plan.WebhookTypeSetStr = config.WebhookTypeSetStr
-	}
-	if !config.WebhookUrl.IsNull() {
-		plan.WebhookUrl = config.WebhookUrl
-	}
-	if !config.WhitelistNumberLimit.IsNull() {
-		plan.WhitelistNumberLimit = config.WhitelistNumberLimit
-	}
-	if !config.ZoneNameKey.IsNull() {
-		plan.ZoneNameKey = config.ZoneNameKey
-	}
-	if !config.BaseValueSetting.IsNull() {
-		plan.BaseValueSetting = config.BaseValueSetting
-	}
-	if !config.CdfSetting.IsNull() {
-		plan.CdfSetting = config.CdfSetting
-	}
-	if !config.EmailSetting.IsNull() {
-		plan.EmailSetting = config.EmailSetting
-	}
-	if !config.InstanceGroupingUpdate.IsNull() {
-		plan.InstanceGroupingUpdate = config.InstanceGroupingUpdate
-	}
-	if !config.LlmEvaluationSetting.IsNull() {
-		plan.LlmEvaluationSetting = config.LlmEvaluationSetting
-	}
-	if !config.LogToLogSettingList.IsNull() {
-		plan.LogToLogSettingList = config.LogToLogSettingList
-	}
-	if !config.WebhookHeaderList.IsNull() {
-		plan.WebhookHeaderList = config.WebhookHeaderList
-	}
-	if !config.SharedUsernames.IsNull() {
-		plan.SharedUsernames = config.SharedUsernames
 	}
 
+	// Values the user configured take precedence so the state matches the plan
+	preserveConfiguredSettings(&plan, &config)
+
 	// Process log_label_settings if provided - each setting must be applied individually
 	if !config.LogLabelSettings.IsNull() && !config.LogLabelSettings.IsUnknown() {
 		var configSettings []logLabelSettingModel
@@ -1711,15 +1826,29 @@ func (r *projectResource) Create(ctx context.Context, req resource.CreateRequest
 
 			// Apply all settings (function will iterate and call API for each)
 			err := r.client.CreateOrUpdateLogLabels(
+				ctx,
 				plan.ProjectName.ValueString(),
-				r.client.Username,
+				r.client.CustomerName(),
 				settings,
 			)
-			if err != nil {
+			if err != nil && adopted {
+				resp.Diagnostics.AddWarning(
+					"Log label settings not applied to adopted project",
+					fmt.Sprintf("Project %q was adopted, but its log label settings could not be applied: %s\n\n", plan.ProjectName.ValueString(), err.Error())+
+						"The project has been saved to state. The next plan shows the log label settings that still differ, and applying it retries them.",
+				)
+			} else if err != nil {
+				// Save the created project so Terraform taints it instead of
+				// losing track of it
 				resp.Diagnostics.AddError(
 					"Error applying log label settings",
-					fmt.Sprintf("Could not apply log label settings: %s", err.Error()),
+					fmt.Sprintf("Project %q was created, but its log label settings could not be applied: %s\n\n", plan.ProjectName.ValueString(), err.Error())+
+						"The project has been saved to state and marked tainted, so the next apply replaces it. "+
+						"To keep the project and only retry the log label settings, run terraform untaint on it and apply again.",
 				)
+				plan.SystemName = config.SystemName
+				plan.ProjectCreationConfig = config.ProjectCreationConfig
+				resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
 				return
 			}
 
@@ -1770,232 +1899,170 @@ func (r *projectResource) Create(ctx context.Context, req resource.CreateRequest
 	tflog.Info(ctx, "Project created successfully", map[string]any{"project_name": plan.ProjectName.ValueString()})
 }
 
-// Read refreshes the Terraform state with the latest data.
-func (r *projectResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
-	var state projectResourceModel
-	diags := req.State.Get(ctx, &state)
-	resp.Diagnostics.Append(diags...)
-	if resp.Diagnostics.HasError() {
-		return
-	}
+// copyLifecycleSettings copies the attributes that control how Terraform
+// manages the project, which the API knows nothing about, from src to dst.
+// They are absent from the configuration when left at their defaults.
+func copyLifecycleSettings(dst, src *projectResourceModel) {
+	dst.AdoptExisting = src.AdoptExisting
+	dst.DeletionProtection = src.DeletionProtection
+	dst.RetainOnDelete = src.RetainOnDelete
+}
 
-	tflog.Info(ctx, "Reading project", map[string]any{"project_name": state.ProjectName.ValueString()})
+// defaultLifecycleSettings sets unset lifecycle attributes, as found after an
+// import, to their schema defaults.
+func defaultLifecycleSettings(m *projectResourceModel) {
+	for _, field := range []*types.Bool{&m.AdoptExisting, &m.DeletionProtection, &m.RetainOnDelete} {
+		if field.IsNull() || field.IsUnknown() {
+			*field = types.BoolValue(false)
+		}
+	}
+}
 
-	// Get the project from the API
-	project, err := r.client.GetProject(state.ProjectName.ValueString(), r.client.Username)
+// adoptProject saves an existing project with its current settings to state
+// before the plan is applied to it, so that it stays tracked if applying the
+// plan fails. It reports whether the project could be read.
+func (r *projectResource) adoptProject(ctx context.Context, plan projectResourceModel, resp *resource.CreateResponse) bool {
+	project, err := r.client.GetProject(ctx, plan.ProjectName.ValueString(), r.client.CustomerName())
 	if err != nil {
 		resp.Diagnostics.AddError(
-			"Error reading project",
-			"Could not read project, unexpected error: "+err.Error(),
+			"Error adopting project",
+			fmt.Sprintf("Could not read existing project %q: %s", plan.ProjectName.ValueString(), apiErrorDetail(err)),
 		)
-		return
+		return false
 	}
 
-	// If project doesn't exist, remove from state
-	if project == nil {
-		resp.State.RemoveResource(ctx)
-		return
+	adopted := plan
+	adopted.ID = plan.ProjectName
+	flattenProjectSettings(&adopted, project.Settings)
+	if adopted.LogLabelSettings.IsUnknown() {
+		adopted.LogLabelSettings = types.ListNull(adopted.LogLabelSettings.ElementType(ctx))
 	}
 
-	// Update state with API data
-	// The project.Settings map contains all the configuration from the API
-	settings := project.Settings
-	if settings == nil {
-		settings = make(map[string]interface{})
-	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, adopted)...)
+	return !resp.Diagnostics.HasError()
+}
 
-	// Helper function to safely get values from settings map
-	getInt64 := func(key string) types.Int64 {
-		if val, ok := settings[key]; ok && val != nil {
-			switch v := val.(type) {
-			case float64:
-				return types.Int64Value(int64(v))
-			case int64:
-				return types.Int64Value(v)
-			case int:
-				return types.Int64Value(int64(v))
-			}
+// rejectedSettings returns the keys of the settings sent to the API whose
+// values the API does not report back, sorted. With no settings from the API
+// every key sent is returned.
+func rejectedSettings(sent, applied map[string]interface{}) []string {
+	var rejected []string
+	for key, value := range sent {
+		if key == "projectName" {
+			continue
 		}
-		return types.Int64Null()
-	}
-
-	getFloat64 := func(key string) types.Float64 {
-		if val, ok := settings[key]; ok && val != nil {
-			switch v := val.(type) {
-			case float64:
-				return types.Float64Value(v)
-			case int64:
-				return types.Float64Value(float64(v))
-			case int:
-				return types.Float64Value(float64(v))
-			}
+		if !settingValuesEqual(value, applied[key]) {
+			rejected = append(rejected, key)
 		}
-		return types.Float64Null()
 	}
+	sort.Strings(rejected)
+	return rejected
+}
 
-	getString := func(key string) types.String {
-		if val, ok := settings[key]; ok && val != nil {
-			if str, ok := val.(string); ok {
-				return types.StringValue(str)
-			}
+// settingValuesEqual reports whether two setting values encode to the same
+// JSON, so that an int64 sent and a float64 read back compare equal.
+func settingValuesEqual(a, b interface{}) bool {
+	// Only the keys sent for a nested object are compared; the API reports
+	// every key of the object
+	if sentObject, ok := a.(map[string]interface{}); ok {
+		appliedObject, ok := b.(map[string]interface{})
+		if !ok {
+			return false
 		}
-		return types.StringNull()
-	}
-
-	getBool := func(key string) types.Bool {
-		if val, ok := settings[key]; ok && val != nil {
-			if b, ok := val.(bool); ok {
-				return types.BoolValue(b)
+		for key, value := range sentObject {
+			if !settingValuesEqual(value, appliedObject[key]) {
+				return false
 			}
 		}
-		return types.BoolNull()
+		return true
 	}
 
-	getJSONString := func(key string) types.String {
-		if val, ok := settings[key]; ok && val != nil {
-			// If it's already a string, return it
-			if str, ok := val.(string); ok {
-				return types.StringValue(str)
-			}
-			// Otherwise, marshal it to JSON
-			if jsonBytes, err := json.Marshal(val); err == nil {
-				return types.StringValue(string(jsonBytes))
-			}
-		}
-		return types.StringNull()
-	}
+	encodedA, errA := json.Marshal(a)
+	encodedB, errB := json.Marshal(b)
+	return errA == nil && errB == nil && string(encodedA) == string(encodedB)
+}
 
-	// Populate all fields from API response
-	state.ProjectDisplayName = getString("projectDisplayName")
-	state.CValue = getInt64("cValue")
-	state.PValue = getFloat64("pValue")
-	state.ProjectTimeZone = getString("projectTimeZone")
-	state.SamplingInterval = getInt64("samplingInterval")
+// addSettingsNotAppliedError reports settings that could not be applied to
+// a newly created project.
+func addSettingsNotAppliedError(diags *diag.Diagnostics, projectName string, err error, rejected []string) {
+	notApplied := "none could be identified"
+	if len(rejected) > 0 {
+		notApplied = strings.Join(rejected, ", ")
+	}
+
+	diags.AddError(
+		"Error applying project settings",
+		fmt.Sprintf("Project %q was created, but its settings could not be applied: %s\n\n", projectName, apiErrorDetail(err))+
+			fmt.Sprintf("Settings not applied: %s\n\n", notApplied)+
+			"The project has been saved to state and marked tainted, so the next apply replaces it. "+
+			"To keep the project and only retry the settings, run terraform untaint on it and apply again.",
+	)
+}
 
-	// Basic Configuration
-	state.UBLRetentionTime = getInt64("UBLRetentionTime")
-	state.AlertAverageTime = getInt64("alertAverageTime")
-	state.AlertHourlyCost = getFloat64("alertHourlyCost")
-	state.AnomalyDetectionMode = getInt64("anomalyDetectionMode")
-	state.AnomalySamplingInterval = getInt64("anomalySamplingInterval")
-	state.AvgPerIncidentDowntimeCost = getFloat64("avgPerIncidentDowntimeCost")
-	state.CausalPredictionSetting = getInt64("causalPredictionSetting")
-	state.CausalMinDelay = getString("causalMinDelay")
-	state.ColdEventThreshold = getInt64("coldEventThreshold")
-	state.ColdNumberLimit = getInt64("coldNumberLimit")
-	state.CollectAllRareEventsFlag = getBool("collectAllRareEventsFlag")
-	state.DailyModelSpan = getInt64("dailyModelSpan")
-	state.DisableLogCompressEvent = getBool("disableLogCompressEvent")
-	state.DisableModelKeywordStatsCollection = getBool("disableModelKeywordStatsCollection")
+// addAdoptedSettingsNotAppliedWarning reports settings that could not be
+// applied to an adopted project. It is a warning rather than an error so
+// that Terraform does not taint, and later destroy, a project it did not
+// create.
+func addAdoptedSettingsNotAppliedWarning(diags *diag.Diagnostics, projectName string, err error, rejected []string) {
+	notApplied := "none could be identified"
+	if len(rejected) > 0 {
+		notApplied = strings.Join(rejected, ", ")
+	}
+
+	diags.AddWarning(
+		"Project settings not applied to adopted project",
+		fmt.Sprintf("Project %q already existed and was adopted, but its settings could not be applied: %s\n\n", projectName, apiErrorDetail(err))+
+			fmt.Sprintf("Settings not applied: %s\n\n", notApplied)+
+			"The project has been saved to state and is not tainted. The next plan shows the settings that still differ from the project, and applying it retries them.",
+	)
+}
 
-	// Anomaly and Detection Settings
-	state.EnableAnomalyScoreEscalation = getBool("enableAnomalyScoreEscalation")
-	state.EnableHotEvent = getBool("enableHotEvent")
-	state.EnableNewAlertEmail = getBool("enableNewAlertEmail")
-	state.EnableStreamDetection = getBool("enableStreamDetection")
-	state.EscalationAnomalyScoreThreshold = getString("escalationAnomalyScoreThreshold")
-	state.FeatureOutlierSensitivity = getString("featureOutlierSensitivity")
-	state.FeatureOutlierThreshold = getFloat64("featureOutlierThreshold")
-	state.HotEventCalmDownPeriod = getInt64("hotEventCalmDownPeriod")
-	state.HotEventDetectionMode = getInt64("hotEventDetectionMode")
-	state.HotEventThreshold = getInt64("hotEventThreshold")
-	state.HotNumberLimit = getInt64("hotNumberLimit")
-	state.IgnoreAnomalyScoreThreshold = getString("ignoreAnomalyScoreThreshold")
-	state.IgnoreInstanceForKB = getBool("ignoreInstanceForKB")
+// Read refreshes the Terraform state with the latest data.
+func (r *projectResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state projectResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
-	// Incident Settings
-	state.IncidentPredictionEventLimit = getInt64("incidentPredictionEventLimit")
-	state.IncidentPredictionWindow = getInt64("incidentPredictionWindow")
-	state.IncidentRelationSearchWindow = getInt64("incidentRelationSearchWindow")
+	readTimeout, diags := state.Timeouts.Read(ctx, defaultReadTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
-	// Instance Settings
-	state.InstanceConvertFlag = getBool("instanceConvertFlag")
-	state.InstanceDownEnable = getBool("instanceDownEnable")
-	state.IsEdgeBrain = getBool("isEdgeBrain")
-	state.IsGroupingByInstance = getBool("isGroupingByInstance")
-	state.IsTracePrompt = getBool("isTracePrompt")
-	state.ShowInstanceDown = getBool("showInstanceDown")
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
 
-	// Log Settings
-	state.KeywordFeatureNumber = getInt64("keywordFeatureNumber")
-	state.KeywordSetting = getInt64("keywordSetting")
-	state.LargeProject = getBool("largeProject")
-	state.LogAnomalyEventBaseScore = getString("logAnomalyEventBaseScore")
-	state.LogDetectionMinCount = getInt64("logDetectionMinCount")
-	state.LogDetectionSize = getInt64("logDetectionSize")
-	state.LogPatternLimitLevel = getInt64("logPatternLimitLevel")
-	state.MaxLogModelSize = getInt64("maxLogModelSize")
-	state.MaximumDetectionWaitTime = getInt64("maximumDetectionWaitTime")
-	state.MaximumThreads = getInt64("maximumThreads")
-	state.ModelKeywordSetting = getInt64("modelKeywordSetting")
-	state.MultiLineFlag = getBool("multiLineFlag")
-	state.NlpFlag = getBool("nlpFlag")
-	state.PrettyJsonConvertorFlag = getBool("prettyJsonConvertorFlag")
-
-	// Model Settings
-	state.MaximumRootCauseResultSize = getInt64("maximumRootCauseResultSize")
-	state.MinIncidentPredictionWindow = getInt64("minIncidentPredictionWindow")
-	state.MinValidModelSpan = getInt64("minValidModelSpan")
-	state.MultiHopSearchLevel = getInt64("multiHopSearchLevel")
-	state.MultiHopSearchLimit = getString("multiHopSearchLimit")
-
-	// Pattern and Event Settings
-	state.NewAlertFlag = getBool("newAlertFlag")
-	state.NewPatternNumberLimit = getInt64("newPatternNumberLimit")
-	state.NewPatternRange = getInt64("newPatternRange")
-	state.NormalEventCausalFlag = getBool("normalEventCausalFlag")
-
-	// Prediction Settings
-	state.PredictionCountThreshold = getInt64("predictionCountThreshold")
-	state.PredictionProbabilityThreshold = getFloat64("predictionProbabilityThreshold")
-	state.PredictionRuleActiveCondition = getInt64("predictionRuleActiveCondition")
-	state.PredictionRuleActiveThreshold = getFloat64("predictionRuleActiveThreshold")
-	state.PredictionRuleFalsePositiveThreshold = getInt64("predictionRuleFalsePositiveThreshold")
-	state.PredictionRuleInactiveThreshold = getFloat64("predictionRuleInactiveThreshold")
-	state.ProjectModelFlag = getBool("projectModelFlag")
-	state.Proxy = getString("proxy")
-
-	// Rare Event Settings
-	state.RareAnomalyType = getInt64("rareAnomalyType")
-	state.RareEventAlertThresholds = getInt64("rareEventAlertThresholds")
-	state.RareNumberLimit = getInt64("rareNumberLimit")
-	state.RetentionTime = getInt64("retentionTime")
-
-	// Root Cause Settings
-	state.RootCauseCountThreshold = getInt64("rootCauseCountThreshold")
-	state.RootCauseLogMessageSearchRange = getInt64("rootCauseLogMessageSearchRange")
-	state.RootCauseProbabilityThreshold = getFloat64("rootCauseProbabilityThreshold")
-	state.RootCauseRankSetting = getInt64("rootCauseRankSetting")
-
-	// Similarity and Training
-	state.SimilaritySensitivity = getString("similaritySensitivity")
-	state.TrainingFilter = getBool("trainingFilter")
+	tflog.Info(ctx, "Reading project", map[string]any{"project_name": state.ProjectName.ValueString()})
 
-	// Webhook Settings
-	state.MaxWebHookRequestSize = getInt64("maxWebHookRequestSize")
-	state.WebhookAlertDampening = getInt64("webhookAlertDampening")
-	state.WebhookBlackListSetStr = getString("webhookBlackListSetStr")
-	state.WebhookCriticalKeywordSetStr = getString("webhookCriticalKeywordSetStr")
-	state.WebhookTypeSetStr = getString("webhookTypeSetStr")
-	state.WebhookUrl = getString("webhookUrl")
-	state.WhitelistNumberLimit = getInt64("whitelistNumberLimit")
-	state.ZoneNameKey = getString("zoneNameKey")
-
-	// Metric Project Fields
-
-	// JSON String Fields
-	state.BaseValueSetting = getJSONString("baseValueSetting")
-	state.CdfSetting = getJSONString("cdfSetting")
-	state.EmailSetting = getJSONString("emailSetting")
-	state.InstanceGroupingUpdate = getJSONString("instanceGroupingUpdate")
-	state.LlmEvaluationSetting = getJSONString("llmEvaluationSetting")
-	state.LogToLogSettingList = getJSONString("logToLogSettingList")
-	state.WebhookHeaderList = getJSONString("webhookHeaderList")
-	state.SharedUsernames = getJSONString("sharedUsernames")
+	// Get the project from the API
+	project, err := r.client.GetProject(ctx, state.ProjectName.ValueString(), r.client.CustomerName())
+	if errors.Is(err, client.ErrNotFound) {
+		// Project was deleted outside Terraform, remove from state
+		tflog.Warn(ctx, "Project not found, removing from state", map[string]any{"project_name": state.ProjectName.ValueString()})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading project",
+			"Could not read project, unexpected error: "+apiErrorDetail(err),
+		)
+		return
+	}
+
+	// Every managed attribute comes from the API so that changes made
+	// outside Terraform show up as drift
+	flattenProjectSettings(&state, project.Settings)
+	defaultLifecycleSettings(&state)
 
 	// Read log label settings from API
-	logLabels, err := r.client.GetLogLabels(state.ProjectName.ValueString(), r.client.Username)
-	if err != nil {
+	logLabels, err := r.client.GetLogLabels(ctx, state.ProjectName.ValueString(), r.client.CustomerName())
+	if errors.Is(err, client.ErrNotFound) {
+		// No labels configured, keep existing state
+	} else if err != nil {
 		tflog.Warn(ctx, "Could not read log labels", map[string]any{"error": err.Error()})
 		// Keep existing state if we can't read from API
 	} else if logLabels != nil {
@@ -2044,6 +2111,15 @@ func (r *projectResource) Update(ctx context.Context, req resource.UpdateRequest
 		return
 	}
 
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
 	// Get the config (only user-specified values, not computed ones)
 	var config projectResourceModel
 	diags = req.Config.Get(ctx, &config)
@@ -2055,226 +2131,42 @@ func (r *projectResource) Update(ctx context.Context, req resource.UpdateRequest
 	tflog.Info(ctx, "Updating project", map[string]any{"project_name": config.ProjectName.ValueString()})
 
 	// Use config (not plan) to populate settings - this ensures we only send user-specified values
+	settings, diags := populateSettings(&config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	projectConfig := &client.ProjectConfig{
 		ProjectName:        config.ProjectName.ValueString(),
 		ProjectDisplayName: config.ProjectDisplayName.ValueString(),
 		SystemName:         config.SystemName.ValueString(),
 		CValue:             int(config.CValue.ValueInt64()),
 		PValue:             config.PValue.ValueFloat64(),
-		Settings:           populateSettings(&config),
+		Settings:           settings,
+		PartialSettings:    partialObjectSettings(settings),
 	}
 
-	err := r.client.UpdateProject(projectConfig)
+	err := r.client.UpdateProject(ctx, projectConfig)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error updating project",
-			"Could not update project, unexpected error: "+err.Error(),
+			"Could not update project, unexpected error: "+apiErrorDetail(err),
 		)
 		return
 	}
 
 	// After successful update, read back the actual state from API
-	project, err := r.client.GetProject(plan.ProjectName.ValueString(), r.client.Username)
+	project, err := r.client.GetProject(ctx, plan.ProjectName.ValueString(), r.client.CustomerName())
 	if err != nil {
 		resp.Diagnostics.AddWarning(
 			"Error reading project after update",
 			"Could not read project after update: "+err.Error()+". State may be out of sync.",
 		)
 	} else if project != nil {
-		// Populate state with actual API values (same logic as Read method)
-		settings := project.Settings
-		if settings == nil {
-			settings = make(map[string]interface{})
-		}
-
-		// Helper functions (same as in Read)
-		getInt64 := func(key string) types.Int64 {
-			if val, ok := settings[key]; ok && val != nil {
-				switch v := val.(type) {
-				case float64:
-					return types.Int64Value(int64(v))
-				case int64:
-					return types.Int64Value(v)
-				case int:
-					return types.Int64Value(int64(v))
-				}
-			}
-			return types.Int64Null()
-		}
-
-		getFloat64 := func(key string) types.Float64 {
-			if val, ok := settings[key]; ok && val != nil {
-				switch v := val.(type) {
-				case float64:
-					return types.Float64Value(v)
-				case int64:
-					return types.Float64Value(float64(v))
-				case int:
-					return types.Float64Value(float64(v))
-				}
-			}
-			return types.Float64Null()
-		}
-
-		getString := func(key string) types.String {
-			if val, ok := settings[key]; ok && val != nil {
-				if str, ok := val.(string); ok {
-					return types.StringValue(str)
-				}
-			}
-			return types.StringNull()
-		}
-
-		getBool := func(key string) types.Bool {
-			if val, ok := settings[key]; ok && val != nil {
-				if b, ok := val.(bool); ok {
-					return types.BoolValue(b)
-				}
-			}
-			return types.BoolNull()
-		}
-
-		getJSONString := func(key string) types.String {
-			if val, ok := settings[key]; ok && val != nil {
-				// If it's already a string, return it
-				if str, ok := val.(string); ok {
-					return types.StringValue(str)
-				}
-				// Otherwise, marshal it to JSON
-				if jsonBytes, err := json.Marshal(val); err == nil {
-					return types.StringValue(string(jsonBytes))
-				}
-			}
-			return types.StringNull()
-		}
-
-		// Populate all fields from API response
-		plan.ProjectDisplayName = getString("projectDisplayName")
-		plan.CValue = getInt64("cValue")
-		plan.PValue = getFloat64("pValue")
-		plan.ProjectTimeZone = getString("projectTimeZone")
-		plan.SamplingInterval = getInt64("samplingInterval")
-
-		// Basic Configuration
-		plan.UBLRetentionTime = getInt64("UBLRetentionTime")
-		plan.AlertAverageTime = getInt64("alertAverageTime")
-		plan.AlertHourlyCost = getFloat64("alertHourlyCost")
-		plan.AnomalyDetectionMode = getInt64("anomalyDetectionMode")
-		plan.AnomalySamplingInterval = getInt64("anomalySamplingInterval")
-		plan.AvgPerIncidentDowntimeCost = getFloat64("avgPerIncidentDowntimeCost")
-		plan.CausalPredictionSetting = getInt64("causalPredictionSetting")
-		plan.CausalMinDelay = getString("causalMinDelay")
-		plan.ColdEventThreshold = getInt64("coldEventThreshold")
-		plan.ColdNumberLimit = getInt64("coldNumberLimit")
-		plan.CollectAllRareEventsFlag = getBool("collectAllRareEventsFlag")
-		plan.DailyModelSpan = getInt64("dailyModelSpan")
-		plan.DisableLogCompressEvent = getBool("disableLogCompressEvent")
-		plan.DisableModelKeywordStatsCollection = getBool("disableModelKeywordStatsCollection")
-
-		// Anomaly and Detection Settings
-		plan.EnableAnomalyScoreEscalation = getBool("enableAnomalyScoreEscalation")
-		plan.EnableHotEvent = getBool("enableHotEvent")
-		plan.EnableNewAlertEmail = getBool("enableNewAlertEmail")
-		plan.EnableStreamDetection = getBool("enableStreamDetection")
-		plan.EscalationAnomalyScoreThreshold = getString("escalationAnomalyScoreThreshold")
-		plan.FeatureOutlierSensitivity = getString("featureOutlierSensitivity")
-		plan.FeatureOutlierThreshold = getFloat64("featureOutlierThreshold")
-		plan.HotEventCalmDownPeriod = getInt64("hotEventCalmDownPeriod")
-		plan.HotEventDetectionMode = getInt64("hotEventDetectionMode")
-		plan.HotEventThreshold = getInt64("hotEventThreshold")
-		plan.HotNumberLimit = getInt64("hotNumberLimit")
-		plan.IgnoreAnomalyScoreThreshold = getString("ignoreAnomalyScoreThreshold")
-		plan.IgnoreInstanceForKB = getBool("ignoreInstanceForKB")
-
-		// Incident Settings
-		plan.IncidentPredictionEventLimit = getInt64("incidentPredictionEventLimit")
-		plan.IncidentPredictionWindow = getInt64("incidentPredictionWindow")
-		plan.IncidentRelationSearchWindow = getInt64("incidentRelationSearchWindow")
-
-		// Instance Settings
-		plan.InstanceConvertFlag = getBool("instanceConvertFlag")
-		plan.InstanceDownEnable = getBool("instanceDownEnable")
-		plan.IsEdgeBrain = getBool("isEdgeBrain")
-		plan.IsGroupingByInstance = getBool("isGroupingByInstance")
-		plan.IsTracePrompt = getBool("isTracePrompt")
-		plan.ShowInstanceDown = getBool("showInstanceDown")
-
-		// Log Settings
-		plan.KeywordFeatureNumber = getInt64("keywordFeatureNumber")
-		plan.KeywordSetting = getInt64("keywordSetting")
-		plan.LargeProject = getBool("largeProject")
-		plan.LogAnomalyEventBaseScore = getString("logAnomalyEventBaseScore")
-		plan.LogDetectionMinCount = getInt64("logDetectionMinCount")
-		plan.LogDetectionSize = getInt64("logDetectionSize")
-		plan.LogPatternLimitLevel = getInt64("logPatternLimitLevel")
-		plan.MaxLogModelSize = getInt64("maxLogModelSize")
-		plan.MaximumDetectionWaitTime = getInt64("maximumDetectionWaitTime")
-		plan.MaximumThreads = getInt64("maximumThreads")
-		plan.ModelKeywordSetting = getInt64("modelKeywordSetting")
-		plan.MultiLineFlag = getBool("multiLineFlag")
-		plan.NlpFlag = getBool("nlpFlag")
-		plan.PrettyJsonConvertorFlag = getBool("prettyJsonConvertorFlag")
-
-		// Model Settings
-		plan.MaximumRootCauseResultSize = getInt64("maximumRootCauseResultSize")
-		plan.MinIncidentPredictionWindow = getInt64("minIncidentPredictionWindow")
-		plan.MinValidModelSpan = getInt64("minValidModelSpan")
-		plan.MultiHopSearchLevel = getInt64("multiHopSearchLevel")
-		plan.MultiHopSearchLimit = getString("multiHopSearchLimit")
-
-		// Pattern and Event Settings
-		plan.NewAlertFlag = getBool("newAlertFlag")
-		plan.NewPatternNumberLimit = getInt64("newPatternNumberLimit")
-		plan.NewPatternRange = getInt64("newPatternRange")
-		plan.NormalEventCausalFlag = getBool("normalEventCausalFlag")
-
-		// Prediction Settings
-		plan.PredictionCountThreshold = getInt64("predictionCountThreshold")
-		plan.PredictionProbabilityThreshold = getFloat64("predictionProbabilityThreshold")
-		plan.PredictionRuleActiveCondition = getInt64("predictionRuleActiveCondition")
-		plan.PredictionRuleActiveThreshold = getFloat64("predictionRuleActiveThreshold")
-		plan.PredictionRuleFalsePositiveThreshold = getInt64("predictionRuleFalsePositiveThreshold")
-		plan.PredictionRuleInactiveThreshold = getFloat64("predictionRuleInactiveThreshold")
-		plan.ProjectModelFlag = getBool("projectModelFlag")
-		plan.Proxy = getString("proxy")
-
-		// Rare Event Settings
-		plan.RareAnomalyType = getInt64("rareAnomalyType")
-		plan.RareEventAlertThresholds = getInt64("rareEventAlertThresholds")
-		plan.RareNumberLimit = getInt64("rareNumberLimit")
-		plan.RetentionTime = getInt64("retentionTime")
-
-		// Root Cause Settings
-		plan.RootCauseCountThreshold = getInt64("rootCauseCountThreshold")
-		plan.RootCauseLogMessageSearchRange = getInt64("rootCauseLogMessageSearchRange")
-		plan.RootCauseProbabilityThreshold = getFloat64("rootCauseProbabilityThreshold")
-		plan.RootCauseRankSetting = getInt64("rootCauseRankSetting")
-
-		// Similarity and Training
-		plan.SimilaritySensitivity = getString("similaritySensitivity")
-		plan.TrainingFilter = getBool("trainingFilter")
-
-		// Webhook Settings
-		plan.MaxWebHookRequestSize = getInt64("maxWebHookRequestSize")
-		plan.WebhookAlertDampening = getInt64("webhookAlertDampening")
-		plan.WebhookBlackListSetStr = getString("webhookBlackListSetStr")
-		plan.WebhookCriticalKeywordSetStr = getString("webhookCriticalKeywordSetStr")
-		plan.WebhookTypeSetStr = getString("webhookTypeSetStr")
-		plan.WebhookUrl = getString("webhookUrl")
-		plan.WhitelistNumberLimit = getInt64("whitelistNumberLimit")
-		plan.ZoneNameKey = getString("zoneNameKey")
-
-		// Metric Project Fields
-
-		// JSON String Fields
-		plan.BaseValueSetting = getJSONString("baseValueSetting")
-		plan.CdfSetting = getJSONString("cdfSetting")
-		plan.EmailSetting = getJSONString("emailSetting")
-		plan.InstanceGroupingUpdate = getJSONString("instanceGroupingUpdate")
-		plan.LlmEvaluationSetting = getJSONString("llmEvaluationSetting")
-		plan.LogToLogSettingList = getJSONString("logToLogSettingList")
-		plan.WebhookHeaderList = getJSONString("webhookHeaderList")
-		plan.SharedUsernames = getJSONString("sharedUsernames")
+		// Populate state with actual API values, keeping configured values
+		flattenProjectSettings(&plan, project.Settings)
+		preserveConfiguredSettings(&plan, &config)
 	}
 
 	// Process log_label_settings if provided - each setting must be applied individually
@@ -2300,8 +2192,9 @@ func (r *projectResource) Update(ctx context.Context, req resource.UpdateRequest
 
 			// Apply all settings (function will iterate and call API for each)
 			err := r.client.CreateOrUpdateLogLabels(
+				ctx,
 				plan.ProjectName.ValueString(),
-				r.client.Username,
+				r.client.CustomerName(),
 				settings,
 			)
 			if err != nil {
@@ -2351,13 +2244,44 @@ func (r *projectResource) Delete(ctx context.Context, req resource.DeleteRequest
 		return
 	}
 
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, defaultDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	if state.DeletionProtection.ValueBool() {
+		resp.Diagnostics.AddError(
+			"Project is protected from deletion",
+			fmt.Sprintf("Project %q has deletion_protection enabled, so Terraform will not destroy it or its trained models. ", state.ProjectName.ValueString())+
+				"If the project should really be deleted or replaced, set deletion_protection = false, apply, and then run the destroy again.",
+		)
+		return
+	}
+
+	if state.RetainOnDelete.ValueBool() {
+		tflog.Info(ctx, "Removing project from state without deleting it", map[string]any{"project_name": state.ProjectName.ValueString()})
+		resp.Diagnostics.AddWarning(
+			"Project retained",
+			fmt.Sprintf("Project %q was removed from Terraform state because retain_on_delete is enabled. It still exists in InsightFinder and must be deleted there if it is no longer needed.", state.ProjectName.ValueString()),
+		)
+		return
+	}
+
 	tflog.Info(ctx, "Deleting project", map[string]any{"project_name": state.ProjectName.ValueString()})
 
-	err := r.client.DeleteProject(state.ProjectName.ValueString())
+	err := r.client.DeleteProject(ctx, state.ProjectName.ValueString())
+	if errors.Is(err, client.ErrNotFound) {
+		tflog.Info(ctx, "Project already deleted", map[string]any{"project_name": state.ProjectName.ValueString()})
+		return
+	}
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error deleting project",
-			"Could not delete project, unexpected error: "+err.Error(),
+			"Could not delete project, unexpected error: "+apiErrorDetail(err),
 		)
 		return
 	}
@@ -2367,8 +2291,108 @@ func (r *projectResource) Delete(ctx context.Context, req resource.DeleteRequest
 
 // ImportState imports the resource into Terraform state.
 func (r *projectResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	// Use the ID (project name) as the identifier
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	// Import using project_name as the ID; Read looks the project up by name
+	resource.ImportStatePassthroughID(ctx, path.Root("project_name"), req, resp)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+}
+
+// UpgradeState migrates state written by earlier versions of the schema.
+func (r *projectResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+	current := schemaResp.Schema
+
+	return map[int64]resource.StateUpgrader{
+		// Version 0 stored email_setting and llm_evaluation_setting as JSON
+		// strings
+		0: {
+			PriorSchema: projectSchemaWithJSONSettings(current, 0, "email_setting", "llm_evaluation_setting"),
+			StateUpgrader: func(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+				upgradeJSONSettings(ctx, current, req, resp)
+			},
+		},
+		// Version 1 stored llm_evaluation_setting as a JSON string
+		1: {
+			PriorSchema: projectSchemaWithJSONSettings(current, 1, "llm_evaluation_setting"),
+			StateUpgrader: func(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+				upgradeJSONSettings(ctx, current, req, resp)
+			},
+		},
+	}
+}
+
+// projectSchemaWithJSONSettings returns s as of the given version, with the
+// named nested attributes replaced by the JSON string attributes that
+// version used.
+func projectSchemaWithJSONSettings(s schema.Schema, version int64, names ...string) *schema.Schema {
+	prior := s
+	prior.Version = version
+	prior.Attributes = make(map[string]schema.Attribute, len(s.Attributes))
+	for name, attribute := range s.Attributes {
+		prior.Attributes[name] = attribute
+	}
+	for _, name := range names {
+		prior.Attributes[name] = schema.StringAttribute{
+			Optional: true,
+			Computed: true,
+		}
+	}
+	return &prior
+}
+
+// upgradeJSONSettings converts the nested attributes that the prior state
+// holds as JSON strings and copies everything else unchanged. A string
+// that is not a JSON object becomes null and is refreshed on the next read.
+func upgradeJSONSettings(ctx context.Context, current schema.Schema, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+	var attributes map[string]tftypes.Value
+	if err := req.State.Raw.As(&attributes); err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Upgrade Project State",
+			"Could not read the prior state: "+err.Error(),
+		)
+		return
+	}
+
+	var empty projectResourceModel
+	for _, setting := range projectObjectSettings(&empty) {
+		prior, ok := attributes[setting.Name]
+		if !ok || !prior.Type().Is(tftypes.String) {
+			continue
+		}
+
+		var encoded *string
+		if err := prior.As(&encoded); err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root(setting.Name),
+				"Unable to Upgrade Project State",
+				"Could not read the prior value: "+err.Error(),
+			)
+			return
+		}
+
+		var value interface{}
+		if encoded != nil && *encoded != "" {
+			if err := json.Unmarshal([]byte(*encoded), &value); err != nil {
+				tflog.Warn(ctx, "Dropping unparseable setting from prior state", map[string]any{
+					"attribute": setting.Name,
+					"error":     err.Error(),
+				})
+			}
+		}
+
+		upgraded, err := settingObject(setting, value).ToTerraformValue(ctx)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root(setting.Name),
+				"Unable to Upgrade Project State",
+				"Could not convert the prior value: "+err.Error(),
+			)
+			return
+		}
+		attributes[setting.Name] = upgraded
+	}
+
+	resp.State.Raw = tftypes.NewValue(current.Type().TerraformType(ctx), attributes)
 }
 
 // normalizeJSON parses and re-marshals JSON to normalize formatting
@@ -2395,133 +2419,45 @@ func normalizeJSON(jsonStr string) string {
 }
 
 // convertLogLabelsToState converts API log labels response to Terraform state model
-// while preserving the order from existing state when possible
+// while preserving the order from existing state when possible. Label types
+// the existing state does not hold follow in client.LabelTypes order; API
+// fields of unknown label types are skipped.
 func convertLogLabelsToState(apiLabels map[string]string, existingState []logLabelSettingModel) []logLabelSettingModel {
 	var result []logLabelSettingModel
 
-	// Map from API field names to label types
-	apiFieldToLabelType := map[string]string{
-		"whitelist":                "whitelist",
-		"trainingWhitelist":        "trainingWhitelist",
-		"trainingBlacklistLabels":  "blacklist",
-		"featurelist":              "featurelist",
-		"incidentlist":             "incidentlist",
-		"triagelist":               "triagelist",
-		"patternNameLabels":        "patternName",
-		"patternSignatureLabels":   "patternSignature",
-		"patternMatchRegexLabels":  "patternMatchRegex",
-		"patternIgnoreRegexLabels": "patternIgnoreRegex",
-		"customActionLabels":       "customAction",
-		"logEventIDLabels":         "logEventID",
-		"logSeverityLabels":        "logSeverity",
-		"logStatusCodeLabels":      "logStatusCode",
-		"alertEventTypeLabels":     "alertEventType",
-		"anomalyFeatureLabels":     "anomalyFeature",
-		"dataFilterLabels":         "dataFilter",
-		"instanceNameLabels":       "instanceName",
-		"dataQualityCheckLabels":   "dataQualityCheck",
-		"extractionBlacklist":      "extractionBlacklist",
-	}
-
-	// Reverse map for looking up API fields from label types
-	labelTypeToAPIField := make(map[string]string)
-	for apiField, labelType := range apiFieldToLabelType {
-		labelTypeToAPIField[labelType] = apiField
-	}
-
 	// Create a map of API data for quick lookup
 	apiDataMap := make(map[string]string)
 	for apiField, jsonString := range apiLabels {
-		if labelType, ok := apiFieldToLabelType[apiField]; ok {
+		if labelType, ok := client.LabelTypeForAPIField(apiField); ok {
 			if jsonString != "" && jsonString != "[]" {
-				apiDataMap[labelType] = normalizeJSON(jsonString)
+				apiDataMap[labelType.Name] = normalizeJSON(jsonString)
 			}
 		}
 	}
 
-	// If we have existing state, preserve its order and only include items that exist in API
-	if len(existingState) > 0 {
-		processedTypes := make(map[string]bool)
-
-		// First pass: preserve order from existing state
-		for _, existing := range existingState {
-			labelType := existing.LabelType.ValueString()
-			if normalizedJSON, ok := apiDataMap[labelType]; ok {
-				result = append(result, logLabelSettingModel{
-					LabelType:      types.StringValue(labelType),
-					LogLabelString: types.StringValue(normalizedJSON),
-				})
-				processedTypes[labelType] = true
-			}
-		}
-
-		// Second pass: add any new types from API that weren't in existing state
-		// Use a consistent order for new types
-		defaultOrder := []string{
-			"trainingWhitelist",
-			"featurelist",
-			"incidentlist",
-			"triagelist",
-			"patternName",
-			"whitelist",
-			"blacklist",
-			"patternSignature",
-			"patternMatchRegex",
-			"patternIgnoreRegex",
-			"customAction",
-			"logEventID",
-			"logSeverity",
-			"logStatusCode",
-			"alertEventType",
-			"anomalyFeature",
-			"dataFilter",
-			"instanceName",
-			"dataQualityCheck",
-			"extractionBlacklist",
+	// First pass: preserve order from existing state
+	processedTypes := make(map[string]bool)
+	for _, existing := range existingState {
+		labelType := existing.LabelType.ValueString()
+		if normalizedJSON, ok := apiDataMap[labelType]; ok && !processedTypes[labelType] {
+			result = append(result, logLabelSettingModel{
+				LabelType:      types.StringValue(labelType),
+				LogLabelString: types.StringValue(normalizedJSON),
+			})
+			processedTypes[labelType] = true
 		}
+	}
 
-		for _, labelType := range defaultOrder {
-			if !processedTypes[labelType] {
-				if normalizedJSON, ok := apiDataMap[labelType]; ok {
-					result = append(result, logLabelSettingModel{
-						LabelType:      types.StringValue(labelType),
-						LogLabelString: types.StringValue(normalizedJSON),
-					})
-				}
-			}
-		}
-	} else {
-		// No existing state, use default order
-		labelTypeOrder := []string{
-			"trainingWhitelist",
-			"featurelist",
-			"incidentlist",
-			"triagelist",
-			"patternName",
-			"whitelist",
-			"blacklist",
-			"patternSignature",
-			"patternMatchRegex",
-			"patternIgnoreRegex",
-			"customAction",
-			"logEventID",
-			"logSeverity",
-			"logStatusCode",
-			"alertEventType",
-			"anomalyFeature",
-			"dataFilter",
-			"instanceName",
-			"dataQualityCheck",
-			"extractionBlacklist",
+	// Second pass: add the types from API that weren't in existing state
+	for _, labelType := range client.LabelTypes {
+		if processedTypes[labelType.Name] {
+			continue
 		}
-
-		for _, labelType := range labelTypeOrder {
-			if normalizedJSON, ok := apiDataMap[labelType]; ok {
-				result = append(result, logLabelSettingModel{
-					LabelType:      types.StringValue(labelType),
-					LogLabelString: types.StringValue(normalizedJSON),
-				})
-			}
+		if normalizedJSON, ok := apiDataMap[labelType.Name]; ok {
+			result = append(result, logLabelSettingModel{
+				LabelType:      types.StringValue(labelType.Name),
+				LogLabelString: types.StringValue(normalizedJSON),
+			})
 		}
 	}
 
@@ -4,10 +4,27 @@
 package provider
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+
+	"github.com/insightfinder/terraform-provider-insightfinder/internal/provider/client"
+	"github.com/insightfinder/terraform-provider-insightfinder/internal/provider/client/clienttest"
 )
 
 func TestAccProjectResource(t *testing.T) {
@@ -69,8 +86,9 @@ func TestAccProjectResourceWithAlerting(t *testing.T) {
 				Config: testAccProjectResourceConfigWithAlerting("test-alert-project", "test-system-alert"),
 				Check: resource.ComposeAggregateTestCheckFunc(
 					resource.TestCheckResourceAttr("insightfinder_project.test", "project_name", "test-alert-project"),
-					resource.TestCheckResourceAttr("insightfinder_project.test", "email_config.0.email_recipients.0", "admin@example.com"),
-					resource.TestCheckResourceAttr("insightfinder_project.test", "email_config.0.enable_alert_email", "true"),
+					resource.TestCheckResourceAttr("insightfinder_project.test", "email_setting.enable_alerts_email", "true"),
+					resource.TestCheckResourceAttr("insightfinder_project.test", "email_setting.email_dampening_period", "3600"),
+					resource.TestCheckResourceAttr("insightfinder_project.test", "email_setting.aw_severity_level", "Major"),
 				),
 			},
 		},
@@ -86,14 +104,218 @@ func TestAccProjectResourceWithLLMEvaluation(t *testing.T) {
 				Config: testAccProjectResourceConfigWithLLM("test-llm-project", "test-system-llm"),
 				Check: resource.ComposeAggregateTestCheckFunc(
 					resource.TestCheckResourceAttr("insightfinder_project.test", "project_name", "test-llm-project"),
-					resource.TestCheckResourceAttr("insightfinder_project.test", "llm_evaluation_setting.0.is_hallucination_evaluation", "true"),
-					resource.TestCheckResourceAttr("insightfinder_project.test", "llm_evaluation_setting.0.is_toxicity_evaluation", "true"),
+					resource.TestCheckResourceAttr("insightfinder_project.test", "llm_evaluation_setting.hallucination", "true"),
+					resource.TestCheckResourceAttr("insightfinder_project.test", "llm_evaluation_setting.toxicity", "true"),
+					resource.TestCheckResourceAttr("insightfinder_project.test", "llm_evaluation_setting.pii_phi_leakage", "false"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccProjectResourceWithExtraSettings(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProjectResourceConfigWithExtraSettings("test-extra-project", "test-system-extra", 5),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("insightfinder_project.test", "extra_settings", `{"compositeRCALimit":5}`),
+				),
+			},
+			{
+				Config: testAccProjectResourceConfigWithExtraSettings("test-extra-project", "test-system-extra", 8),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("insightfinder_project.test", "extra_settings", `{"compositeRCALimit":8}`),
+				),
+			},
+			{
+				Config:      testAccProjectResourceConfigWithConflictingExtraSettings("test-extra-project", "test-system-extra"),
+				ExpectError: regexp.MustCompile("Conflicting Extra Setting"),
+			},
+		},
+	})
+}
+
+func TestAccProjectResourceDrift(t *testing.T) {
+	projectName := "test-drift-project"
+
+	// updateOutOfBand changes project settings the way an edit in the
+	// InsightFinder UI would
+	updateOutOfBand := func(settings map[string]interface{}) func() {
+		return func() {
+			err := testAccAPIClient(t).UpdateProject(context.Background(), &client.ProjectConfig{
+				ProjectName: projectName,
+				Settings:    settings,
+			})
+			if err != nil {
+				t.Fatalf("Failed to update project outside Terraform: %v", err)
+			}
+		}
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProjectResourceConfigDrift(projectName, "test-system-drift"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("insightfinder_project.test", "enable_hot_event", "true"),
+					resource.TestCheckResourceAttr("insightfinder_project.test", "c_value", "3"),
+				),
+			},
+			// A flag turned off in the UI is planned back on
+			{
+				PreConfig:          updateOutOfBand(map[string]interface{}{"enableHotEvent": false}),
+				Config:             testAccProjectResourceConfigDrift(projectName, "test-system-drift"),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			// So is a changed number
+			{
+				PreConfig:          updateOutOfBand(map[string]interface{}{"enableHotEvent": true, "cValue": 5}),
+				Config:             testAccProjectResourceConfigDrift(projectName, "test-system-drift"),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			// Changed JSON settings are detected
+			{
+				PreConfig: updateOutOfBand(map[string]interface{}{
+					"cValue":            3,
+					"webhookHeaderList": []interface{}{map[string]interface{}{"key": "X-Changed", "value": "1"}},
+				}),
+				Config:             testAccProjectResourceConfigDrift(projectName, "test-system-drift"),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			// Applying restores the configuration
+			{
+				Config: testAccProjectResourceConfigDrift(projectName, "test-system-drift"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("insightfinder_project.test", "enable_hot_event", "true"),
+					resource.TestCheckResourceAttr("insightfinder_project.test", "c_value", "3"),
+					resource.TestCheckResourceAttr("insightfinder_project.test", "webhook_header_list", `[{"key":"X-Source","value":"terraform"}]`),
+				),
+			},
+		},
+	})
+}
+
+func TestAccProjectResourceAdoptExisting(t *testing.T) {
+	projectName := "test-adopt-project"
+
+	createOutOfBand := func() {
+		err := testAccAPIClient(t).CreateProject(context.Background(), &client.ProjectConfig{
+			ProjectName:      projectName,
+			SystemName:       "test-system-adopt",
+			DataType:         "Log",
+			InstanceType:     "PrivateCloud",
+			ProjectCloudType: "PrivateCloud",
+		})
+		if err != nil {
+			t.Fatalf("Failed to create project outside Terraform: %v", err)
+		}
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// An existing project is not taken over by default
+			{
+				PreConfig:   createOutOfBand,
+				Config:      testAccProjectResourceConfigAdopt(projectName, "test-system-adopt", false),
+				ExpectError: regexp.MustCompile(`Project already exists`),
+			},
+			{
+				Config: testAccProjectResourceConfigAdopt(projectName, "test-system-adopt", true),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("insightfinder_project.test", "id", projectName),
+					resource.TestCheckResourceAttr("insightfinder_project.test", "adopt_existing", "true"),
+					resource.TestCheckResourceAttr("insightfinder_project.test", "c_value", "4"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccProjectResourceImportExisting(t *testing.T) {
+	projectName := "test-import-project"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// The error for an existing project recommends terraform import
+			{
+				PreConfig: func() {
+					err := testAccAPIClient(t).CreateProject(context.Background(), &client.ProjectConfig{
+						ProjectName:      projectName,
+						SystemName:       "test-system-import",
+						DataType:         "Log",
+						InstanceType:     "PrivateCloud",
+						ProjectCloudType: "PrivateCloud",
+					})
+					if err != nil {
+						t.Fatalf("Failed to create project outside Terraform: %v", err)
+					}
+				},
+				Config:      testAccProjectResourceConfigAdopt(projectName, "test-system-import", false),
+				ExpectError: regexp.MustCompile(`terraform import`),
+			},
+			{
+				Config:             testAccProjectResourceConfigAdopt(projectName, "test-system-import", false),
+				ResourceName:       "insightfinder_project.test",
+				ImportState:        true,
+				ImportStateId:      projectName,
+				ImportStatePersist: true,
+				ImportStateCheck: func(states []*terraform.InstanceState) error {
+					if len(states) != 1 || states[0].Attributes["project_name"] != projectName {
+						return fmt.Errorf("expected one project named %q to be imported, got %v", projectName, states)
+					}
+					return nil
+				},
+			},
+			// The imported project is then managed like any other
+			{
+				Config: testAccProjectResourceConfigAdopt(projectName, "test-system-import", false),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("insightfinder_project.test", "id", projectName),
+					resource.TestCheckResourceAttr("insightfinder_project.test", "c_value", "4"),
 				),
 			},
 		},
 	})
 }
 
+func TestAccProjectResourceDeletionProtection(t *testing.T) {
+	projectName := "test-protected-project"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProjectResourceConfigProtected(projectName, "test-system-protected", true),
+				Check:  resource.TestCheckResourceAttr("insightfinder_project.test", "deletion_protection", "true"),
+			},
+			// Destroying a protected project fails
+			{
+				Config:      testAccProjectResourceConfigProtected(projectName, "test-system-protected", true),
+				Destroy:     true,
+				ExpectError: regexp.MustCompile(`Project is protected from deletion`),
+			},
+			// Turning protection off lets the test's destroy go through
+			{
+				Config: testAccProjectResourceConfigProtected(projectName, "test-system-protected", false),
+				Check:  resource.TestCheckResourceAttr("insightfinder_project.test", "deletion_protection", "false"),
+			},
+		},
+	})
+}
+
 func testAccProjectResourceConfig(projectName, displayName, systemName string) string {
 	return fmt.Sprintf(`
 resource "insightfinder_project" "test" {
@@ -102,13 +324,11 @@ resource "insightfinder_project" "test" {
   system_name          = %[3]q
 
   project_creation_config = {
-    data_type               = "Log"
-    instance_type           = "PrivateCloud"
-    project_cloud_type      = "PrivateCloud"
-    insight_agent_type      = "LogStreaming"
-    project_creation_type   = "Kafka"
-    sampling_interval       = 10
-    sampling_interval_in_seconds = 600
+    data_type             = "Log"
+    instance_type         = "PrivateCloud"
+    project_cloud_type    = "PrivateCloud"
+    insight_agent_type    = "LogStreaming"
+    project_creation_type = "Kafka"
   }
 }
 `, projectName, displayName, systemName)
@@ -122,13 +342,11 @@ resource "insightfinder_project" "test" {
   system_name          = %[3]q
 
   project_creation_config = {
-    data_type               = "Log"
-    instance_type           = "PrivateCloud"
-    project_cloud_type      = "PrivateCloud"
-    insight_agent_type      = "LogStreaming"
-    project_creation_type   = "Kafka"
-    sampling_interval       = 10
-    sampling_interval_in_seconds = 600
+    data_type             = "Log"
+    instance_type         = "PrivateCloud"
+    project_cloud_type    = "PrivateCloud"
+    insight_agent_type    = "LogStreaming"
+    project_creation_type = "Kafka"
   }
 
   sampling_interval = 10
@@ -144,19 +362,19 @@ resource "insightfinder_project" "test" {
   system_name  = %[2]q
 
   project_creation_config = {
-    data_type               = "Log"
-    instance_type           = "PrivateCloud"
-    project_cloud_type      = "PrivateCloud"
-    insight_agent_type      = "LogStreaming"
-    project_creation_type   = "Kafka"
-    sampling_interval       = 10
-    sampling_interval_in_seconds = 600
+    data_type             = "Log"
+    instance_type         = "PrivateCloud"
+    project_cloud_type    = "PrivateCloud"
+    insight_agent_type    = "LogStreaming"
+    project_creation_type = "Kafka"
   }
 
-  email_config = {
-    email_recipients    = ["admin@example.com", "ops@example.com"]
-    enable_alert_email  = true
-    enable_hourly_email = false
+  email_setting = {
+    enable_alerts_email               = true
+    email_dampening_period            = 3600
+    alerts_email_dampening_period     = 3600
+    prediction_email_dampening_period = 3600
+    aw_severity_level                 = "Major"
   }
 }
 `, projectName, systemName)
@@ -169,26 +387,1162 @@ resource "insightfinder_project" "test" {
   system_name  = %[2]q
 
   project_creation_config = {
-    data_type               = "Log"
-    instance_type           = "PrivateCloud"
-    project_cloud_type      = "PrivateCloud"
-    insight_agent_type      = "LogStreaming"
-    project_creation_type   = "Kafka"
-    sampling_interval       = 10
-    sampling_interval_in_seconds = 600
+    data_type             = "Log"
+    instance_type         = "PrivateCloud"
+    project_cloud_type    = "PrivateCloud"
+    insight_agent_type    = "LogStreaming"
+    project_creation_type = "Kafka"
   }
 
   llm_evaluation_setting = {
-    is_hallucination_evaluation      = true
-    is_answer_relevant_evaluation    = false
-    is_logic_consistency_evaluation  = false
-    is_factual_inaccuracy_evaluation = false
-    is_malicious_prompt_evaluation   = false
-    is_toxicity_evaluation           = true
-    is_pii_phi_leakage_evaluation    = false
-    is_topic_guardrails_evaluation   = false
-    is_tone_detection_evaluation     = false
+    hallucination   = true
+    toxicity        = true
+    pii_phi_leakage = false
+  }
+}
+`, projectName, systemName)
+}
+
+func testAccProjectResourceConfigWithExtraSettings(projectName, systemName string, compositeRCALimit int) string {
+	return fmt.Sprintf(`
+resource "insightfinder_project" "test" {
+  project_name = %[1]q
+  system_name  = %[2]q
+
+  project_creation_config = {
+    data_type          = "Log"
+    instance_type      = "PrivateCloud"
+    project_cloud_type = "PrivateCloud"
+  }
+
+  extra_settings = jsonencode({
+    compositeRCALimit = %[3]d
+  })
+}
+`, projectName, systemName, compositeRCALimit)
+}
+
+func testAccProjectResourceConfigWithConflictingExtraSettings(projectName, systemName string) string {
+	return fmt.Sprintf(`
+resource "insightfinder_project" "test" {
+  project_name = %[1]q
+  system_name  = %[2]q
+
+  project_creation_config = {
+    data_type          = "Log"
+    instance_type      = "PrivateCloud"
+    project_cloud_type = "PrivateCloud"
   }
+
+  extra_settings = jsonencode({
+    enableHotEvent = false
+  })
 }
 `, projectName, systemName)
 }
+
+func testAccProjectResourceConfigDrift(projectName, systemName string) string {
+	return fmt.Sprintf(`
+resource "insightfinder_project" "test" {
+  project_name = %[1]q
+  system_name  = %[2]q
+
+  project_creation_config = {
+    data_type          = "Log"
+    instance_type      = "PrivateCloud"
+    project_cloud_type = "PrivateCloud"
+  }
+
+  enable_hot_event    = true
+  c_value             = 3
+  webhook_header_list = jsonencode([{ key = "X-Source", value = "terraform" }])
+}
+`, projectName, systemName)
+}
+
+func testAccProjectResourceConfigAdopt(projectName, systemName string, adopt bool) string {
+	return fmt.Sprintf(`
+resource "insightfinder_project" "test" {
+  project_name   = %[1]q
+  system_name    = %[2]q
+  adopt_existing = %[3]t
+
+  project_creation_config = {
+    data_type          = "Log"
+    instance_type      = "PrivateCloud"
+    project_cloud_type = "PrivateCloud"
+  }
+
+  c_value = 4
+}
+`, projectName, systemName, adopt)
+}
+
+func testAccProjectResourceConfigProtected(projectName, systemName string, protected bool) string {
+	return fmt.Sprintf(`
+resource "insightfinder_project" "test" {
+  project_name        = %[1]q
+  system_name         = %[2]q
+  deletion_protection = %[3]t
+
+  project_creation_config = {
+    data_type          = "Log"
+    instance_type      = "PrivateCloud"
+    project_cloud_type = "PrivateCloud"
+  }
+}
+`, projectName, systemName, protected)
+}
+
+func TestConvertLogLabelsToState(t *testing.T) {
+	tests := []struct {
+		name     string
+		api      map[string]string
+		existing []string
+		expected [][2]string
+	}{
+		{
+			name: "default order",
+			api: map[string]string{
+				"whitelist":         `["ERROR"]`,
+				"trainingWhitelist": `["INFO"]`,
+				"patternNameLabels": `[ "a" ]`,
+			},
+			expected: [][2]string{
+				{"trainingWhitelist", `["INFO"]`},
+				{"patternName", `["a"]`},
+				{"whitelist", `["ERROR"]`},
+			},
+		},
+		{
+			name: "existing order preserved",
+			api: map[string]string{
+				"whitelist":               `["ERROR"]`,
+				"trainingBlacklistLabels": `["DEBUG"]`,
+				"incidentlist":            `["OUTAGE"]`,
+			},
+			existing: []string{"blacklist", "whitelist"},
+			expected: [][2]string{
+				{"blacklist", `["DEBUG"]`},
+				{"whitelist", `["ERROR"]`},
+				{"incidentlist", `["OUTAGE"]`},
+			},
+		},
+		{
+			name: "empty and unknown fields skipped",
+			api: map[string]string{
+				"whitelist":    `[]`,
+				"featurelist":  "",
+				"unknownField": `["x"]`,
+			},
+			expected: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var existing []logLabelSettingModel
+			for _, labelType := range tt.existing {
+				existing = append(existing, logLabelSettingModel{
+					LabelType:      types.StringValue(labelType),
+					LogLabelString: types.StringValue("[]"),
+				})
+			}
+
+			result := convertLogLabelsToState(tt.api, existing)
+			if len(result) != len(tt.expected) {
+				t.Fatalf("Expected %d settings, got %d: %v", len(tt.expected), len(result), result)
+			}
+			for i, expected := range tt.expected {
+				if result[i].LabelType.ValueString() != expected[0] || result[i].LogLabelString.ValueString() != expected[1] {
+					t.Errorf("Expected setting %d to be %v, got %v", i, expected, result[i])
+				}
+			}
+		})
+	}
+}
+
+func TestPopulateSettings(t *testing.T) {
+	tests := []struct {
+		name         string
+		model        projectResourceModel
+		expected     map[string]interface{}
+		expectErrors []path.Path
+	}{
+		{
+			name: "explicit zero values are sent",
+			model: projectResourceModel{
+				EnableHotEvent: types.BoolValue(false),
+				HotNumberLimit: types.Int64Value(0),
+				Proxy:          types.StringValue(""),
+				PValue:         types.Float64Value(0),
+			},
+			expected: map[string]interface{}{
+				"enableHotEvent": false,
+				"hotNumberLimit": int64(0),
+				"proxy":          "",
+				"pValue":         float64(0),
+			},
+		},
+		{
+			name: "null and unknown values are left out",
+			model: projectResourceModel{
+				ProjectName:   types.StringValue("test-project"),
+				NlpFlag:       types.BoolNull(),
+				MultiLineFlag: types.BoolUnknown(),
+				CValue:        types.Int64Unknown(),
+			},
+			expected: map[string]interface{}{
+				"projectName": "test-project",
+			},
+		},
+		{
+			name: "extra settings are merged",
+			model: projectResourceModel{
+				NlpFlag:       types.BoolValue(true),
+				ExtraSettings: types.StringValue(`{"compositeRCALimit":3,"newSetting":"on"}`),
+			},
+			expected: map[string]interface{}{
+				"nlpFlag":           true,
+				"compositeRCALimit": float64(3),
+				"newSetting":        "on",
+			},
+		},
+		{
+			name: "JSON fields are parsed",
+			model: projectResourceModel{
+				SharedUsernames:  types.StringValue(`["alice","bob"]`),
+				BaseValueSetting: types.StringValue(`{"isSourceProject":false}`),
+				CdfSetting:       types.StringValue(""),
+			},
+			expected: map[string]interface{}{
+				"sharedUsernames":  []interface{}{"alice", "bob"},
+				"baseValueSetting": map[string]interface{}{"isSourceProject": false},
+			},
+		},
+		{
+			name: "disabled evaluations are sent",
+			model: projectResourceModel{
+				LlmEvaluationSetting: settingObject(
+					projectObjectSettings(&projectResourceModel{})["llmEvaluationSetting"],
+					map[string]interface{}{"isToxicityEvaluation": false, "isAgeBiasEvaluation": true},
+				),
+			},
+			expected: map[string]interface{}{
+				"llmEvaluationSetting": map[string]interface{}{
+					"isToxicityEvaluation": false,
+					"isAgeBiasEvaluation":  true,
+				},
+			},
+		},
+		{
+			name: "only configured nested attributes are sent",
+			model: projectResourceModel{
+				EmailSetting: types.ObjectValueMust(emailSettingAttrTypes, map[string]attr.Value{
+					"only_send_with_rca":                     types.BoolValue(false),
+					"enable_notification_aw":                 types.BoolNull(),
+					"enable_incident_prediction_email_alert": types.BoolUnknown(),
+					"enable_incident_detection_email_alert":  types.BoolNull(),
+					"enable_alerts_email":                    types.BoolValue(true),
+					"enable_root_cause_email_alert":          types.BoolNull(),
+					"email_dampening_period":                 types.Int64Value(0),
+					"alerts_email_dampening_period":          types.Int64Null(),
+					"prediction_email_dampening_period":      types.Int64Null(),
+					"aw_severity_level":                      types.StringValue("Major"),
+				}),
+			},
+			expected: map[string]interface{}{
+				"emailSetting": map[string]interface{}{
+					"onlySendWithRCA":      false,
+					"enableAlertsEmail":    true,
+					"emailDampeningPeriod": int64(0),
+					"awSeverityLevel":      "Major",
+				},
+			},
+		},
+		{
+			name: "malformed JSON fields are reported",
+			model: projectResourceModel{
+				NlpFlag:         types.BoolValue(true),
+				CdfSetting:      types.StringValue(`{}`),
+				SharedUsernames: types.StringValue(`alice`),
+				ExtraSettings:   types.StringValue(`[1]`),
+			},
+			expected: map[string]interface{}{
+				"nlpFlag": true,
+			},
+			expectErrors: []path.Path{
+				path.Root("cdf_setting"),
+				path.Root("extra_settings"),
+				path.Root("shared_usernames"),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			settings, diags := populateSettings(&tt.model)
+			if !reflect.DeepEqual(settings, tt.expected) {
+				t.Errorf("Expected settings %v, got %v", tt.expected, settings)
+			}
+
+			var errorPaths []path.Path
+			for _, d := range diags.Errors() {
+				errorPaths = append(errorPaths, d.(diag.DiagnosticWithPath).Path())
+			}
+			sort.Slice(errorPaths, func(i, j int) bool { return errorPaths[i].String() < errorPaths[j].String() })
+			if !reflect.DeepEqual(errorPaths, tt.expectErrors) {
+				t.Errorf("Expected errors at %v, got %v", tt.expectErrors, diags.Errors())
+			}
+		})
+	}
+}
+
+func TestProjectResourceValidateConfig(t *testing.T) {
+	tests := []struct {
+		name          string
+		extraSettings types.String
+		expectErrors  int
+	}{
+		{
+			name:          "unset",
+			extraSettings: types.StringNull(),
+		},
+		{
+			name:          "unknown",
+			extraSettings: types.StringUnknown(),
+		},
+		{
+			name:          "unmodeled settings",
+			extraSettings: types.StringValue(`{"compositeRCALimit":3}`),
+		},
+		{
+			// Reported by the attribute's validator instead
+			name:          "not an object",
+			extraSettings: types.StringValue(`[1,2]`),
+		},
+		{
+			name:          "conflicts with attributes",
+			extraSettings: types.StringValue(`{"enableHotEvent":false,"emailSetting":{},"projectName":"x","compositeRCALimit":3}`),
+			expectErrors:  3,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			r, s := newUnitTestResource(t, NewProjectResource, clienttest.New("test_user"))
+			state := newUnitTestState(t, s, projectResourceModel{
+				ProjectName:          types.StringValue("test-project"),
+				ExtraSettings:        tt.extraSettings,
+				LogLabelSettings:     types.ListNull(types.ObjectType{AttrTypes: map[string]attr.Type{"label_type": types.StringType, "log_label_string": types.StringType}}),
+				EmailSetting:         types.ObjectNull(emailSettingAttrTypes),
+				LlmEvaluationSetting: types.ObjectNull(llmEvaluationSettingAttrTypes()),
+				Timeouts:             nullTimeouts,
+			})
+
+			resp := &fwresource.ValidateConfigResponse{}
+			r.(fwresource.ResourceWithValidateConfig).ValidateConfig(ctx, fwresource.ValidateConfigRequest{
+				Config: tfsdk.Config{Schema: state.Schema, Raw: state.Raw},
+			}, resp)
+
+			if got := resp.Diagnostics.ErrorsCount(); got != tt.expectErrors {
+				t.Errorf("Expected %d errors, got %d: %v", tt.expectErrors, got, resp.Diagnostics.Errors())
+			}
+		})
+	}
+}
+
+func TestProjectResourceSchemaValidators(t *testing.T) {
+	ctx := context.Background()
+	_, s := newUnitTestResource(t, NewProjectResource, clienttest.New("test_user"))
+
+	stringCases := []struct {
+		path  path.Path
+		valid string
+		bad   string
+	}{
+		{path.Root("project_creation_config").AtName("data_type"), "Log", "Logs"},
+		{path.Root("project_creation_config").AtName("instance_type"), "PrivateCloud", "Private Cloud"},
+		{path.Root("project_creation_config").AtName("project_cloud_type"), "AWS", "aws"},
+		{path.Root("project_creation_config").AtName("insight_agent_type"), "LogStreaming", "Streaming"},
+		{path.Root("project_time_zone"), "America/New_York", "EST5"},
+		{path.Root("similarity_sensitivity"), "0.9", "high"},
+		{path.Root("feature_outlier_sensitivity"), "0.5", "2"},
+		{path.Root("escalation_anomaly_score_threshold"), "50", "-1"},
+		{path.Root("cdf_setting"), `[{"a":1}]`, `{}`},
+		{path.Root("shared_usernames"), `["alice"]`, `alice`},
+		{path.Root("webhook_header_list"), `[{"key":"a","value":"b"}]`, `["a"]`},
+		{path.Root("extra_settings"), `{"compositeRCALimit":3}`, `null`},
+	}
+	for _, tc := range stringCases {
+		attribute, diags := s.AttributeAtPath(ctx, tc.path)
+		if diags.HasError() {
+			t.Fatalf("Missing attribute %s: %v", tc.path, diags)
+		}
+		validators := attribute.(interface{ StringValidators() []validator.String }).StringValidators()
+		for value, wantError := range map[string]bool{tc.valid: false, tc.bad: true} {
+			resp := &validator.StringResponse{}
+			for _, v := range validators {
+				v.ValidateString(ctx, validator.StringRequest{Path: tc.path, ConfigValue: types.StringValue(value)}, resp)
+			}
+			if resp.Diagnostics.HasError() != wantError {
+				t.Errorf("%s = %q: expected error %t, got %v", tc.path, value, wantError, resp.Diagnostics)
+			}
+		}
+	}
+
+	floatCases := []struct {
+		path  path.Path
+		valid float64
+		bad   float64
+	}{
+		{path.Root("p_value"), 0.9, 1.5},
+		{path.Root("root_cause_probability_threshold"), 0.8, -0.1},
+		{path.Root("feature_outlier_threshold"), 3.5, -1},
+	}
+	for _, tc := range floatCases {
+		attribute, diags := s.AttributeAtPath(ctx, tc.path)
+		if diags.HasError() {
+			t.Fatalf("Missing attribute %s: %v", tc.path, diags)
+		}
+		validators := attribute.(interface{ Float64Validators() []validator.Float64 }).Float64Validators()
+		for value, wantError := range map[float64]bool{tc.valid: false, tc.bad: true} {
+			resp := &validator.Float64Response{}
+			for _, v := range validators {
+				v.ValidateFloat64(ctx, validator.Float64Request{Path: tc.path, ConfigValue: types.Float64Value(value)}, resp)
+			}
+			if resp.Diagnostics.HasError() != wantError {
+				t.Errorf("%s = %g: expected error %t, got %v", tc.path, value, wantError, resp.Diagnostics)
+			}
+		}
+	}
+
+	intCases := []struct {
+		path  path.Path
+		valid int64
+		bad   int64
+	}{
+		{path.Root("c_value"), 3, 0},
+		{path.Root("hot_event_threshold"), 10, -1},
+	}
+	for _, tc := range intCases {
+		attribute, diags := s.AttributeAtPath(ctx, tc.path)
+		if diags.HasError() {
+			t.Fatalf("Missing attribute %s: %v", tc.path, diags)
+		}
+		validators := attribute.(interface{ Int64Validators() []validator.Int64 }).Int64Validators()
+		for value, wantError := range map[int64]bool{tc.valid: false, tc.bad: true} {
+			resp := &validator.Int64Response{}
+			for _, v := range validators {
+				v.ValidateInt64(ctx, validator.Int64Request{Path: tc.path, ConfigValue: types.Int64Value(value)}, resp)
+			}
+			if resp.Diagnostics.HasError() != wantError {
+				t.Errorf("%s = %d: expected error %t, got %v", tc.path, value, wantError, resp.Diagnostics)
+			}
+		}
+	}
+}
+
+func TestRefreshExtraSettings(t *testing.T) {
+	settings := map[string]interface{}{
+		"compositeRCALimit": float64(7),
+		"newSetting":        "remote",
+		"unrelated":         true,
+	}
+
+	tests := []struct {
+		name     string
+		current  types.String
+		expected types.String
+	}{
+		{
+			name:     "unset stays unset",
+			current:  types.StringNull(),
+			expected: types.StringNull(),
+		},
+		{
+			name:     "configured keys take API values",
+			current:  types.StringValue(`{"newSetting":"local","compositeRCALimit":3}`),
+			expected: types.StringValue(`{"compositeRCALimit":7,"newSetting":"remote"}`),
+		},
+		{
+			name:     "keys missing from the API are dropped",
+			current:  types.StringValue(`{"gone":1,"newSetting":"remote"}`),
+			expected: types.StringValue(`{"newSetting":"remote"}`),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := refreshExtraSettings(tt.current, settings); !got.Equal(tt.expected) {
+				t.Errorf("Expected %s, got %s", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestFlattenProjectSettings(t *testing.T) {
+	settings := map[string]interface{}{
+		"projectDisplayName":    "From API",
+		"cValue":                float64(4),
+		"pValue":                "0.9",
+		"enableHotEvent":        false,
+		"nlpFlag":               "true",
+		"hotNumberLimit":        "12",
+		"causalMinDelay":        float64(30),
+		"emailSetting":          map[string]interface{}{"enableAlertsEmail": true, "emailDampeningPeriod": float64(3600)},
+		"sharedUsernames":       `[ "alice" ]`,
+		"unmodeled":             "ignored",
+		"compositeRCALimit":     float64(5),
+		"multiHopSearchLimit":   nil,
+		"webhookTypeSetStr":     "",
+		"webhookAlertDampening": float64(0),
+	}
+
+	model := projectResourceModel{
+		ProjectName:     types.StringValue("test-project"),
+		ProjectTimeZone: types.StringValue("UTC"),
+		ExtraSettings:   types.StringValue(`{"compositeRCALimit":1}`),
+	}
+	flattenProjectSettings(&model, settings)
+
+	checks := []struct {
+		name     string
+		got      attr.Value
+		expected attr.Value
+	}{
+		{"project_name untouched", model.ProjectName, types.StringValue("test-project")},
+		{"string", model.ProjectDisplayName, types.StringValue("From API")},
+		{"number", model.CValue, types.Int64Value(4)},
+		{"numeric string float", model.PValue, types.Float64Value(0.9)},
+		{"explicit false", model.EnableHotEvent, types.BoolValue(false)},
+		{"boolean string", model.NlpFlag, types.BoolValue(true)},
+		{"numeric string int", model.HotNumberLimit, types.Int64Value(12)},
+		{"number in string attribute", model.CausalMinDelay, types.StringValue("30")},
+		{"nested object", model.EmailSetting.Attributes()["email_dampening_period"], types.Int64Value(3600)},
+		{"nested object missing key", model.EmailSetting.Attributes()["aw_severity_level"], types.StringNull()},
+		{"JSON text normalized", model.SharedUsernames, types.StringValue(`["alice"]`)},
+		{"missing setting removed", model.ProjectTimeZone, types.StringNull()},
+		{"null setting", model.MultiHopSearchLimit, types.StringNull()},
+		{"empty string", model.WebhookTypeSetStr, types.StringValue("")},
+		{"zero", model.WebhookAlertDampening, types.Int64Value(0)},
+		{"extra settings refreshed", model.ExtraSettings, types.StringValue(`{"compositeRCALimit":5}`)},
+	}
+	for _, check := range checks {
+		if !check.got.Equal(check.expected) {
+			t.Errorf("%s: expected %s, got %s", check.name, check.expected, check.got)
+		}
+	}
+}
+
+func TestPreserveConfiguredSettings(t *testing.T) {
+	var empty projectResourceModel
+	email := projectObjectSettings(&empty)["emailSetting"]
+
+	model := projectResourceModel{
+		CValue:         types.Int64Value(5),
+		EnableHotEvent: types.BoolValue(true),
+		EmailSetting:   settingObject(email, map[string]interface{}{"enableAlertsEmail": true, "emailDampeningPeriod": float64(60)}),
+		ExtraSettings:  types.StringValue(`{"x":2}`),
+	}
+	config := projectResourceModel{
+		CValue:         types.Int64Value(3),
+		EnableHotEvent: types.BoolNull(),
+		EmailSetting:   settingObject(email, map[string]interface{}{"enableAlertsEmail": false}),
+		ExtraSettings:  types.StringValue(`{"x":1}`),
+	}
+	preserveConfiguredSettings(&model, &config)
+
+	if !model.CValue.Equal(types.Int64Value(3)) {
+		t.Errorf("Expected configured c_value to win, got %s", model.CValue)
+	}
+	if !model.EnableHotEvent.Equal(types.BoolValue(true)) {
+		t.Errorf("Expected unconfigured enable_hot_event to keep the API value, got %s", model.EnableHotEvent)
+	}
+	if !model.EmailSetting.Attributes()["enable_alerts_email"].Equal(types.BoolValue(false)) {
+		t.Errorf("Expected configured email_setting.enable_alerts_email to win, got %s", model.EmailSetting)
+	}
+	if !model.EmailSetting.Attributes()["email_dampening_period"].Equal(types.Int64Value(60)) {
+		t.Errorf("Expected unconfigured email_setting.email_dampening_period to keep the API value, got %s", model.EmailSetting)
+	}
+	if !model.ExtraSettings.Equal(types.StringValue(`{"x":1}`)) {
+		t.Errorf("Expected configured extra_settings to win, got %s", model.ExtraSettings)
+	}
+}
+
+func TestProjectResourceReadDetectsDrift(t *testing.T) {
+	ctx := context.Background()
+	fake := clienttest.New("test_user")
+	fake.Projects["test-project"] = &client.ProjectConfig{
+		ProjectName: "test-project",
+		Settings: map[string]interface{}{
+			"projectDisplayName": "Renamed in UI",
+			"enableHotEvent":     false,
+			"cValue":             float64(5),
+		},
+	}
+
+	r, s := newUnitTestResource(t, NewProjectResource, fake)
+	labelType := types.ObjectType{AttrTypes: map[string]attr.Type{"label_type": types.StringType, "log_label_string": types.StringType}}
+	current := newUnitTestState(t, s, projectResourceModel{
+		ID:                   types.StringValue("test-project"),
+		ProjectName:          types.StringValue("test-project"),
+		ProjectDisplayName:   types.StringValue("Test Project"),
+		SystemName:           types.StringValue("test-system"),
+		EnableHotEvent:       types.BoolValue(true),
+		CValue:               types.Int64Value(3),
+		ProjectTimeZone:      types.StringValue("UTC"),
+		LogLabelSettings:     types.ListValueMust(labelType, []attr.Value{}),
+		EmailSetting:         types.ObjectNull(emailSettingAttrTypes),
+		LlmEvaluationSetting: types.ObjectNull(llmEvaluationSettingAttrTypes()),
+		Timeouts:             nullTimeouts,
+	})
+
+	resp := &fwresource.ReadResponse{State: current}
+	r.Read(ctx, fwresource.ReadRequest{State: current}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Expected no error, got: %v", resp.Diagnostics.Errors())
+	}
+
+	var state projectResourceModel
+	resp.State.Get(ctx, &state)
+	if !state.ProjectDisplayName.Equal(types.StringValue("Renamed in UI")) {
+		t.Errorf("Expected display name from the API, got %s", state.ProjectDisplayName)
+	}
+	if !state.EnableHotEvent.Equal(types.BoolValue(false)) {
+		t.Errorf("Expected enable_hot_event from the API, got %s", state.EnableHotEvent)
+	}
+	if !state.CValue.Equal(types.Int64Value(5)) {
+		t.Errorf("Expected c_value from the API, got %s", state.CValue)
+	}
+	if !state.ProjectTimeZone.IsNull() {
+		t.Errorf("Expected project_time_zone missing from the API to be null, got %s", state.ProjectTimeZone)
+	}
+	if !state.SystemName.Equal(types.StringValue("test-system")) {
+		t.Errorf("Expected system_name to be kept, got %s", state.SystemName)
+	}
+}
+
+func TestProjectResourceCreateSettingsRejected(t *testing.T) {
+	ctx := context.Background()
+	fake := clienttest.New("test_user")
+	fake.Errors["UpdateProject"] = errors.New("invalid cValue")
+
+	r, s := newUnitTestResource(t, NewProjectResource, fake)
+	labelType := types.ObjectType{AttrTypes: map[string]attr.Type{"label_type": types.StringType, "log_label_string": types.StringType}}
+	plan := newUnitTestPlan(t, s, projectResourceModel{
+		ProjectName:        types.StringValue("test-project"),
+		ProjectDisplayName: types.StringValue("Test Project"),
+		SystemName:         types.StringValue("test-system"),
+		ProjectCreationConfig: &projectCreationConfigModel{
+			DataType:         types.StringValue("Log"),
+			InstanceType:     types.StringValue("PrivateCloud"),
+			ProjectCloudType: types.StringValue("PrivateCloud"),
+		},
+		CValue:               types.Int64Value(5),
+		ProjectTimeZone:      types.StringValue("UTC"),
+		LogLabelSettings:     types.ListNull(labelType),
+		EmailSetting:         types.ObjectNull(emailSettingAttrTypes),
+		LlmEvaluationSetting: types.ObjectNull(llmEvaluationSettingAttrTypes()),
+		Timeouts:             nullTimeouts,
+	})
+
+	resp := &fwresource.CreateResponse{State: newUnitTestState(t, s, nil)}
+	r.Create(ctx, fwresource.CreateRequest{
+		Plan:   plan,
+		Config: tfsdk.Config{Schema: plan.Schema, Raw: plan.Raw},
+	}, resp)
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("Expected an error when settings cannot be applied, got none")
+	}
+	detail := resp.Diagnostics.Errors()[0].Detail()
+	if !regexp.MustCompile(`Settings not applied: cValue, projectTimeZone`).MatchString(detail) {
+		t.Errorf("Expected rejected settings in the diagnostic, got: %s", detail)
+	}
+
+	// The created project is still tracked so Terraform taints it
+	if resp.State.Raw.IsNull() {
+		t.Fatal("Expected the created project to be saved to state")
+	}
+	var state projectResourceModel
+	resp.State.Get(ctx, &state)
+	if state.ID.ValueString() != "test-project" {
+		t.Errorf("Expected ID 'test-project', got %q", state.ID.ValueString())
+	}
+	if !state.CValue.IsNull() {
+		t.Errorf("Expected c_value to reflect the API, got %s", state.CValue)
+	}
+}
+
+func TestProjectResourceCreateLogLabelsRejected(t *testing.T) {
+	ctx := context.Background()
+	fake := clienttest.New("test_user")
+	fake.Errors["CreateOrUpdateLogLabels"] = errors.New("labels not converged")
+
+	r, s := newUnitTestResource(t, NewProjectResource, fake)
+	labelType := types.ObjectType{AttrTypes: map[string]attr.Type{"label_type": types.StringType, "log_label_string": types.StringType}}
+	plan := newUnitTestPlan(t, s, projectResourceModel{
+		ProjectName: types.StringValue("test-project"),
+		SystemName:  types.StringValue("test-system"),
+		ProjectCreationConfig: &projectCreationConfigModel{
+			DataType:         types.StringValue("Log"),
+			InstanceType:     types.StringValue("PrivateCloud"),
+			ProjectCloudType: types.StringValue("PrivateCloud"),
+		},
+		LogLabelSettings: types.ListValueMust(labelType, []attr.Value{
+			types.ObjectValueMust(labelType.AttrTypes, map[string]attr.Value{
+				"label_type":       types.StringValue("whitelist"),
+				"log_label_string": types.StringValue(`["ERROR"]`),
+			}),
+		}),
+		EmailSetting:         types.ObjectNull(emailSettingAttrTypes),
+		LlmEvaluationSetting: types.ObjectNull(llmEvaluationSettingAttrTypes()),
+		Timeouts:             nullTimeouts,
+	})
+
+	resp := &fwresource.CreateResponse{State: newUnitTestState(t, s, nil)}
+	r.Create(ctx, fwresource.CreateRequest{
+		Plan:   plan,
+		Config: tfsdk.Config{Schema: plan.Schema, Raw: plan.Raw},
+	}, resp)
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("Expected an error when log labels cannot be applied, got none")
+	}
+
+	// The created project is still tracked so Terraform taints it instead
+	// of orphaning it
+	if resp.State.Raw.IsNull() {
+		t.Fatal("Expected the created project to be saved to state")
+	}
+	var state projectResourceModel
+	resp.State.Get(ctx, &state)
+	if state.ID.ValueString() != "test-project" {
+		t.Errorf("Expected ID 'test-project', got %q", state.ID.ValueString())
+	}
+	if !state.SystemName.Equal(types.StringValue("test-system")) {
+		t.Errorf("Expected system_name to be kept, got %s", state.SystemName)
+	}
+}
+
+func TestRejectedSettings(t *testing.T) {
+	sent := map[string]interface{}{
+		"projectName":        "test-project",
+		"cValue":             int64(5),
+		"projectTimeZone":    "UTC",
+		"projectDisplayName": "Test Project",
+	}
+
+	tests := []struct {
+		name     string
+		applied  map[string]interface{}
+		expected []string
+	}{
+		{
+			name:     "no settings read back",
+			applied:  nil,
+			expected: []string{"cValue", "projectDisplayName", "projectTimeZone"},
+		},
+		{
+			name: "partially applied",
+			applied: map[string]interface{}{
+				"cValue":             float64(5),
+				"projectTimeZone":    "America/New_York",
+				"projectDisplayName": "Test Project",
+			},
+			expected: []string{"projectTimeZone"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := rejectedSettings(sent, tt.applied)
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("Expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestProjectResourceCreateExisting(t *testing.T) {
+	labelType := types.ObjectType{AttrTypes: map[string]attr.Type{"label_type": types.StringType, "log_label_string": types.StringType}}
+
+	tests := []struct {
+		name          string
+		adoptExisting bool
+		expectError   bool
+	}{
+		{
+			name:          "fails by default",
+			adoptExisting: false,
+			expectError:   true,
+		},
+		{
+			name:          "adopts when enabled",
+			adoptExisting: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			fake := clienttest.New("test_user")
+			fake.Projects["test-project"] = &client.ProjectConfig{
+				ProjectName: "test-project",
+				Settings: map[string]interface{}{
+					"projectDisplayName": "Someone Else's Project",
+					"cValue":             float64(2),
+					"projectTimeZone":    "UTC",
+				},
+			}
+
+			r, s := newUnitTestResource(t, NewProjectResource, fake)
+			plan := newUnitTestPlan(t, s, projectResourceModel{
+				ProjectName:   types.StringValue("test-project"),
+				SystemName:    types.StringValue("test-system"),
+				AdoptExisting: types.BoolValue(tt.adoptExisting),
+				ProjectCreationConfig: &projectCreationConfigModel{
+					DataType:         types.StringValue("Log"),
+					InstanceType:     types.StringValue("PrivateCloud"),
+					ProjectCloudType: types.StringValue("PrivateCloud"),
+				},
+				CValue:               types.Int64Value(4),
+				LogLabelSettings:     types.ListNull(labelType),
+				EmailSetting:         types.ObjectNull(emailSettingAttrTypes),
+				LlmEvaluationSetting: types.ObjectNull(llmEvaluationSettingAttrTypes()),
+				Timeouts:             nullTimeouts,
+			})
+
+			resp := &fwresource.CreateResponse{State: newUnitTestState(t, s, nil)}
+			r.Create(ctx, fwresource.CreateRequest{
+				Plan:   plan,
+				Config: tfsdk.Config{Schema: plan.Schema, Raw: plan.Raw},
+			}, resp)
+
+			if tt.expectError {
+				if !resp.Diagnostics.HasError() {
+					t.Fatal("Expected an error for an existing project, got none")
+				}
+				if !regexp.MustCompile(`terraform import`).MatchString(resp.Diagnostics.Errors()[0].Detail()) {
+					t.Errorf("Expected the error to point at terraform import, got: %s", resp.Diagnostics.Errors()[0].Detail())
+				}
+				if fake.Called("UpdateProject") {
+					t.Error("Expected the existing project not to be updated")
+				}
+				return
+			}
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("Expected no error, got: %v", resp.Diagnostics.Errors())
+			}
+
+			var state projectResourceModel
+			resp.State.Get(ctx, &state)
+			if !state.CValue.Equal(types.Int64Value(4)) {
+				t.Errorf("Expected the plan to be applied, got c_value %s", state.CValue)
+			}
+			if !state.ProjectTimeZone.Equal(types.StringValue("UTC")) {
+				t.Errorf("Expected unconfigured settings to be read from the project, got %s", state.ProjectTimeZone)
+			}
+			if fake.Projects["test-project"].Settings["cValue"] != float64(4) {
+				t.Errorf("Expected c_value to be sent to the API, got %v", fake.Projects["test-project"].Settings["cValue"])
+			}
+		})
+	}
+}
+
+func TestProjectResourceCreateAdoptedSettingsRejected(t *testing.T) {
+	ctx := context.Background()
+	fake := clienttest.New("test_user")
+	fake.Projects["test-project"] = &client.ProjectConfig{
+		ProjectName: "test-project",
+		Settings: map[string]interface{}{
+			"cValue":          float64(2),
+			"projectTimeZone": "UTC",
+		},
+	}
+	fake.Errors["UpdateProject"] = errors.New("invalid cValue")
+
+	r, s := newUnitTestResource(t, NewProjectResource, fake)
+	labelType := types.ObjectType{AttrTypes: map[string]attr.Type{"label_type": types.StringType, "log_label_string": types.StringType}}
+	plan := newUnitTestPlan(t, s, projectResourceModel{
+		ProjectName:   types.StringValue("test-project"),
+		SystemName:    types.StringValue("test-system"),
+		AdoptExisting: types.BoolValue(true),
+		ProjectCreationConfig: &projectCreationConfigModel{
+			DataType:         types.StringValue("Log"),
+			InstanceType:     types.StringValue("PrivateCloud"),
+			ProjectCloudType: types.StringValue("PrivateCloud"),
+		},
+		CValue:               types.Int64Value(4),
+		LogLabelSettings:     types.ListNull(labelType),
+		EmailSetting:         types.ObjectNull(emailSettingAttrTypes),
+		LlmEvaluationSetting: types.ObjectNull(llmEvaluationSettingAttrTypes()),
+		Timeouts:             nullTimeouts,
+	})
+
+	resp := &fwresource.CreateResponse{State: newUnitTestState(t, s, nil)}
+	r.Create(ctx, fwresource.CreateRequest{
+		Plan:   plan,
+		Config: tfsdk.Config{Schema: plan.Schema, Raw: plan.Raw},
+	}, resp)
+
+	// An error would taint the project, and Terraform would destroy a
+	// project it never created on the next apply
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Expected no error for an adopted project, got: %v", resp.Diagnostics.Errors())
+	}
+	warnings := resp.Diagnostics.Warnings()
+	if len(warnings) != 1 || !regexp.MustCompile(`already existed and was adopted(.|\n)*Settings not applied: cValue`).MatchString(warnings[0].Detail()) {
+		t.Fatalf("Expected a warning naming the rejected settings, got: %v", warnings)
+	}
+
+	var state projectResourceModel
+	resp.State.Get(ctx, &state)
+	if state.ID.ValueString() != "test-project" {
+		t.Errorf("Expected ID 'test-project', got %q", state.ID.ValueString())
+	}
+	if !state.CValue.Equal(types.Int64Value(4)) {
+		t.Errorf("Expected the planned c_value in state so the next refresh shows the drift, got %s", state.CValue)
+	}
+	if fake.Projects["test-project"].Settings["cValue"] != float64(2) {
+		t.Errorf("Expected the project to keep its c_value, got %v", fake.Projects["test-project"].Settings["cValue"])
+	}
+}
+
+func TestProjectResourceImportState(t *testing.T) {
+	ctx := context.Background()
+	fake := clienttest.New("test_user")
+	fake.Projects["test-project"] = &client.ProjectConfig{
+		ProjectName: "test-project",
+		Settings:    map[string]interface{}{"cValue": float64(3)},
+	}
+
+	r, s := newUnitTestResource(t, NewProjectResource, fake)
+	importResp := &fwresource.ImportStateResponse{State: newUnitTestState(t, s, nil)}
+	r.(fwresource.ResourceWithImportState).ImportState(ctx, fwresource.ImportStateRequest{ID: "test-project"}, importResp)
+	if importResp.Diagnostics.HasError() {
+		t.Fatalf("Expected no error, got: %v", importResp.Diagnostics.Errors())
+	}
+
+	// Read looks the project up by project_name, which import has to set
+	readResp := &fwresource.ReadResponse{State: importResp.State}
+	r.Read(ctx, fwresource.ReadRequest{State: importResp.State}, readResp)
+	if readResp.Diagnostics.HasError() {
+		t.Fatalf("Expected no error, got: %v", readResp.Diagnostics.Errors())
+	}
+	if readResp.State.Raw.IsNull() {
+		t.Fatal("Expected the imported project to stay in state")
+	}
+
+	var state projectResourceModel
+	readResp.State.Get(ctx, &state)
+	if state.ID.ValueString() != "test-project" || state.ProjectName.ValueString() != "test-project" {
+		t.Errorf("Expected id and project_name 'test-project', got %s, %s", state.ID, state.ProjectName)
+	}
+	if !state.CValue.Equal(types.Int64Value(3)) {
+		t.Errorf("Expected c_value from the API, got %s", state.CValue)
+	}
+}
+
+func TestProjectResourceUpdatePartialEmailSetting(t *testing.T) {
+	ctx := context.Background()
+	fake := clienttest.New("test_user")
+	fake.Projects["test-project"] = &client.ProjectConfig{
+		ProjectName: "test-project",
+		Settings: map[string]interface{}{
+			"emailSetting": map[string]interface{}{
+				"onlySendWithRCA":      false,
+				"emailDampeningPeriod": float64(3600),
+				"awSeverityLevel":      "Major",
+			},
+		},
+	}
+
+	emailSetting := settingObject(projectObjectSettings(&projectResourceModel{})["emailSetting"], map[string]interface{}{"onlySendWithRCA": true})
+	labelType := types.ObjectType{AttrTypes: map[string]attr.Type{"label_type": types.StringType, "log_label_string": types.StringType}}
+	model := projectResourceModel{
+		ID:                   types.StringValue("test-project"),
+		ProjectName:          types.StringValue("test-project"),
+		SystemName:           types.StringValue("test-system"),
+		LogLabelSettings:     types.ListNull(labelType),
+		EmailSetting:         emailSetting,
+		LlmEvaluationSetting: types.ObjectNull(llmEvaluationSettingAttrTypes()),
+		Timeouts:             nullTimeouts,
+	}
+
+	r, s := newUnitTestResource(t, NewProjectResource, fake)
+	plan := newUnitTestPlan(t, s, model)
+	resp := &fwresource.UpdateResponse{State: newUnitTestState(t, s, model)}
+	r.Update(ctx, fwresource.UpdateRequest{
+		Plan:   plan,
+		Config: tfsdk.Config{Schema: plan.Schema, Raw: plan.Raw},
+		State:  newUnitTestState(t, s, model),
+	}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Expected no error, got: %v", resp.Diagnostics.Errors())
+	}
+
+	stored, _ := fake.Projects["test-project"].Settings["emailSetting"].(map[string]interface{})
+	if stored["onlySendWithRCA"] != true {
+		t.Errorf("Expected only_send_with_rca to be applied, got %v", stored)
+	}
+	if stored["emailDampeningPeriod"] != float64(3600) || stored["awSeverityLevel"] != "Major" {
+		t.Errorf("Expected unset email_setting fields to keep their values, got %v", stored)
+	}
+}
+
+func TestProjectResourceDelete(t *testing.T) {
+	labelType := types.ObjectType{AttrTypes: map[string]attr.Type{"label_type": types.StringType, "log_label_string": types.StringType}}
+
+	tests := []struct {
+		name               string
+		deletionProtection bool
+		retainOnDelete     bool
+		expectError        bool
+		expectDeleted      bool
+	}{
+		{
+			name:          "deletes by default",
+			expectDeleted: true,
+		},
+		{
+			name:               "deletion protection blocks delete",
+			deletionProtection: true,
+			retainOnDelete:     true,
+			expectError:        true,
+		},
+		{
+			name:           "retain on delete keeps the project",
+			retainOnDelete: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			fake := clienttest.New("test_user")
+			fake.Projects["test-project"] = &client.ProjectConfig{ProjectName: "test-project"}
+
+			r, s := newUnitTestResource(t, NewProjectResource, fake)
+			state := newUnitTestState(t, s, projectResourceModel{
+				ID:                   types.StringValue("test-project"),
+				ProjectName:          types.StringValue("test-project"),
+				SystemName:           types.StringValue("test-system"),
+				DeletionProtection:   types.BoolValue(tt.deletionProtection),
+				RetainOnDelete:       types.BoolValue(tt.retainOnDelete),
+				LogLabelSettings:     types.ListNull(labelType),
+				EmailSetting:         types.ObjectNull(emailSettingAttrTypes),
+				LlmEvaluationSetting: types.ObjectNull(llmEvaluationSettingAttrTypes()),
+				Timeouts:             nullTimeouts,
+			})
+
+			resp := &fwresource.DeleteResponse{State: state}
+			r.Delete(ctx, fwresource.DeleteRequest{State: state}, resp)
+
+			if tt.expectError != resp.Diagnostics.HasError() {
+				t.Fatalf("Expected error %t, got: %v", tt.expectError, resp.Diagnostics.Errors())
+			}
+			if _, exists := fake.Projects["test-project"]; exists == tt.expectDeleted {
+				t.Errorf("Expected project deleted %t, but it exists %t", tt.expectDeleted, exists)
+			}
+		})
+	}
+}
+
+func TestProjectResourceUpgradeState(t *testing.T) {
+	ctx := context.Background()
+	r, current := newUnitTestResource(t, NewProjectResource, clienttest.New("test_user"))
+	upgraders := r.(fwresource.ResourceWithUpgradeState).UpgradeState(ctx)
+	llm := projectObjectSettings(&projectResourceModel{})["llmEvaluationSetting"]
+
+	upgradedEmail := types.ObjectValueMust(emailSettingAttrTypes, map[string]attr.Value{
+		"only_send_with_rca":                     types.BoolNull(),
+		"enable_notification_aw":                 types.BoolNull(),
+		"enable_incident_prediction_email_alert": types.BoolNull(),
+		"enable_incident_detection_email_alert":  types.BoolNull(),
+		"enable_alerts_email":                    types.BoolValue(true),
+		"enable_root_cause_email_alert":          types.BoolNull(),
+		"email_dampening_period":                 types.Int64Value(3600),
+		"alerts_email_dampening_period":          types.Int64Null(),
+		"prediction_email_dampening_period":      types.Int64Null(),
+		"aw_severity_level":                      types.StringValue("Major"),
+	})
+	upgradedLLM := settingObject(llm, map[string]interface{}{"isToxicityEvaluation": true, "isHallucinationEvaluation": false})
+
+	tests := []struct {
+		name          string
+		version       int64
+		emailSetting  attr.Value
+		llmSetting    types.String
+		expectedEmail types.Object
+		expectedLLM   types.Object
+	}{
+		{
+			name:          "version 0 JSON objects",
+			version:       0,
+			emailSetting:  types.StringValue(`{"enableAlertsEmail":true,"emailDampeningPeriod":3600,"awSeverityLevel":"Major"}`),
+			llmSetting:    types.StringValue(`{"isToxicityEvaluation":true,"isHallucinationEvaluation":false}`),
+			expectedEmail: upgradedEmail,
+			expectedLLM:   upgradedLLM,
+		},
+		{
+			name:          "version 0 null",
+			version:       0,
+			emailSetting:  types.StringNull(),
+			llmSetting:    types.StringNull(),
+			expectedEmail: types.ObjectNull(emailSettingAttrTypes),
+			expectedLLM:   types.ObjectNull(llm.AttrTypes),
+		},
+		{
+			name:          "version 0 not JSON",
+			version:       0,
+			emailSetting:  types.StringValue("not json"),
+			llmSetting:    types.StringValue(""),
+			expectedEmail: types.ObjectNull(emailSettingAttrTypes),
+			expectedLLM:   types.ObjectNull(llm.AttrTypes),
+		},
+		{
+			name:          "version 1 keeps email_setting",
+			version:       1,
+			emailSetting:  upgradedEmail,
+			llmSetting:    types.StringValue(`{"isToxicityEvaluation":true,"isHallucinationEvaluation":false}`),
+			expectedEmail: upgradedEmail,
+			expectedLLM:   upgradedLLM,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			upgrader := upgraders[tt.version]
+			prior := tfsdk.State{
+				Schema: *upgrader.PriorSchema,
+				Raw:    tftypes.NewValue(upgrader.PriorSchema.Type().TerraformType(ctx), nil),
+			}
+			prior.SetAttribute(ctx, path.Root("project_name"), types.StringValue("test-project"))
+			prior.SetAttribute(ctx, path.Root("email_setting"), tt.emailSetting)
+			prior.SetAttribute(ctx, path.Root("llm_evaluation_setting"), tt.llmSetting)
+
+			resp := &fwresource.UpgradeStateResponse{State: tfsdk.State{Schema: current}}
+			upgrader.StateUpgrader(ctx, fwresource.UpgradeStateRequest{State: &prior}, resp)
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("Expected no error, got: %v", resp.Diagnostics.Errors())
+			}
+
+			var state projectResourceModel
+			if diags := resp.State.Get(ctx, &state); diags.HasError() {
+				t.Fatalf("Expected upgraded state to match the schema, got: %v", diags.Errors())
+			}
+			if state.ProjectName.ValueString() != "test-project" {
+				t.Errorf("Expected project_name to be kept, got %s", state.ProjectName)
+			}
+			if !state.EmailSetting.Equal(tt.expectedEmail) {
+				t.Errorf("Expected email_setting %s, got %s", tt.expectedEmail, state.EmailSetting)
+			}
+			if !state.LlmEvaluationSetting.Equal(tt.expectedLLM) {
+				t.Errorf("Expected llm_evaluation_setting %s, got %s", tt.expectedLLM, state.LlmEvaluationSetting)
+			}
+		})
+	}
+}
@@ -0,0 +1,436 @@
+// Copyright (c) InsightFinder Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/insightfinder/terraform-provider-insightfinder/internal/provider/client"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &projectWebhookResource{}
+	_ resource.ResourceWithConfigure   = &projectWebhookResource{}
+	_ resource.ResourceWithImportState = &projectWebhookResource{}
+)
+
+// NewProjectWebhookResource is a helper function to simplify the provider implementation.
+func NewProjectWebhookResource() resource.Resource {
+	return &projectWebhookResource{}
+}
+
+// projectWebhookResource is the resource implementation.
+type projectWebhookResource struct {
+	client client.InsightFinderAPI
+}
+
+// projectWebhookResourceModel maps the resource schema data.
+type projectWebhookResourceModel struct {
+	ID                types.String   `tfsdk:"id"`
+	ProjectName       types.String   `tfsdk:"project_name"`
+	URL               types.String   `tfsdk:"url"`
+	Headers           types.Map      `tfsdk:"headers"`
+	EventTypes        types.Set      `tfsdk:"event_types"`
+	BlacklistKeywords types.Set      `tfsdk:"blacklist_keywords"`
+	CriticalKeywords  types.Set      `tfsdk:"critical_keywords"`
+	AlertDampening    types.Int64    `tfsdk:"alert_dampening"`
+	Timeouts          timeouts.Value `tfsdk:"timeouts"`
+}
+
+// Metadata returns the resource type name.
+func (r *projectWebhookResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_project_webhook"
+}
+
+// Schema defines the schema for the resource.
+func (r *projectWebhookResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages the webhook notifications of an InsightFinder project. Do not also set the webhook_* attributes of the insightfinder_project resource for the same project.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Identifier for the webhook (project_name).",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"project_name": schema.StringAttribute{
+				Description: "The name of the project to send webhooks for.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"url": schema.StringAttribute{
+				Description: "The http or https URL notifications are posted to.",
+				Required:    true,
+				Validators: []validator.String{
+					httpURL(),
+				},
+			},
+			"headers": schema.MapAttribute{
+				Description: "HTTP headers sent with every notification, such as an Authorization token.",
+				ElementType: types.StringType,
+				Optional:    true,
+				Sensitive:   true,
+				Validators: []validator.Map{
+					mapvalidator.KeysAre(stringvalidator.LengthAtLeast(1)),
+				},
+			},
+			"event_types": schema.SetAttribute{
+				Description: "Event types that trigger a notification, e.g. log, detectedIncident, predictedIncident or detectedIncidentWithRC.",
+				ElementType: types.StringType,
+				Optional:    true,
+				Validators: []validator.Set{
+					setvalidator.ValueStringsAre(stringvalidator.LengthAtLeast(1)),
+				},
+			},
+			"blacklist_keywords": schema.SetAttribute{
+				Description: "Keywords that suppress a notification when the event contains them.",
+				ElementType: types.StringType,
+				Optional:    true,
+				Validators: []validator.Set{
+					setvalidator.ValueStringsAre(stringvalidator.LengthAtLeast(1)),
+				},
+			},
+			"critical_keywords": schema.SetAttribute{
+				Description: "Keywords that mark an event as critical.",
+				ElementType: types.StringType,
+				Optional:    true,
+				Validators: []validator.Set{
+					setvalidator.ValueStringsAre(stringvalidator.LengthAtLeast(1)),
+				},
+			},
+			"alert_dampening": schema.Int64Attribute{
+				Description: "Minimum time between notifications for the same alert. When not set, the value configured in InsightFinder is kept.",
+				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
+		},
+	}
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *projectWebhookResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(client.InsightFinderAPI)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected client.InsightFinderAPI, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *projectWebhookResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan projectWebhookResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	tflog.Debug(ctx, "Creating project webhook", map[string]interface{}{
+		"project_name": plan.ProjectName.ValueString(),
+	})
+
+	config, diags := webhookConfigFromModel(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.UpdateWebhookConfig(ctx, config)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Creating Project Webhook",
+			fmt.Sprintf("Could not configure the webhook of project '%s': %s", plan.ProjectName.ValueString(), apiErrorDetail(err)),
+		)
+		return
+	}
+
+	plan.ID = plan.ProjectName
+	plan.AlertDampening, err = r.appliedAlertDampening(ctx, config)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Creating Project Webhook",
+			fmt.Sprintf("Could not read back the webhook of project '%s': %s", plan.ProjectName.ValueString(), apiErrorDetail(err)),
+		)
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *projectWebhookResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state projectWebhookResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	readTimeout, diags := state.Timeouts.Read(ctx, defaultReadTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	tflog.Debug(ctx, "Reading project webhook", map[string]interface{}{
+		"project_name": state.ProjectName.ValueString(),
+	})
+
+	// A project without a webhook URL, or without the project itself, has
+	// no webhook to manage
+	config, err := r.client.GetWebhookConfig(ctx, state.ProjectName.ValueString(), r.client.CustomerName())
+	if errors.Is(err, client.ErrNotFound) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Project Webhook",
+			"Could not read project webhook: "+apiErrorDetail(err),
+		)
+		return
+	}
+
+	diags = state.setFromWebhookConfig(ctx, config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *projectWebhookResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan projectWebhookResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	tflog.Debug(ctx, "Updating project webhook", map[string]interface{}{
+		"project_name": plan.ProjectName.ValueString(),
+	})
+
+	config, diags := webhookConfigFromModel(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.UpdateWebhookConfig(ctx, config)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Updating Project Webhook",
+			"Could not update project webhook: "+apiErrorDetail(err),
+		)
+		return
+	}
+
+	plan.AlertDampening, err = r.appliedAlertDampening(ctx, config)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Updating Project Webhook",
+			"Could not read back project webhook: "+apiErrorDetail(err),
+		)
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *projectWebhookResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state projectWebhookResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, defaultDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	tflog.Debug(ctx, "Deleting project webhook", map[string]interface{}{
+		"project_name": state.ProjectName.ValueString(),
+	})
+
+	// Clearing the webhook of a deleted project leaves nothing to do
+	err := r.client.DeleteWebhookConfig(ctx, state.ProjectName.ValueString())
+	if errors.Is(err, client.ErrNotFound) {
+		tflog.Debug(ctx, "Project not found, considering webhook as already deleted")
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Deleting Project Webhook",
+			"Could not delete project webhook: "+apiErrorDetail(err),
+		)
+		return
+	}
+}
+
+// ImportState imports the resource state.
+func (r *projectWebhookResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// Import using project_name as the ID
+	resource.ImportStatePassthroughID(ctx, path.Root("project_name"), req, resp)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+}
+
+// appliedAlertDampening returns the alert dampening to store after config
+// was written: the configured value, or the project's current value when
+// the dampening was left unset and therefore not written.
+func (r *projectWebhookResource) appliedAlertDampening(ctx context.Context, config *client.WebhookConfig) (types.Int64, error) {
+	if config.AlertDampening != nil {
+		return types.Int64Value(int64(*config.AlertDampening)), nil
+	}
+
+	current, err := r.client.GetWebhookConfig(ctx, config.ProjectName, r.client.CustomerName())
+	if err != nil {
+		return types.Int64Unknown(), err
+	}
+	return types.Int64Value(int64(*current.AlertDampening)), nil
+}
+
+// webhookConfigFromModel converts the resource model to the client's
+// webhook configuration. Unset sets and headers clear the setting; an unset
+// alert dampening is left out so the project's current value is kept.
+func webhookConfigFromModel(ctx context.Context, m *projectWebhookResourceModel) (*client.WebhookConfig, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	config := &client.WebhookConfig{
+		ProjectName: m.ProjectName.ValueString(),
+		URL:         m.URL.ValueString(),
+	}
+
+	if !m.Headers.IsNull() && !m.Headers.IsUnknown() {
+		diags.Append(m.Headers.ElementsAs(ctx, &config.Headers, false)...)
+	}
+	for field, values := range map[*types.Set]*[]string{
+		&m.EventTypes:        &config.EventTypes,
+		&m.BlacklistKeywords: &config.BlacklistKeywords,
+		&m.CriticalKeywords:  &config.CriticalKeywords,
+	} {
+		if !field.IsNull() && !field.IsUnknown() {
+			diags.Append(field.ElementsAs(ctx, values, false)...)
+		}
+	}
+	if !m.AlertDampening.IsNull() && !m.AlertDampening.IsUnknown() {
+		dampening := int(m.AlertDampening.ValueInt64())
+		config.AlertDampening = &dampening
+	}
+
+	return config, diags
+}
+
+// setFromWebhookConfig sets the model from the webhook configuration read
+// from the API. Empty sets and headers stay null when they were not set.
+func (m *projectWebhookResourceModel) setFromWebhookConfig(ctx context.Context, config *client.WebhookConfig) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	m.ID = types.StringValue(config.ProjectName)
+	m.ProjectName = types.StringValue(config.ProjectName)
+	m.URL = types.StringValue(config.URL)
+	if config.AlertDampening != nil {
+		m.AlertDampening = types.Int64Value(int64(*config.AlertDampening))
+	}
+
+	if len(config.Headers) > 0 || !m.Headers.IsNull() {
+		headers, d := types.MapValueFrom(ctx, types.StringType, config.Headers)
+		diags.Append(d...)
+		m.Headers = headers
+	}
+	for field, values := range map[*types.Set][]string{
+		&m.EventTypes:        config.EventTypes,
+		&m.BlacklistKeywords: config.BlacklistKeywords,
+		&m.CriticalKeywords:  config.CriticalKeywords,
+	} {
+		if len(values) == 0 && field.IsNull() {
+			continue
+		}
+		if values == nil {
+			values = []string{}
+		}
+		set, d := types.SetValueFrom(ctx, types.StringType, values)
+		diags.Append(d...)
+		*field = set
+	}
+
+	return diags
+}
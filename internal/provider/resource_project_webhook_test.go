@@ -0,0 +1,257 @@
+// Copyright (c) InsightFinder Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+
+	"github.com/insightfinder/terraform-provider-insightfinder/internal/provider/client"
+	"github.com/insightfinder/terraform-provider-insightfinder/internal/provider/client/clienttest"
+)
+
+func TestAccProjectWebhookResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccProjectWebhookResourceConfig("test-webhook-project", "https://hooks.example.com/incidents", "detectedIncident"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("insightfinder_project_webhook.test", "id", "test-webhook-project"),
+					resource.TestCheckResourceAttr("insightfinder_project_webhook.test", "url", "https://hooks.example.com/incidents"),
+					resource.TestCheckResourceAttr("insightfinder_project_webhook.test", "headers.Authorization", "Bearer token"),
+					resource.TestCheckResourceAttr("insightfinder_project_webhook.test", "event_types.#", "2"),
+					resource.TestCheckTypeSetElemAttr("insightfinder_project_webhook.test", "event_types.*", "detectedIncident"),
+					resource.TestCheckResourceAttr("insightfinder_project_webhook.test", "critical_keywords.#", "1"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "insightfinder_project_webhook.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Update and Read testing
+			{
+				Config: testAccProjectWebhookResourceConfig("test-webhook-project", "https://hooks.example.com/v2", "predictedIncident"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("insightfinder_project_webhook.test", "url", "https://hooks.example.com/v2"),
+					resource.TestCheckTypeSetElemAttr("insightfinder_project_webhook.test", "event_types.*", "predictedIncident"),
+				),
+			},
+			// Delete testing automatically occurs at the end
+		},
+	})
+}
+
+func testAccProjectWebhookResourceConfig(projectName, url, eventType string) string {
+	return fmt.Sprintf(`
+resource "insightfinder_project" "test" {
+  project_name = %[1]q
+  system_name  = "test-system-webhook"
+
+  project_creation_config = {
+    data_type          = "Log"
+    instance_type      = "PrivateCloud"
+    project_cloud_type = "PrivateCloud"
+  }
+}
+
+resource "insightfinder_project_webhook" "test" {
+  project_name = insightfinder_project.test.project_name
+  url          = %[2]q
+
+  headers = {
+    Authorization = "Bearer token"
+  }
+
+  event_types       = ["log", %[3]q]
+  critical_keywords = ["outage"]
+}
+`, projectName, url, eventType)
+}
+
+func TestProjectWebhookResourceCreate(t *testing.T) {
+	ctx := context.Background()
+	fake := clienttest.New("test_user")
+	// The dampening was set in the UI; leaving it unset must keep it
+	fake.Projects["test-project"] = &client.ProjectConfig{
+		ProjectName: "test-project",
+		Settings:    map[string]interface{}{"webhookAlertDampening": float64(45)},
+	}
+
+	r, s := newUnitTestResource(t, NewProjectWebhookResource, fake)
+	plan := newUnitTestPlan(t, s, projectWebhookResourceModel{
+		ProjectName:       types.StringValue("test-project"),
+		URL:               types.StringValue("https://hooks.example.com"),
+		Headers:           types.MapValueMust(types.StringType, map[string]attr.Value{"Authorization": types.StringValue("Bearer token")}),
+		EventTypes:        types.SetValueMust(types.StringType, []attr.Value{types.StringValue("log"), types.StringValue("detectedIncident")}),
+		BlacklistKeywords: types.SetNull(types.StringType),
+		CriticalKeywords:  types.SetNull(types.StringType),
+		AlertDampening:    types.Int64Unknown(),
+		Timeouts:          nullTimeouts,
+	})
+
+	resp := &fwresource.CreateResponse{State: newUnitTestState(t, s, nil)}
+	r.Create(ctx, fwresource.CreateRequest{Plan: plan}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Expected no error, got: %v", resp.Diagnostics.Errors())
+	}
+
+	var state projectWebhookResourceModel
+	resp.State.Get(ctx, &state)
+	if state.ID.ValueString() != "test-project" {
+		t.Errorf("Expected ID 'test-project', got %q", state.ID.ValueString())
+	}
+	if !state.AlertDampening.Equal(types.Int64Value(45)) {
+		t.Errorf("Expected alert_dampening 45 from the project, got %s", state.AlertDampening)
+	}
+
+	settings := fake.Projects["test-project"].Settings
+	if settings["webhookAlertDampening"] != float64(45) {
+		t.Errorf("Expected webhookAlertDampening to be kept, got %v", settings["webhookAlertDampening"])
+	}
+	if settings["webhookUrl"] != "https://hooks.example.com" {
+		t.Errorf("Expected webhookUrl to be set, got %v", settings["webhookUrl"])
+	}
+	if settings["webhookTypeSetStr"] != `["detectedIncident","log"]` {
+		t.Errorf("Expected event types as a JSON array, got %v", settings["webhookTypeSetStr"])
+	}
+	headers, _ := settings["webhookHeaderList"].([]interface{})
+	if len(headers) != 1 {
+		t.Errorf("Expected one header, got %v", settings["webhookHeaderList"])
+	}
+}
+
+func TestProjectWebhookResourceRead(t *testing.T) {
+	tests := []struct {
+		name          string
+		settings      map[string]interface{}
+		expectRemoved bool
+	}{
+		{
+			name: "configured webhook",
+			settings: map[string]interface{}{
+				"webhookUrl":             "https://hooks.example.com/changed",
+				"webhookTypeSetStr":      `["log"]`,
+				"webhookBlackListSetStr": "debug, trace",
+				"webhookAlertDampening":  float64(30),
+			},
+		},
+		{
+			name:          "no webhook URL",
+			settings:      map[string]interface{}{"webhookUrl": ""},
+			expectRemoved: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			fake := clienttest.New("test_user")
+			fake.Projects["test-project"] = &client.ProjectConfig{ProjectName: "test-project", Settings: tt.settings}
+
+			r, s := newUnitTestResource(t, NewProjectWebhookResource, fake)
+			current := newUnitTestState(t, s, projectWebhookResourceModel{
+				ID:                types.StringValue("test-project"),
+				ProjectName:       types.StringValue("test-project"),
+				URL:               types.StringValue("https://hooks.example.com"),
+				Headers:           types.MapNull(types.StringType),
+				EventTypes:        types.SetNull(types.StringType),
+				BlacklistKeywords: types.SetNull(types.StringType),
+				CriticalKeywords:  types.SetNull(types.StringType),
+				AlertDampening:    types.Int64Value(0),
+				Timeouts:          nullTimeouts,
+			})
+
+			resp := &fwresource.ReadResponse{State: current}
+			r.Read(ctx, fwresource.ReadRequest{State: current}, resp)
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("Expected no error, got: %v", resp.Diagnostics.Errors())
+			}
+
+			if tt.expectRemoved {
+				if !resp.State.Raw.IsNull() {
+					t.Error("Expected resource to be removed from state")
+				}
+				return
+			}
+
+			var state projectWebhookResourceModel
+			resp.State.Get(ctx, &state)
+			if state.URL.ValueString() != "https://hooks.example.com/changed" {
+				t.Errorf("Expected URL from the API, got %s", state.URL)
+			}
+			if !state.EventTypes.Equal(types.SetValueMust(types.StringType, []attr.Value{types.StringValue("log")})) {
+				t.Errorf("Expected event types from the API, got %s", state.EventTypes)
+			}
+			expectedBlacklist := types.SetValueMust(types.StringType, []attr.Value{types.StringValue("debug"), types.StringValue("trace")})
+			if !state.BlacklistKeywords.Equal(expectedBlacklist) {
+				t.Errorf("Expected comma separated keywords to be split, got %s", state.BlacklistKeywords)
+			}
+			if !state.Headers.IsNull() || !state.CriticalKeywords.IsNull() {
+				t.Errorf("Expected unset headers and critical keywords to stay null, got %s, %s", state.Headers, state.CriticalKeywords)
+			}
+			if !state.AlertDampening.Equal(types.Int64Value(30)) {
+				t.Errorf("Expected alert_dampening 30, got %s", state.AlertDampening)
+			}
+		})
+	}
+}
+
+func TestProjectWebhookResourceDelete(t *testing.T) {
+	ctx := context.Background()
+	fake := clienttest.New("test_user")
+	fake.Projects["test-project"] = &client.ProjectConfig{
+		ProjectName: "test-project",
+		Settings: map[string]interface{}{
+			"webhookUrl":        "https://hooks.example.com",
+			"webhookTypeSetStr": `["log"]`,
+			"enableHotEvent":    true,
+		},
+	}
+
+	r, s := newUnitTestResource(t, NewProjectWebhookResource, fake)
+	state := newUnitTestState(t, s, projectWebhookResourceModel{
+		ID:                types.StringValue("test-project"),
+		ProjectName:       types.StringValue("test-project"),
+		URL:               types.StringValue("https://hooks.example.com"),
+		Headers:           types.MapNull(types.StringType),
+		EventTypes:        types.SetNull(types.StringType),
+		BlacklistKeywords: types.SetNull(types.StringType),
+		CriticalKeywords:  types.SetNull(types.StringType),
+		AlertDampening:    types.Int64Value(0),
+		Timeouts:          nullTimeouts,
+	})
+
+	resp := &fwresource.DeleteResponse{State: state}
+	r.Delete(ctx, fwresource.DeleteRequest{State: state}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Expected no error, got: %v", resp.Diagnostics.Errors())
+	}
+
+	settings := fake.Projects["test-project"].Settings
+	if settings["webhookUrl"] != "" || settings["webhookTypeSetStr"] != "[]" {
+		t.Errorf("Expected webhook settings to be cleared, got %v", settings)
+	}
+	if settings["enableHotEvent"] != true {
+		t.Errorf("Expected other settings to be kept, got %v", settings)
+	}
+
+	// Deleting the webhook of a deleted project succeeds
+	delete(fake.Projects, "test-project")
+	resp = &fwresource.DeleteResponse{State: state}
+	r.Delete(ctx, fwresource.DeleteRequest{State: state}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Errorf("Expected no error for a missing project, got: %v", resp.Diagnostics.Errors())
+	}
+}
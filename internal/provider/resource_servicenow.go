@@ -5,15 +5,20 @@ package provider
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 
@@ -34,24 +39,25 @@ func NewServiceNowResource() resource.Resource {
 
 // servicenowResource is the resource implementation.
 type servicenowResource struct {
-	client *client.Client
+	client client.InsightFinderAPI
 }
 
 // servicenowResourceModel maps the resource schema data.
 type servicenowResourceModel struct {
-	ID              types.String `tfsdk:"id"`
-	Account         types.String `tfsdk:"account"`
-	ServiceHost     types.String `tfsdk:"service_host"`
-	Password        types.String `tfsdk:"password"`
-	Proxy           types.String `tfsdk:"proxy"`
-	DampeningPeriod types.Int64  `tfsdk:"dampening_period"`
-	AppID           types.String `tfsdk:"app_id"`
-	AppKey          types.String `tfsdk:"app_key"`
-	AuthType        types.String `tfsdk:"auth_type"`
-	SystemNames     types.List   `tfsdk:"system_names"`
-	SystemIDs       types.List   `tfsdk:"system_ids"`
-	Options         types.List   `tfsdk:"options"`
-	ContentOption   types.List   `tfsdk:"content_option"`
+	ID              types.String   `tfsdk:"id"`
+	Account         types.String   `tfsdk:"account"`
+	ServiceHost     types.String   `tfsdk:"service_host"`
+	Password        types.String   `tfsdk:"password"`
+	Proxy           types.String   `tfsdk:"proxy"`
+	DampeningPeriod types.Int64    `tfsdk:"dampening_period"`
+	AppID           types.String   `tfsdk:"app_id"`
+	AppKey          types.String   `tfsdk:"app_key"`
+	AuthType        types.String   `tfsdk:"auth_type"`
+	SystemNames     types.List     `tfsdk:"system_names"`
+	SystemIDs       types.List     `tfsdk:"system_ids"`
+	Options         types.List     `tfsdk:"options"`
+	ContentOption   types.List     `tfsdk:"content_option"`
+	Timeouts        timeouts.Value `tfsdk:"timeouts"`
 }
 
 // Metadata returns the resource type name.
@@ -60,7 +66,7 @@ func (r *servicenowResource) Metadata(_ context.Context, req resource.MetadataRe
 }
 
 // Schema defines the schema for the resource.
-func (r *servicenowResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+func (r *servicenowResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Description: "Manages InsightFinder ServiceNow integration.",
 		Attributes: map[string]schema.Attribute{
@@ -97,6 +103,9 @@ func (r *servicenowResource) Schema(_ context.Context, _ resource.SchemaRequest,
 			"dampening_period": schema.Int64Attribute{
 				Description: "Dampening period in seconds.",
 				Required:    true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
 			},
 			"app_id": schema.StringAttribute{
 				Description: "ServiceNow application ID (optional).",
@@ -112,6 +121,9 @@ func (r *servicenowResource) Schema(_ context.Context, _ resource.SchemaRequest,
 				Optional:    true,
 				Computed:    true,
 				Default:     stringdefault.StaticString("basic"),
+				Validators: []validator.String{
+					stringvalidator.OneOfCaseInsensitive("basic", "oauth"),
+				},
 			},
 			"system_names": schema.ListAttribute{
 				Description: "List of system names to integrate (will be resolved to system IDs).",
@@ -135,6 +147,14 @@ func (r *servicenowResource) Schema(_ context.Context, _ resource.SchemaRequest,
 				ElementType: types.StringType,
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
+		},
 	}
 }
 
@@ -144,11 +164,11 @@ func (r *servicenowResource) Configure(_ context.Context, req resource.Configure
 		return
 	}
 
-	client, ok := req.ProviderData.(*client.Client)
+	client, ok := req.ProviderData.(client.InsightFinderAPI)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected client.InsightFinderAPI, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
@@ -165,6 +185,15 @@ func (r *servicenowResource) Create(ctx context.Context, req resource.CreateRequ
 		return
 	}
 
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
 	tflog.Debug(ctx, "Creating ServiceNow config", map[string]interface{}{
 		"account":      plan.Account.ValueString(),
 		"service_host": plan.ServiceHost.ValueString(),
@@ -213,11 +242,11 @@ func (r *servicenowResource) Create(ctx context.Context, req resource.CreateRequ
 			return
 		}
 
-		resolvedIDs, err := r.client.ResolveSystemNameToIDs(systemNames, r.client.Username)
+		resolvedIDs, err := r.client.ResolveSystemNameToIDs(ctx, systemNames, r.client.CustomerName())
 		if err != nil {
 			resp.Diagnostics.AddError(
 				"Error Resolving System Names",
-				fmt.Sprintf("Could not resolve system names to IDs: %s", err.Error()),
+				fmt.Sprintf("Could not resolve system names to IDs: %s", apiErrorDetail(err)),
 			)
 			return
 		}
@@ -231,11 +260,11 @@ func (r *servicenowResource) Create(ctx context.Context, req resource.CreateRequ
 			return
 		}
 		// Resolve IDs to names
-		names, err := r.client.ResolveSystemIDsToNames(systemIDs, r.client.Username)
+		names, err := r.client.ResolveSystemIDsToNames(ctx, systemIDs, r.client.CustomerName())
 		if err != nil {
 			resp.Diagnostics.AddError(
 				"Error Resolving System IDs",
-				fmt.Sprintf("Could not resolve system IDs to names: %s", err.Error()),
+				fmt.Sprintf("Could not resolve system IDs to names: %s", apiErrorDetail(err)),
 			)
 			return
 		}
@@ -276,21 +305,21 @@ func (r *servicenowResource) Create(ctx context.Context, req resource.CreateRequ
 	}
 
 	// First call with verify=true
-	err := r.client.CreateOrUpdateServiceNowConfig(config, r.client.Username, true)
+	err := r.client.CreateOrUpdateServiceNowConfig(ctx, config, r.client.CustomerName(), true)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Creating ServiceNow Config (Verification)",
-			"Could not create ServiceNow config: "+err.Error(),
+			"Could not create ServiceNow config: "+apiErrorDetail(err),
 		)
 		return
 	}
 
 	// Second call without verify flag
-	err = r.client.CreateOrUpdateServiceNowConfig(config, r.client.Username, false)
+	err = r.client.CreateOrUpdateServiceNowConfig(ctx, config, r.client.CustomerName(), false)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Creating ServiceNow Config",
-			"Could not create ServiceNow config: "+err.Error(),
+			"Could not create ServiceNow config: "+apiErrorDetail(err),
 		)
 		return
 	}
@@ -327,6 +356,15 @@ func (r *servicenowResource) Read(ctx context.Context, req resource.ReadRequest,
 		return
 	}
 
+	readTimeout, diags := state.Timeouts.Read(ctx, defaultReadTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
 	tflog.Debug(ctx, "Reading ServiceNow config", map[string]interface{}{
 		"account":      state.Account.ValueString(),
 		"service_host": state.ServiceHost.ValueString(),
@@ -334,24 +372,23 @@ func (r *servicenowResource) Read(ctx context.Context, req resource.ReadRequest,
 
 	// Get current ServiceNow configuration
 	config, err := r.client.GetServiceNowConfig(
+		ctx,
 		state.Account.ValueString(),
 		state.ServiceHost.ValueString(),
-		r.client.Username,
+		r.client.CustomerName(),
 	)
+	if errors.Is(err, client.ErrNotFound) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Reading ServiceNow Config",
-			"Could not read ServiceNow config: "+err.Error(),
+			"Could not read ServiceNow config: "+apiErrorDetail(err),
 		)
 		return
 	}
 
-	// If config doesn't exist, remove from state
-	if config == nil {
-		resp.State.RemoveResource(ctx)
-		return
-	}
-
 	// Update state with current values
 	// Note: Don't update sensitive fields (password, app_id, app_key) if they come back empty
 	// as the API doesn't return the actual values for security reasons.
@@ -405,7 +442,7 @@ func (r *servicenowResource) Read(ctx context.Context, req resource.ReadRequest,
 
 		// If we didn't preserve state order, resolve fresh
 		if len(resolvedNames) == 0 {
-			if names, err := r.client.ResolveSystemIDsToNames(config.SystemIDs, r.client.Username); err == nil {
+			if names, err := r.client.ResolveSystemIDsToNames(ctx, config.SystemIDs, r.client.CustomerName()); err == nil {
 				config.SystemIDs, resolvedNames = alignSystemMappings(config.SystemIDs, names)
 			} else if len(config.SystemNames) > 0 {
 				config.SystemIDs, resolvedNames = alignSystemMappings(config.SystemIDs, config.SystemNames)
@@ -473,6 +510,15 @@ func (r *servicenowResource) Update(ctx context.Context, req resource.UpdateRequ
 		return
 	}
 
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
 	var priorState servicenowResourceModel
 	diags = req.State.Get(ctx, &priorState)
 	resp.Diagnostics.Append(diags...)
@@ -544,11 +590,11 @@ func (r *servicenowResource) Update(ctx context.Context, req resource.UpdateRequ
 			return
 		}
 
-		resolvedIDs, err := r.client.ResolveSystemNameToIDs(systemNames, r.client.Username)
+		resolvedIDs, err := r.client.ResolveSystemNameToIDs(ctx, systemNames, r.client.CustomerName())
 		if err != nil {
 			resp.Diagnostics.AddError(
 				"Error Resolving System Names",
-				fmt.Sprintf("Could not resolve system names to IDs: %s", err.Error()),
+				fmt.Sprintf("Could not resolve system names to IDs: %s", apiErrorDetail(err)),
 			)
 			return
 		}
@@ -562,11 +608,11 @@ func (r *servicenowResource) Update(ctx context.Context, req resource.UpdateRequ
 			return
 		}
 		// Resolve IDs to names
-		names, err := r.client.ResolveSystemIDsToNames(systemIDs, r.client.Username)
+		names, err := r.client.ResolveSystemIDsToNames(ctx, systemIDs, r.client.CustomerName())
 		if err != nil {
 			resp.Diagnostics.AddError(
 				"Error Resolving System IDs",
-				fmt.Sprintf("Could not resolve system IDs to names: %s", err.Error()),
+				fmt.Sprintf("Could not resolve system IDs to names: %s", apiErrorDetail(err)),
 			)
 			return
 		}
@@ -607,21 +653,21 @@ func (r *servicenowResource) Update(ctx context.Context, req resource.UpdateRequ
 	}
 
 	// First call with verify=true
-	err := r.client.CreateOrUpdateServiceNowConfig(config, r.client.Username, true)
+	err := r.client.CreateOrUpdateServiceNowConfig(ctx, config, r.client.CustomerName(), true)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Updating ServiceNow Config (Verification)",
-			"Could not update ServiceNow config: "+err.Error(),
+			"Could not update ServiceNow config: "+apiErrorDetail(err),
 		)
 		return
 	}
 
 	// Second call without verify flag
-	err = r.client.CreateOrUpdateServiceNowConfig(config, r.client.Username, false)
+	err = r.client.CreateOrUpdateServiceNowConfig(ctx, config, r.client.CustomerName(), false)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Updating ServiceNow Config",
-			"Could not update ServiceNow config: "+err.Error(),
+			"Could not update ServiceNow config: "+apiErrorDetail(err),
 		)
 		return
 	}
@@ -665,20 +711,33 @@ func (r *servicenowResource) Delete(ctx context.Context, req resource.DeleteRequ
 		return
 	}
 
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, defaultDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
 	tflog.Debug(ctx, "Deleting ServiceNow config", map[string]interface{}{
 		"account":      state.Account.ValueString(),
 		"service_host": state.ServiceHost.ValueString(),
 	})
 
 	err := r.client.DeleteServiceNowConfig(
+		ctx,
 		state.Account.ValueString(),
 		state.ServiceHost.ValueString(),
-		r.client.Username,
+		r.client.CustomerName(),
 	)
+	if errors.Is(err, client.ErrNotFound) {
+		return
+	}
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Deleting ServiceNow Config",
-			"Could not delete ServiceNow config: "+err.Error(),
+			"Could not delete ServiceNow config: "+apiErrorDetail(err),
 		)
 		return
 	}
@@ -5,6 +5,7 @@ package provider
 
 import (
 	"fmt"
+	"reflect"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
@@ -243,3 +244,54 @@ resource "insightfinder_servicenow" "test" {
 }
 `, account, serviceHost, username, password, dampeningPeriod, systemNamesStr)
 }
+
+func TestAlignSystemMappings(t *testing.T) {
+	tests := []struct {
+		name          string
+		ids           []string
+		names         []string
+		expectedIDs   []string
+		expectedNames []string
+	}{
+		{
+			name:          "no IDs",
+			ids:           nil,
+			names:         []string{"system1"},
+			expectedIDs:   []string{},
+			expectedNames: []string{"system1"},
+		},
+		{
+			name:          "aligned",
+			ids:           []string{"id1", "id2"},
+			names:         []string{"system1", "system2"},
+			expectedIDs:   []string{"id1", "id2"},
+			expectedNames: []string{"system1", "system2"},
+		},
+		{
+			name:          "missing names fall back to IDs",
+			ids:           []string{"id1", "id2"},
+			names:         []string{"system1"},
+			expectedIDs:   []string{"id1", "id2"},
+			expectedNames: []string{"system1", "id2"},
+		},
+		{
+			name:          "duplicates and blanks removed",
+			ids:           []string{" id1 ", "", "id2", "id1"},
+			names:         []string{"system1", "", "system2", "system1"},
+			expectedIDs:   []string{"id1", "id2"},
+			expectedNames: []string{"system1", "system2"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ids, names := alignSystemMappings(tt.ids, tt.names)
+			if !reflect.DeepEqual(ids, tt.expectedIDs) {
+				t.Errorf("Expected IDs %v, got %v", tt.expectedIDs, ids)
+			}
+			if !reflect.DeepEqual(names, tt.expectedNames) {
+				t.Errorf("Expected names %v, got %v", tt.expectedNames, names)
+			}
+		})
+	}
+}
@@ -0,0 +1,17 @@
+// Copyright (c) InsightFinder Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import "time"
+
+// Default operation deadlines used when a resource's timeouts block does not
+// override them. Individual API calls inherit the operation deadline, so a
+// slow watch-tower-setting update is bounded by the update timeout rather
+// than by a fixed per-request limit.
+const (
+	defaultCreateTimeout = 10 * time.Minute
+	defaultReadTimeout   = 5 * time.Minute
+	defaultUpdateTimeout = 10 * time.Minute
+	defaultDeleteTimeout = 5 * time.Minute
+)
@@ -0,0 +1,327 @@
+// Copyright (c) InsightFinder Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/url"
+	"strconv"
+	"time"
+
+	// Embed the time zone database so time zones validate the same on
+	// hosts without one, such as Windows.
+	_ "time/tzdata"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/insightfinder/terraform-provider-insightfinder/internal/provider/client"
+)
+
+var (
+	_ validator.String = httpURLValidator{}
+	_ validator.String = timeZoneValidator{}
+	_ validator.String = numericStringValidator{}
+	_ validator.String = jsonStringValidator{}
+	_ validator.String = logLabelStringValidator{}
+)
+
+// httpURLValidator checks that a string is an absolute http or https URL.
+type httpURLValidator struct{}
+
+// httpURL returns a validator for absolute http and https URLs.
+func httpURL() validator.String {
+	return httpURLValidator{}
+}
+
+// Description describes the validation in plain text formatting.
+func (v httpURLValidator) Description(_ context.Context) string {
+	return "value must be an absolute http or https URL"
+}
+
+// MarkdownDescription describes the validation in Markdown formatting.
+func (v httpURLValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+// ValidateString performs the validation.
+func (v httpURLValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	value := req.ConfigValue.ValueString()
+	parsed, err := url.Parse(value)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid URL",
+			fmt.Sprintf("Attribute %s %s, got: %q", req.Path, v.Description(ctx), value),
+		)
+	}
+}
+
+// timeZoneValidator checks that a string is an IANA time zone name.
+type timeZoneValidator struct{}
+
+// timeZone returns a validator for IANA time zone names such as UTC or
+// America/New_York.
+func timeZone() validator.String {
+	return timeZoneValidator{}
+}
+
+// Description describes the validation in plain text formatting.
+func (v timeZoneValidator) Description(_ context.Context) string {
+	return "value must be an IANA time zone name, such as UTC or America/New_York"
+}
+
+// MarkdownDescription describes the validation in Markdown formatting.
+func (v timeZoneValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+// ValidateString performs the validation.
+func (v timeZoneValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	// LoadLocation treats "" as UTC and "Local" as the host's zone; neither
+	// means anything to InsightFinder.
+	value := req.ConfigValue.ValueString()
+	if _, err := time.LoadLocation(value); err != nil || value == "" || value == "Local" {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Time Zone",
+			fmt.Sprintf("Attribute %s %s, got: %q", req.Path, v.Description(ctx), value),
+		)
+	}
+}
+
+// numericStringValidator checks that a string holds a number within
+// [min, max]. Several watch-tower settings are numbers sent as strings.
+type numericStringValidator struct {
+	min float64
+	max float64
+}
+
+// numericStringBetween returns a validator for strings holding a number
+// between min and max, inclusive.
+func numericStringBetween(min, max float64) validator.String {
+	return numericStringValidator{min: min, max: max}
+}
+
+// numericStringAtLeast returns a validator for strings holding a number of
+// at least min.
+func numericStringAtLeast(min float64) validator.String {
+	return numericStringValidator{min: min, max: math.Inf(1)}
+}
+
+// Description describes the validation in plain text formatting.
+func (v numericStringValidator) Description(_ context.Context) string {
+	if math.IsInf(v.max, 1) {
+		return fmt.Sprintf("value must be a number of at least %g", v.min)
+	}
+	return fmt.Sprintf("value must be a number between %g and %g", v.min, v.max)
+}
+
+// MarkdownDescription describes the validation in Markdown formatting.
+func (v numericStringValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+// ValidateString performs the validation.
+func (v numericStringValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	value := req.ConfigValue.ValueString()
+	number, err := strconv.ParseFloat(value, 64)
+	if err != nil || math.IsNaN(number) || number < v.min || number > v.max {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Attribute Value",
+			fmt.Sprintf("Attribute %s %s, got: %q", req.Path, v.Description(ctx), value),
+		)
+	}
+}
+
+// jsonShape is the kind of JSON value a JSON-encoded string attribute must
+// hold.
+type jsonShape int
+
+const (
+	jsonArray jsonShape = iota
+	jsonObject
+	jsonArrayOfStrings
+	jsonArrayOfObjects
+)
+
+// String describes the shape for diagnostics.
+func (s jsonShape) String() string {
+	switch s {
+	case jsonObject:
+		return "a JSON object"
+	case jsonArrayOfStrings:
+		return "a JSON array of strings"
+	case jsonArrayOfObjects:
+		return "a JSON array of objects"
+	default:
+		return "a JSON array"
+	}
+}
+
+// decodeJSONShape decodes value and checks that it holds the given shape.
+// The error describes what was found instead, never the value itself, as
+// some JSON attributes are sensitive.
+func decodeJSONShape(value string, shape jsonShape) (interface{}, error) {
+	var decoded interface{}
+	if err := json.Unmarshal([]byte(value), &decoded); err != nil {
+		return nil, fmt.Errorf("must hold %s, but is not valid JSON: %w", shape, err)
+	}
+
+	if shape == jsonObject {
+		if _, ok := decoded.(map[string]interface{}); !ok {
+			return nil, fmt.Errorf("must hold %s, got %s", shape, jsonKind(decoded))
+		}
+		return decoded, nil
+	}
+
+	items, ok := decoded.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("must hold %s, got %s", shape, jsonKind(decoded))
+	}
+	for i, item := range items {
+		switch shape {
+		case jsonArrayOfStrings:
+			if _, ok := item.(string); !ok {
+				return nil, fmt.Errorf("must hold %s, got %s at index %d", shape, jsonKind(item), i)
+			}
+		case jsonArrayOfObjects:
+			if _, ok := item.(map[string]interface{}); !ok {
+				return nil, fmt.Errorf("must hold %s, got %s at index %d", shape, jsonKind(item), i)
+			}
+		}
+	}
+	return decoded, nil
+}
+
+// jsonKind names the kind of a decoded JSON value.
+func jsonKind(value interface{}) string {
+	switch value.(type) {
+	case nil:
+		return "null"
+	case map[string]interface{}:
+		return "an object"
+	case []interface{}:
+		return "an array"
+	case string:
+		return "a string"
+	case float64:
+		return "a number"
+	case bool:
+		return "a boolean"
+	default:
+		return fmt.Sprintf("%T", value)
+	}
+}
+
+// jsonStringValidator checks that a string holds JSON of a given shape.
+type jsonStringValidator struct {
+	shape jsonShape
+}
+
+// jsonString returns a validator for strings holding JSON of the given
+// shape, such as the output of jsonencode().
+func jsonString(shape jsonShape) validator.String {
+	return jsonStringValidator{shape: shape}
+}
+
+// Description describes the validation in plain text formatting.
+func (v jsonStringValidator) Description(_ context.Context) string {
+	return fmt.Sprintf("value must hold %s", v.shape)
+}
+
+// MarkdownDescription describes the validation in Markdown formatting.
+func (v jsonStringValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+// ValidateString performs the validation. Empty strings are accepted, as
+// they leave the setting unchanged.
+func (v jsonStringValidator) ValidateString(_ context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() || req.ConfigValue.ValueString() == "" {
+		return
+	}
+
+	if _, err := decodeJSONShape(req.ConfigValue.ValueString(), v.shape); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid JSON Value",
+			fmt.Sprintf("Attribute %s %s.", req.Path, err),
+		)
+	}
+}
+
+// logLabelStringValidator checks that a log_label_string holds a JSON array.
+// Entries not of the shape its sibling label_type expects, as listed in
+// client.LabelTypes, are accepted with a deprecation warning: earlier
+// versions documented rule objects for every label type.
+type logLabelStringValidator struct{}
+
+// logLabelString returns a validator for the log_label_string of a label
+// setting.
+func logLabelString() validator.String {
+	return logLabelStringValidator{}
+}
+
+// Description describes the validation in plain text formatting.
+func (v logLabelStringValidator) Description(_ context.Context) string {
+	return "value must be a JSON array, preferably of the entries label_type expects"
+}
+
+// MarkdownDescription describes the validation in Markdown formatting.
+func (v logLabelStringValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+// ValidateString performs the validation. Without a known label_type only
+// the JSON array is checked; label_type reports unknown types itself.
+func (v logLabelStringValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if _, err := decodeJSONShape(req.ConfigValue.ValueString(), jsonArray); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Log Labels",
+			fmt.Sprintf("Attribute %s %s.", req.Path, err),
+		)
+		return
+	}
+
+	var labelType types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, req.Path.ParentPath().AtName("label_type"), &labelType)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	t, ok := client.LookupLabelType(labelType.ValueString())
+	if !ok {
+		return
+	}
+	if err := t.CheckLabels(req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeWarning(
+			req.Path,
+			"Deprecated Log Label Shape",
+			fmt.Sprintf("Attribute %s %s. The labels are still sent as written, but this shape is deprecated "+
+				"and may be rejected in a future version; label type %s expects %s.", req.Path, err, t.Name, t.Shape),
+		)
+	}
+}
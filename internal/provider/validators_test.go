@@ -0,0 +1,65 @@
+// Copyright (c) InsightFinder Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestStringValidators(t *testing.T) {
+	cases := []struct {
+		name      string
+		validator validator.String
+		value     types.String
+		wantError bool
+	}{
+		{"url https", httpURL(), types.StringValue("https://hooks.example.com/if"), false},
+		{"url without scheme", httpURL(), types.StringValue("hooks.example.com"), true},
+		{"url ftp", httpURL(), types.StringValue("ftp://hooks.example.com"), true},
+		{"time zone UTC", timeZone(), types.StringValue("UTC"), false},
+		{"time zone region", timeZone(), types.StringValue("America/New_York"), false},
+		{"time zone unknown name", timeZone(), types.StringValue("Mars/Olympus_Mons"), true},
+		{"time zone wrong case", timeZone(), types.StringValue("america/new_york"), true},
+		{"time zone empty", timeZone(), types.StringValue(""), true},
+		{"time zone local", timeZone(), types.StringValue("Local"), true},
+		{"time zone null", timeZone(), types.StringNull(), false},
+		{"time zone unknown", timeZone(), types.StringUnknown(), false},
+		{"number in range", numericStringBetween(0, 1), types.StringValue("0.75"), false},
+		{"number at bound", numericStringBetween(0, 1), types.StringValue("1"), false},
+		{"number above range", numericStringBetween(0, 1), types.StringValue("1.5"), true},
+		{"number not numeric", numericStringBetween(0, 1), types.StringValue("high"), true},
+		{"number NaN", numericStringAtLeast(0), types.StringValue("NaN"), true},
+		{"number unbounded", numericStringAtLeast(0), types.StringValue("250"), false},
+		{"number negative", numericStringAtLeast(0), types.StringValue("-1"), true},
+		{"json array", jsonString(jsonArray), types.StringValue(`[1,"a",{}]`), false},
+		{"json array given object", jsonString(jsonArray), types.StringValue(`{}`), true},
+		{"json array given string", jsonString(jsonArray), types.StringValue(`plain`), true},
+		{"json empty", jsonString(jsonArray), types.StringValue(""), false},
+		{"json object", jsonString(jsonObject), types.StringValue(`{"a":[1]}`), false},
+		{"json object given null", jsonString(jsonObject), types.StringValue(`null`), true},
+		{"json strings", jsonString(jsonArrayOfStrings), types.StringValue(`["a","b"]`), false},
+		{"json strings given number", jsonString(jsonArrayOfStrings), types.StringValue(`["a",1]`), true},
+		{"json objects", jsonString(jsonArrayOfObjects), types.StringValue(`[{"key":"a"}]`), false},
+		{"json objects given string", jsonString(jsonArrayOfObjects), types.StringValue(`["a"]`), true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			resp := &validator.StringResponse{}
+			tc.validator.ValidateString(context.Background(), validator.StringRequest{
+				Path:        path.Root("test"),
+				ConfigValue: tc.value,
+			}, resp)
+
+			if resp.Diagnostics.HasError() != tc.wantError {
+				t.Errorf("Expected error %t, got diagnostics: %v", tc.wantError, resp.Diagnostics)
+			}
+		})
+	}
+}